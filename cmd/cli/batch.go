@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func submitBatchCmd() *cobra.Command {
+	var file string
+	var batchSize int
+
+	cmd := &cobra.Command{
+		Use:   "submit-batch",
+		Short: "Submit jobs from an NDJSON file or stdin",
+		Long: `Submit jobs from NDJSON: one SubmitJob request per line, e.g.
+
+  {"type": "notification:email", "payload": "{\"to\": \"a@example.com\", \"subject\": \"Hi\", \"body\": \"...\"}"}
+  {"type": "finance:invoice", "payload": "{...}", "priority": 5, "idempotency_key": "inv-42"}
+
+Blank lines are ignored. Jobs are sent in batches of --batch-size; a rejected job
+is reported with its line number and does not stop the others.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var in io.Reader = os.Stdin
+			if file != "-" {
+				f, err := os.Open(file)
+				if err != nil {
+					log.Fatalf("Failed to open %s: %v", file, err)
+				}
+				defer f.Close()
+				in = f
+			}
+
+			conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				log.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+			client := pb.NewJobSchedulerClient(conn)
+
+			var submitted, rejected int
+			var jobs []*pb.SubmitJobRequest
+			var lines []int
+
+			flush := func() {
+				if len(jobs) == 0 {
+					return
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+
+				resp, err := client.SubmitJobs(ctx, &pb.SubmitJobsRequest{Jobs: jobs})
+				if err != nil {
+					log.Fatalf("Failed to submit lines %d-%d: %v", lines[0], lines[len(lines)-1], err)
+				}
+
+				for i, r := range resp.Results {
+					switch {
+					case r.Error != "":
+						rejected++
+						fmt.Printf("✗ line %d: %s\n", lines[i], r.Error)
+					case r.Duplicate:
+						submitted++
+						fmt.Printf("✓ line %d: job %s (duplicate)\n", lines[i], r.JobId)
+					default:
+						submitted++
+						fmt.Printf("✓ line %d: job %s\n", lines[i], r.JobId)
+					}
+				}
+				jobs, lines = nil, nil
+			}
+
+			scanner := bufio.NewScanner(in)
+			scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+			lineNo := 0
+			for scanner.Scan() {
+				lineNo++
+				line := strings.TrimSpace(scanner.Text())
+				if line == "" {
+					continue
+				}
+
+				var req pb.SubmitJobRequest
+				if err := protojson.Unmarshal([]byte(line), &req); err != nil {
+					rejected++
+					fmt.Printf("✗ line %d: invalid JSON: %v\n", lineNo, err)
+					continue
+				}
+
+				jobs = append(jobs, &req)
+				lines = append(lines, lineNo)
+				if len(jobs) >= batchSize {
+					flush()
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				log.Fatalf("Failed to read input: %v", err)
+			}
+			flush()
+
+			fmt.Printf("\nSubmitted: %d  Rejected: %d\n", submitted, rejected)
+			if rejected > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "-", "NDJSON file to read, - for stdin")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 500, "Jobs per SubmitJobs request (max 1000)")
+
+	return cmd
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"github.com/spf13/cobra"
+)
+
+func dlqCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dlq",
+		Short: "Manage dead-lettered jobs",
+	}
+
+	cmd.AddCommand(dlqListCmd())
+	cmd.AddCommand(dlqRetryCmd())
+
+	return cmd
+}
+
+func dlqRetryCmd() *cobra.Command {
+	var jobIDs []string
+	var jobType, errorContains, failedAfter, failedBefore, payload string
+
+	cmd := &cobra.Command{
+		Use:   "retry",
+		Short: "Requeue dead-lettered jobs by id or by filter",
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				// a single job can be replayed with an edited payload
+				if payload != "" {
+					if len(jobIDs) != 1 {
+						log.Fatalf("--data requires exactly one --id")
+					}
+					resp, err := client.RetryDeadJob(ctx, &pb.RetryDeadJobRequest{JobId: jobIDs[0], Payload: payload})
+					if err != nil {
+						log.Fatalf("Failed to retry dead job: %v", err)
+					}
+					fmt.Printf("✓ Job %s requeued (replay #%d)\n", resp.JobId, resp.ReplayCount)
+					return
+				}
+
+				resp, err := client.RetryDeadJobs(ctx, &pb.RetryDeadJobsRequest{
+					JobIds:        jobIDs,
+					Type:          jobType,
+					ErrorContains: errorContains,
+					FailedAfter:   failedAfter,
+					FailedBefore:  failedBefore,
+				})
+				if err != nil {
+					log.Fatalf("Failed to retry dead jobs: %v", err)
+				}
+
+				fmt.Printf("✓ %d job(s) requeued\n", len(resp.JobIds))
+				if len(resp.JobIds) > 0 {
+					fmt.Printf("  Job IDs: %s\n", strings.Join(resp.JobIds, ", "))
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&jobIDs, "id", nil, "Dead job ID (repeatable)")
+	cmd.Flags().StringVar(&jobType, "type", "", "Only jobs of this type")
+	cmd.Flags().StringVar(&errorContains, "error", "", "Only jobs whose last error contains this text")
+	cmd.Flags().StringVar(&failedAfter, "since", "", "Only jobs dead-lettered at or after this time (RFC 3339)")
+	cmd.Flags().StringVar(&failedBefore, "until", "", "Only jobs dead-lettered before this time (RFC 3339)")
+	cmd.Flags().StringVar(&payload, "data", "", "Replacement payload (JSON); requires a single --id")
+
+	return cmd
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"github.com/spf13/cobra"
+)
+
+// listFlags registers the filter and sort flags shared by list and dlq list.
+// dead leaves out the filters dead jobs do not support.
+func listFlags(cmd *cobra.Command, req *pb.ListJobRequest, dead bool) func() {
+	var minPriority, minRetries, maxRetries int32
+
+	cmd.Flags().Int32Var(&req.Limit, "limit", 20, "Jobs per page")
+	cmd.Flags().Int32Var(&req.Offset, "offset", 0, "Jobs to skip")
+	cmd.Flags().StringVar(&req.PageToken, "page-token", "", "Continue after a previous page (printed as the next page token)")
+	cmd.Flags().StringVar(&req.TotalCount, "total", "", "How to count matching jobs: exact, estimated or none")
+	cmd.Flags().StringVar(&req.Type, "type", "", "Only jobs of this type")
+	cmd.Flags().StringVar(&req.Queue, "queue", "", "Only jobs on this queue")
+	cmd.Flags().Int32Var(&minPriority, "min-priority", 0, "Only jobs with at least this priority")
+	cmd.Flags().Int32Var(&minRetries, "min-retries", 0, "Only jobs retried at least this many times")
+	cmd.Flags().Int32Var(&maxRetries, "max-retries", 0, "Only jobs retried at most this many times")
+	cmd.Flags().StringVar(&req.ErrorContains, "error", "", "Only jobs whose last error contains this text")
+	if dead {
+		cmd.Flags().StringVar(&req.CompletedAfter, "since", "", "Only jobs dead-lettered at or after this time (RFC 3339)")
+		cmd.Flags().StringVar(&req.CompletedBefore, "until", "", "Only jobs dead-lettered before this time (RFC 3339)")
+		cmd.Flags().StringVar(&req.SortBy, "sort", "", "Sort by completed_at (default), priority or retry_count")
+	} else {
+		cmd.Flags().StringSliceVar(&req.Status, "status", nil, "Only jobs in these statuses (repeatable)")
+		cmd.Flags().StringVar(&req.CreatedAfter, "created-after", "", "Only jobs created at or after this time (RFC 3339)")
+		cmd.Flags().StringVar(&req.CreatedBefore, "created-before", "", "Only jobs created before this time (RFC 3339)")
+		cmd.Flags().StringVar(&req.CompletedAfter, "completed-after", "", "Only jobs completed at or after this time (RFC 3339)")
+		cmd.Flags().StringVar(&req.CompletedBefore, "completed-before", "", "Only jobs completed before this time (RFC 3339)")
+		cmd.Flags().StringVar(&req.SortBy, "sort", "", "Sort by created_at (default), completed_at, priority or retry_count")
+	}
+	cmd.Flags().StringVar(&req.SortOrder, "order", "desc", "Sort order: asc or desc")
+
+	// optional filters are only sent when their flag was set
+	return func() {
+		if cmd.Flags().Changed("min-priority") {
+			req.MinPriority = &minPriority
+		}
+		if cmd.Flags().Changed("min-retries") {
+			req.MinRetries = &minRetries
+		}
+		if cmd.Flags().Changed("max-retries") {
+			req.MaxRetries = &maxRetries
+		}
+	}
+}
+
+func listCmd() *cobra.Command {
+	var req pb.ListJobRequest
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List jobs with filters and sorting",
+	}
+	resolve := listFlags(cmd, &req, false)
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		resolve()
+		withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+			resp, err := client.ListJobs(ctx, &req)
+			if err != nil {
+				log.Fatalf("Failed to list jobs: %v", err)
+			}
+			printJobs(resp)
+		})
+	}
+
+	return cmd
+}
+
+func dlqListCmd() *cobra.Command {
+	var req pb.ListJobRequest
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List dead-lettered jobs with filters and sorting",
+	}
+	resolve := listFlags(cmd, &req, true)
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		resolve()
+		withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+			resp, err := client.ListDeadJobs(ctx, &req)
+			if err != nil {
+				log.Fatalf("Failed to list dead jobs: %v", err)
+			}
+			printJobs(resp)
+		})
+	}
+
+	return cmd
+}
+
+func printJobs(resp *pb.ListJobResponse) {
+	if len(resp.Jobs) == 0 {
+		fmt.Println("No jobs found")
+		return
+	}
+
+	fmt.Printf("  %-8s %-10s %-22s %-14s %-7s %-20s %s\n", "ID", "STATUS", "TYPE", "QUEUE", "RETRIES", "CREATED", "ERROR")
+	for _, j := range resp.Jobs {
+		errMsg := j.ErrorMessage
+		if len(errMsg) > 60 {
+			errMsg = errMsg[:57] + "..."
+		}
+		fmt.Printf("  %-8s %-10s %-22s %-14s %-7s %-20s %s\n", j.JobId, j.Status, j.Type, j.Queue, j.RetryCount, j.CreatedAt,
+			strings.ReplaceAll(errMsg, "\n", " "))
+	}
+
+	if resp.Meta != nil && resp.Meta.TotalCount != "none" {
+		total := fmt.Sprintf("%d jobs", resp.Meta.TotalRecords)
+		if resp.Meta.TotalCount == "estimated" {
+			total = fmt.Sprintf("~%d jobs, estimated", resp.Meta.TotalRecords)
+		}
+		if resp.Meta.CurrentPage > 0 {
+			fmt.Printf("\nPage %d of %d (%s)\n", resp.Meta.CurrentPage, resp.Meta.TotalPages, total)
+		} else {
+			fmt.Printf("\n%s\n", total)
+		}
+	}
+	if resp.NextPageToken != "" {
+		fmt.Printf("Next page: --page-token %s\n", resp.NextPageToken)
+	}
+}
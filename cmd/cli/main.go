@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"time"
 
@@ -29,7 +30,16 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&serverAddr, "server", grpcHost, "gRPC server address")
 
 	rootCmd.AddCommand(submitCmd())
+	rootCmd.AddCommand(submitBatchCmd())
 	rootCmd.AddCommand(getCmd())
+	rootCmd.AddCommand(listCmd())
+	rootCmd.AddCommand(watchCmd())
+	rootCmd.AddCommand(attemptsCmd())
+	rootCmd.AddCommand(statsCmd())
+	rootCmd.AddCommand(cancelCmd())
+	rootCmd.AddCommand(dlqCmd())
+	rootCmd.AddCommand(scheduleCmd())
+	rootCmd.AddCommand(workflowCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
@@ -37,7 +47,11 @@ func main() {
 }
 
 func submitCmd() *cobra.Command {
-	var jobType, payload string
+	var jobType, payload, runAt, queue, idempotencyKey string
+	var delay time.Duration
+	var priority int32
+	var retry pb.RetryPolicy
+	var baseDelay, maxDelay, timeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "submit",
@@ -52,15 +66,34 @@ func submitCmd() *cobra.Command {
 			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 			defer cancel()
 
+			var retryPolicy *pb.RetryPolicy
+			if cmd.Flags().Changed("max-attempts") || cmd.Flags().Changed("backoff") || cmd.Flags().Changed("base-delay") ||
+				cmd.Flags().Changed("max-delay") || cmd.Flags().Changed("jitter") {
+				retry.BaseDelayMs = baseDelay.Milliseconds()
+				retry.MaxDelayMs = maxDelay.Milliseconds()
+				retryPolicy = &retry
+			}
+
 			resp, err := client.SubmitJob(ctx, &pb.SubmitJobRequest{
-				Type:    jobType,
-				Payload: payload,
+				Type:           jobType,
+				Payload:        payload,
+				RunAt:          runAt,
+				DelaySeconds:   int64(delay / time.Second),
+				Priority:       priority,
+				Queue:          queue,
+				IdempotencyKey: idempotencyKey,
+				RetryPolicy:    retryPolicy,
+				TimeoutMs:      timeout.Milliseconds(),
 			})
 			if err != nil {
 				log.Fatalf("Failed to submit job: %v", err)
 			}
 
-			fmt.Printf("✓ Job submitted successfully\n")
+			if resp.Duplicate {
+				fmt.Printf("✓ Job already submitted (same idempotency key or unique job)\n")
+			} else {
+				fmt.Printf("✓ Job submitted successfully\n")
+			}
 			fmt.Printf("  Job ID: %s\n", resp.JobId)
 			fmt.Printf("  Status: %s\n", resp.Status)
 		},
@@ -68,6 +101,18 @@ func submitCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&jobType, "type", "dummy", "Job type")
 	cmd.Flags().StringVar(&payload, "data", "{}", "Job payload (JSON)")
+	cmd.Flags().StringVar(&runAt, "run-at", "", "Run the job at a specific time (RFC 3339, e.g. 2026-01-30T09:00:00Z)")
+	cmd.Flags().DurationVar(&delay, "delay", 0, "Run the job after a delay (e.g. 30m, 72h)")
+	cmd.Flags().Int32Var(&priority, "priority", 0, "Job priority (higher runs first)")
+	cmd.Flags().StringVar(&queue, "queue", "", "Queue to run the job on (defaults to the job type's queue)")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Key that makes retried submissions return the original job")
+	cmd.Flags().Int32Var(&retry.MaxAttempts, "max-attempts", 0, "Failures before the job is dead-lettered (overrides the job type's policy)")
+	cmd.Flags().StringVar(&retry.Backoff, "backoff", "", "Retry backoff: exponential, linear or fixed")
+	cmd.Flags().DurationVar(&baseDelay, "base-delay", 0, "Delay before the first retry (e.g. 500ms, 10s)")
+	cmd.Flags().DurationVar(&maxDelay, "max-delay", 0, "Upper bound for retry delays")
+	cmd.Flags().Float64Var(&retry.Jitter, "jitter", 0, "Randomize retry delays by up to this fraction (0-1)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Execution timeout for this job (overrides the job type's timeout)")
+	cmd.MarkFlagsMutuallyExclusive("run-at", "delay")
 
 	return cmd
 }
@@ -98,9 +143,32 @@ func getCmd() *cobra.Command {
 			fmt.Printf("  ID:             %s\n", resp.JobId)
 			fmt.Printf("  Type:           %s\n", resp.Type)
 			fmt.Printf("  Status:         %s\n", resp.Status)
+			fmt.Printf("  Queue:          %s\n", resp.Queue)
+			fmt.Printf("  Priority:       %d\n", resp.Priority)
 			fmt.Printf("  Payload:    	  %s\n", resp.Payload)
 			fmt.Printf("  Created:    	  %s\n", resp.CreatedAt)
 			fmt.Printf("  Retry Count:    %s\n", resp.RetryCount)
+			if resp.IdempotencyKey != "" {
+				fmt.Printf("  Idempotency Key: %s\n", resp.IdempotencyKey)
+			}
+			if resp.TimeoutMs > 0 {
+				fmt.Printf("  Timeout:        %s\n", time.Duration(resp.TimeoutMs)*time.Millisecond)
+			}
+			if resp.LockedBy != "" {
+				fmt.Printf("  Locked By:      %s (lease until %s)\n", resp.LockedBy, resp.LeaseExpiresAt)
+			}
+			if resp.ReplayCount > 0 {
+				fmt.Printf("  Replayed:       %d time(s), last at %s\n", resp.ReplayCount, resp.ReplayedAt)
+			}
+			if resp.Status == "pending" {
+				fmt.Printf("  Next Run:       %s\n", resp.NextRunAt)
+			}
+			if resp.Progress != nil {
+				fmt.Printf("  Progress:       %s\n", formatProgress(resp))
+			}
+			if resp.Result != "" {
+				fmt.Printf("  Result:         %s\n", resp.Result)
+			}
 			if resp.CompletedAt != "" {
 				fmt.Printf("  Completed:      %s\n", resp.CompletedAt)
 			}
@@ -116,3 +184,120 @@ func getCmd() *cobra.Command {
 
 	return cmd
 }
+
+func watchCmd() *cobra.Command {
+	var jobID string
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Follow a job's status and progress until it finishes",
+		Run: func(cmd *cobra.Command, args []string) {
+			conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				log.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			client := pb.NewJobSchedulerClient(conn)
+			stream, err := client.WatchJob(context.Background(), &pb.GetJobRequest{JobId: jobID})
+			if err != nil {
+				log.Fatalf("Failed to watch job: %v", err)
+			}
+
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					return
+				}
+				if err != nil {
+					log.Fatalf("Failed to watch job: %v", err)
+				}
+
+				line := fmt.Sprintf("[%s] %s", time.Now().Format("15:04:05"), resp.Status)
+				if resp.Progress != nil {
+					line += "  " + formatProgress(resp)
+				}
+				if resp.ErrorMessage != "" {
+					line += "  error: " + resp.ErrorMessage
+				}
+				fmt.Println(line)
+				if resp.Result != "" {
+					fmt.Printf("  Result: %s\n", resp.Result)
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&jobID, "id", "", "Job ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func attemptsCmd() *cobra.Command {
+	var jobID string
+
+	cmd := &cobra.Command{
+		Use:   "attempts",
+		Short: "List every attempt of a job, including dead-lettered ones",
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				resp, err := client.ListJobAttempts(ctx, &pb.ListJobAttemptsRequest{JobId: jobID})
+				if err != nil {
+					log.Fatalf("Failed to list attempts: %v", err)
+				}
+
+				if len(resp.Attempts) == 0 {
+					fmt.Printf("Job %s has not been attempted yet\n", resp.JobId)
+					return
+				}
+
+				fmt.Printf("Attempts of job %s:\n", resp.JobId)
+				for _, a := range resp.Attempts {
+					fmt.Printf("  #%-3d %-14s %s  %-10s %s\n", a.Attempt, a.Outcome, a.StartedAt,
+						time.Duration(a.DurationMs)*time.Millisecond, a.Worker)
+					if a.Error != "" {
+						fmt.Printf("       %s\n", a.Error)
+					}
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&jobID, "id", "", "Job ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func formatProgress(resp *pb.GetJobResponse) string {
+	progress := fmt.Sprintf("%d%%", resp.GetProgress())
+	if resp.ProgressMessage != "" {
+		progress += " - " + resp.ProgressMessage
+	}
+	return progress
+}
+
+func cancelCmd() *cobra.Command {
+	var jobID string
+
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel a pending or running job",
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				resp, err := client.CancelJob(ctx, &pb.CancelJobRequest{JobId: jobID})
+				if err != nil {
+					log.Fatalf("Failed to cancel job: %v", err)
+				}
+
+				fmt.Printf("✓ Job %s %s\n", resp.JobId, resp.Status)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&jobID, "id", "", "Job ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
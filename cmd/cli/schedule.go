@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// withClient dials the scheduler and runs fn with a request-scoped context.
+func withClient(fn func(ctx context.Context, client pb.JobSchedulerClient)) {
+	conn, err := grpc.NewClient(serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	fn(ctx, pb.NewJobSchedulerClient(conn))
+}
+
+func scheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage recurring cron schedules",
+	}
+
+	cmd.AddCommand(scheduleCreateCmd())
+	cmd.AddCommand(scheduleListCmd())
+	cmd.AddCommand(schedulePauseCmd(false))
+	cmd.AddCommand(schedulePauseCmd(true))
+	cmd.AddCommand(scheduleDeleteCmd())
+
+	return cmd
+}
+
+func scheduleCreateCmd() *cobra.Command {
+	var name, cronExpr, timezone, jobType, payload, catchup string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a cron schedule",
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				resp, err := client.CreateSchedule(ctx, &pb.CreateScheduleRequest{
+					Name:            name,
+					CronExpr:        cronExpr,
+					Timezone:        timezone,
+					JobType:         jobType,
+					PayloadTemplate: payload,
+					CatchupPolicy:   catchup,
+				})
+				if err != nil {
+					log.Fatalf("Failed to create schedule: %v", err)
+				}
+
+				fmt.Printf("✓ Schedule created successfully\n")
+				printSchedule(resp)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Unique schedule name (required)")
+	cmd.Flags().StringVar(&cronExpr, "cron", "", "Cron expression, e.g. '0 2 * * *' or '@daily' (required)")
+	cmd.Flags().StringVar(&timezone, "tz", "UTC", "IANA time zone the cron expression is evaluated in")
+	cmd.Flags().StringVar(&jobType, "type", "", "Job type to enqueue (required)")
+	cmd.Flags().StringVar(&payload, "data", "{}", "Payload template (JSON, may use {{.Date}}, {{.ScheduledAt}}, {{.Name}})")
+	cmd.Flags().StringVar(&catchup, "catchup", "skip", "Catch-up policy for missed ticks: skip, once or all")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("cron")
+	cmd.MarkFlagRequired("type")
+
+	return cmd
+}
+
+func scheduleListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List cron schedules",
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				resp, err := client.ListSchedules(ctx, &pb.ListSchedulesRequest{})
+				if err != nil {
+					log.Fatalf("Failed to list schedules: %v", err)
+				}
+
+				if len(resp.Schedules) == 0 {
+					fmt.Println("No schedules found")
+					return
+				}
+
+				for _, sc := range resp.Schedules {
+					printSchedule(sc)
+					fmt.Println()
+				}
+			})
+		},
+	}
+}
+
+func schedulePauseCmd(resume bool) *cobra.Command {
+	var scheduleID string
+
+	use, short := "pause", "Pause a cron schedule"
+	if resume {
+		use, short = "resume", "Resume a paused cron schedule"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				resp, err := client.PauseSchedule(ctx, &pb.PauseScheduleRequest{
+					ScheduleId: scheduleID,
+					Resume:     resume,
+				})
+				if err != nil {
+					log.Fatalf("Failed to %s schedule: %v", use, err)
+				}
+
+				printSchedule(resp)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&scheduleID, "id", "", "Schedule ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func scheduleDeleteCmd() *cobra.Command {
+	var scheduleID string
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a cron schedule",
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				_, err := client.DeleteSchedule(ctx, &pb.DeleteScheduleRequest{ScheduleId: scheduleID})
+				if err != nil {
+					log.Fatalf("Failed to delete schedule: %v", err)
+				}
+
+				fmt.Printf("✓ Schedule %s deleted\n", scheduleID)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&scheduleID, "id", "", "Schedule ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func printSchedule(sc *pb.ScheduleResponse) {
+	fmt.Printf("  ID:             %s\n", sc.ScheduleId)
+	fmt.Printf("  Name:           %s\n", sc.Name)
+	fmt.Printf("  Cron:           %s (%s)\n", sc.CronExpr, sc.Timezone)
+	fmt.Printf("  Job Type:       %s\n", sc.JobType)
+	fmt.Printf("  Catch-up:       %s\n", sc.CatchupPolicy)
+	fmt.Printf("  Paused:         %t\n", sc.Paused)
+	fmt.Printf("  Next Run:       %s\n", sc.NextRunAt)
+	if sc.LastRunAt != "" {
+		fmt.Printf("  Last Run:       %s\n", sc.LastRunAt)
+	}
+}
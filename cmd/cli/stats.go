@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"github.com/spf13/cobra"
+)
+
+func statsCmd() *cobra.Command {
+	var jobType string
+	var window, bucket time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show job counts and per-type throughput, latency and success ratio",
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				counts, err := client.GetJobStats(ctx, &pb.GetJobStatsRequest{})
+				if err != nil {
+					log.Fatalf("Failed to get stats: %v", err)
+				}
+
+				fmt.Printf("Jobs: %d total\n", counts.TotalJobs)
+				fmt.Printf("  Pending: %d  Running: %d  Completed: %d  Failed: %d  Cancelled: %d  Skipped: %d  Dead: %d\n",
+					counts.PendingJobs, counts.RunningJobs, counts.CompletedJobs, counts.FailedJobs-counts.DeadJobs,
+					counts.CancelledJobs, counts.SkippedJobs, counts.DeadJobs)
+
+				bucketSeconds := int64(bucket / time.Second)
+				resp, err := client.GetJobTypeStats(ctx, &pb.GetJobTypeStatsRequest{
+					Type:          jobType,
+					WindowSeconds: int64(window / time.Second),
+					BucketSeconds: &bucketSeconds,
+				})
+				if err != nil {
+					log.Fatalf("Failed to get job type stats: %v", err)
+				}
+
+				fmt.Printf("\nLast %s by type:\n", window)
+				if len(resp.Types) == 0 {
+					fmt.Println("  No jobs started or finished in this window")
+					return
+				}
+				fmt.Printf("  %-28s %9s %7s %8s %8s %10s %10s %10s %10s\n",
+					"TYPE", "COMPLETED", "FAILED", "PER MIN", "SUCCESS", "AVG WAIT", "P95 WAIT", "AVG RUN", "P95 RUN")
+				for _, t := range resp.Types {
+					printWindow("  "+t.Type, t.Total)
+					for _, b := range t.Buckets {
+						printWindow("    "+b.Start, b)
+					}
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&jobType, "type", "", "Only show this job type")
+	cmd.Flags().DurationVar(&window, "window", time.Hour, "How far back to look (e.g. 15m, 24h)")
+	cmd.Flags().DurationVar(&bucket, "bucket", 0, "Also break the window into buckets of this size (e.g. 5m)")
+
+	return cmd
+}
+
+func printWindow(label string, w *pb.JobWindowStats) {
+	ms := func(v int64) time.Duration { return time.Duration(v) * time.Millisecond }
+	fmt.Printf("%-30s %9d %7d %8.2f %7.1f%% %10s %10s %10s %10s\n",
+		label, w.Completed, w.Failed, w.ThroughputPerMinute, w.SuccessRatio*100,
+		ms(w.AvgWaitMs), ms(w.P95WaitMs), ms(w.AvgDurationMs), ms(w.P95DurationMs))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func workflowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workflow",
+		Short: "Submit and inspect workflows of dependent jobs",
+	}
+
+	cmd.AddCommand(workflowSubmitCmd())
+	cmd.AddCommand(workflowGetCmd())
+
+	return cmd
+}
+
+func workflowSubmitCmd() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Submit a workflow from a JSON file",
+		Long: `Submit a workflow from a JSON file of the form:
+
+  {
+    "name": "invoice-and-email",
+    "jobs": [
+      {"key": "invoice", "type": "finance:invoice", "payload": "{...}"},
+      {"key": "email", "type": "notification:email", "payload": "{...}", "depends_on": ["invoice"]}
+    ]
+  }`,
+		Run: func(cmd *cobra.Command, args []string) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				log.Fatalf("Failed to read workflow file: %v", err)
+			}
+
+			var req pb.SubmitWorkflowRequest
+			if err := protojson.Unmarshal(data, &req); err != nil {
+				log.Fatalf("Invalid workflow file: %v", err)
+			}
+
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				resp, err := client.SubmitWorkflow(ctx, &req)
+				if err != nil {
+					log.Fatalf("Failed to submit workflow: %v", err)
+				}
+
+				fmt.Printf("✓ Workflow submitted successfully\n")
+				fmt.Printf("  Workflow ID: %s\n", resp.WorkflowId)
+				printWorkflowJobs(resp.Jobs)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Path to the workflow JSON file (required)")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func workflowGetCmd() *cobra.Command {
+	var workflowID string
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Get workflow status",
+		Run: func(cmd *cobra.Command, args []string) {
+			withClient(func(ctx context.Context, client pb.JobSchedulerClient) {
+				resp, err := client.GetWorkflow(ctx, &pb.GetWorkflowRequest{WorkflowId: workflowID})
+				if err != nil {
+					log.Fatalf("Failed to get workflow: %v", err)
+				}
+
+				fmt.Printf("Workflow Details:\n")
+				fmt.Printf("  ID:             %s\n", resp.WorkflowId)
+				fmt.Printf("  Name:           %s\n", resp.Name)
+				fmt.Printf("  Status:         %s\n", resp.Status)
+				fmt.Printf("  Created:        %s\n", resp.CreatedAt)
+				printWorkflowJobs(resp.Jobs)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&workflowID, "id", "", "Workflow ID (required)")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func printWorkflowJobs(jobs []*pb.WorkflowJobStatus) {
+	fmt.Printf("  Jobs:\n")
+	for _, j := range jobs {
+		fmt.Printf("    %-16s job %-8s %-10s %s", j.Key, j.JobId, j.Status, j.Type)
+		if len(j.DependsOn) > 0 {
+			fmt.Printf(" (after %v)", j.DependsOn)
+		}
+		fmt.Println()
+		if j.ErrorMessage != "" {
+			fmt.Printf("    %-16s %s\n", "", j.ErrorMessage)
+		}
+	}
+}
@@ -19,6 +19,8 @@ type Config struct {
 	Address     string
 	TotalJobs   int
 	Concurrency int
+	// BatchSize > 0 submits jobs with SubmitJobs, BatchSize jobs per request.
+	BatchSize int
 }
 
 func main() {
@@ -26,15 +28,21 @@ func main() {
 	addr := flag.String("addr", "localhost:50052", "gRPC server address")
 	total := flag.Int("total", 1000, "Total number of jobs to submit")
 	workers := flag.Int("workers", 50, "Number of concurrent workers")
+	batch := flag.Int("batch", 0, "Jobs per SubmitJobs request (0 submits one job per SubmitJob call)")
 	flag.Parse()
 
 	cfg := Config{
 		Address:     *addr,
 		TotalJobs:   *total,
 		Concurrency: *workers,
+		BatchSize:   *batch,
 	}
 
-	fmt.Printf("🚀 Starting Load Test (Invoice Gen): %d jobs, %d workers\n", cfg.TotalJobs, cfg.Concurrency)
+	mode := "single"
+	if cfg.BatchSize > 0 {
+		mode = fmt.Sprintf("batches of %d", cfg.BatchSize)
+	}
+	fmt.Printf("🚀 Starting Load Test (Invoice Gen): %d jobs, %d workers, %s\n", cfg.TotalJobs, cfg.Concurrency, mode)
 
 	// 2. Connect
 	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -52,7 +60,7 @@ func main() {
 		failCount    atomic.Int64
 		durations    = make([]time.Duration, 0, cfg.TotalJobs)
 		durationsMu  sync.Mutex
-		jobChan      = make(chan int, cfg.TotalJobs)
+		jobChan      = make(chan []int, cfg.TotalJobs)
 	)
 
 	startTime := time.Now()
@@ -62,46 +70,48 @@ func main() {
 	for i := 0; i < cfg.Concurrency; i++ {
 		go func(workerID int) {
 			defer wg.Done()
-			for jobID := range jobChan {
+			for jobIDs := range jobChan {
 				start := time.Now()
 
-				// --- PAYLOAD: Finance Invoice ---
-				// Note: invoice_id MUST be unique to bypass idempotency checks and force PDF gen.
-				req := &pb.SubmitJobRequest{
-					Type: "finance:invoice",
-					Payload: fmt.Sprintf(`{
-						"user_id": "load_user_%d",
-						"amount": 99.99,
-						"currency": "USD",
-						"date": "2026-01-28",
-						"invoice_id": "inv_load_%d", 
-						"items": [
-							{ "description": "Load Test Item A", "quantity": 1, "unit_price": 50.00 },
-							{ "description": "Load Test Item B", "quantity": 2, "unit_price": 24.995 }
-						]
-					}`, workerID, jobID),
-				}
-
 				// RPC Call
 				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-				_, err := client.SubmitJob(ctx, req)
+				if cfg.BatchSize > 0 {
+					req := &pb.SubmitJobsRequest{}
+					for _, jobID := range jobIDs {
+						req.Jobs = append(req.Jobs, invoiceRequest(workerID, jobID))
+					}
+					resp, err := client.SubmitJobs(ctx, req)
+					if err != nil {
+						failCount.Add(int64(len(jobIDs)))
+					} else {
+						for _, r := range resp.Results {
+							if r.Error != "" {
+								failCount.Add(1)
+							} else {
+								successCount.Add(1)
+							}
+						}
+					}
+				} else {
+					_, err := client.SubmitJob(ctx, invoiceRequest(workerID, jobIDs[0]))
+					if err != nil {
+						failCount.Add(1)
+						// log.Printf("Error: %v", err) // Uncomment to debug
+					} else {
+						successCount.Add(1)
+					}
+				}
 				cancel()
 
 				latency := time.Since(start)
 
-				if err != nil {
-					failCount.Add(1)
-					// log.Printf("Error: %v", err) // Uncomment to debug
-				} else {
-					successCount.Add(1)
-				}
-
 				durationsMu.Lock()
 				durations = append(durations, latency)
 				durationsMu.Unlock()
 
-				current := jobsSent.Add(1)
-				if current%100 == 0 {
+				before := jobsSent.Load()
+				current := jobsSent.Add(int64(len(jobIDs)))
+				if current/100 != before/100 {
 					fmt.Printf("\rProgress: %d/%d jobs...", current, cfg.TotalJobs)
 				}
 			}
@@ -109,8 +119,13 @@ func main() {
 	}
 
 	// 5. Fill Queue
-	for i := 0; i < cfg.TotalJobs; i++ {
-		jobChan <- i
+	size := max(cfg.BatchSize, 1)
+	for i := 0; i < cfg.TotalJobs; i += size {
+		var jobIDs []int
+		for j := i; j < min(i+size, cfg.TotalJobs); j++ {
+			jobIDs = append(jobIDs, j)
+		}
+		jobChan <- jobIDs
 	}
 	close(jobChan)
 
@@ -133,7 +148,29 @@ func main() {
 	fmt.Printf("Throughput:       %.2f jobs/sec\n", float64(cfg.TotalJobs)/totalTime.Seconds())
 	fmt.Printf("Success:          %d\n", successCount.Load())
 	fmt.Printf("Failed:           %d\n", failCount.Load())
+	if cfg.BatchSize > 0 {
+		fmt.Printf("Latency is per SubmitJobs request of up to %d jobs\n", cfg.BatchSize)
+	}
 	fmt.Printf("Latency P50:      %v\n", p50)
 	fmt.Printf("Latency P99:      %v\n", p99)
 	fmt.Println("=========================================")
 }
+
+// invoiceRequest builds a finance:invoice submission.
+// Note: invoice_id MUST be unique to bypass idempotency checks and force PDF gen.
+func invoiceRequest(workerID, jobID int) *pb.SubmitJobRequest {
+	return &pb.SubmitJobRequest{
+		Type: "finance:invoice",
+		Payload: fmt.Sprintf(`{
+			"user_id": "load_user_%d",
+			"amount": 99.99,
+			"currency": "USD",
+			"date": "2026-01-28",
+			"invoice_id": "inv_load_%d",
+			"items": [
+				{ "description": "Load Test Item A", "quantity": 1, "unit_price": 50.00 },
+				{ "description": "Load Test Item B", "quantity": 2, "unit_price": 24.995 }
+			]
+		}`, workerID, jobID),
+	}
+}
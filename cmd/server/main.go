@@ -6,12 +6,14 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/bhanuprakaash/job-scheduler/internal/config"
 	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/scheduler"
 	"github.com/bhanuprakaash/job-scheduler/internal/store"
 	"github.com/bhanuprakaash/job-scheduler/internal/worker"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -29,12 +31,25 @@ func main() {
 		logger.Fatal("Failed to load the config", "error", err)
 	}
 
-	// db connection
-	db, err := store.NewStore(appCtx, cfg.PG_DB_URL)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(cfg, os.Args[2:])
+		return
+	}
+
+	// job store
+	db, err := setupStore(appCtx, cfg)
 	if err != nil {
-		logger.Fatal("Failed to ping the store", "error", err)
+		logger.Fatal("Failed to open the store", "error", err)
+	}
+
+	// jobs partitions: kept created ahead so new jobs always have one to land in
+	if partitioner, ok := db.(store.JobPartitioner); ok {
+		partitionsAhead := time.Duration(cfg.JOB_PARTITIONS_AHEAD_DAYS) * 24 * time.Hour
+		if _, err := partitioner.EnsureJobPartitions(appCtx, partitionsAhead); err != nil {
+			logger.Fatal("Failed to create jobs partitions", "error", err)
+		}
+		go maintainJobPartitions(serverCtx, partitioner, partitionsAhead)
 	}
-	logger.Info("Connected to Database")
 
 	// job registry
 	jobRegistry, err := setupJobRegistry(cfg, db)
@@ -42,9 +57,29 @@ func main() {
 		logger.Fatal("Failed to setup job registry", "error", err)
 	}
 
-	// worker pool
-	workerPool := worker.NewPool(db, jobRegistry, cfg.WORKERS_COUNT, time.Duration(cfg.POLL_INTERVAL_SECONDS)*time.Second)
-	workerPool.Start(serverCtx)
+	// worker pools: one per configured queue plus any queue a job type defaults to
+	queues := poolQueues(cfg, jobRegistry)
+	var workerPools []*worker.Pool
+	for _, queue := range queues {
+		pool := worker.NewPool(db, jobRegistry, worker.PoolConfig{
+			Queue:         queue.Name,
+			Workers:       queue.Workers,
+			PollInterval:  time.Duration(queue.PollIntervalSeconds) * time.Second,
+			PriorityAging: time.Duration(cfg.PRIORITY_AGING_SECONDS) * time.Second,
+			LeaseDuration: time.Duration(cfg.LEASE_DURATION_SECONDS) * time.Second,
+		})
+		pool.Start(serverCtx)
+		workerPools = append(workerPools, pool)
+	}
+
+	// cron schedules
+	cronScheduler := scheduler.NewScheduler(
+		db,
+		jobRegistry,
+		time.Duration(cfg.SCHEDULER_POLL_INTERVAL_SECONDS)*time.Second,
+		time.Duration(cfg.SCHEDULER_MISFIRE_GRACE_SECONDS)*time.Second,
+	)
+	cronScheduler.Start(serverCtx)
 
 	var wg sync.WaitGroup
 
@@ -52,7 +87,7 @@ func main() {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		runGRPCServer(serverCtx, cfg, db, jobRegistry)
+		runGRPCServer(serverCtx, cfg, db, jobRegistry, queues)
 	}()
 
 	// http gateway
@@ -107,12 +142,27 @@ func main() {
 
 	logger.Info("Network services stopped")
 
-	logger.Info("Draining worker pool...")
-	workerPool.Stop()
-	logger.Info("Worker pool drained")
+	cronScheduler.Stop()
+
+	logger.Info("Draining worker pools...")
+	for _, pool := range workerPools {
+		pool.Stop()
+	}
+	logger.Info("Worker pools drained")
 
 	db.Close()
-	logger.Info("Database closed")
+	logger.Info("Store closed")
 	logger.Info("Bye!")
 
 }
+
+func poolQueues(cfg *config.Config, registry *worker.Registry) []config.QueueConfig {
+	queues := append([]config.QueueConfig{}, cfg.QUEUES...)
+	for _, name := range registry.Queues() {
+		if !slices.ContainsFunc(queues, func(q config.QueueConfig) bool { return q.Name == name }) {
+			logger.Info("Queue not configured in QUEUES, using defaults", "queue", name)
+			queues = append(queues, cfg.Queue(name))
+		}
+	}
+	return queues
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/config"
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+)
+
+const migrateUsage = `Usage: server migrate <command>
+
+Commands:
+  up              apply every pending migration
+  down [-steps N] revert the last N applied migrations (default 1)
+  status          list migrations and when they were applied
+`
+
+// migrator is implemented by the stores with a versioned schema.
+type migrator interface {
+	Migrate(ctx context.Context) ([]store.Migration, error)
+	MigrateDown(ctx context.Context, steps int) ([]store.Migration, error)
+	MigrationStatus(ctx context.Context) ([]store.MigrationState, error)
+	Close()
+}
+
+// openMigrator opens the database of the store selected by STORE_DRIVER.
+func openMigrator(ctx context.Context, cfg *config.Config) (migrator, error) {
+	switch cfg.STORE_DRIVER {
+	case "postgres":
+		db, err := store.NewStore(ctx, cfg.PG_DB_URL)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	case "sqlite":
+		db, err := store.NewSQLiteStore(ctx, cfg.SQLITE_PATH)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	default:
+		return nil, fmt.Errorf("migrations only apply to the postgres and sqlite stores, not %q", cfg.STORE_DRIVER)
+	}
+}
+
+// runMigrate implements the "migrate" subcommand and exits the process.
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	db, err := openMigrator(ctx, cfg)
+	if err != nil {
+		logger.Fatal("Failed to open the store", "error", err)
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "up":
+		applied, err := db.Migrate(ctx)
+		if err != nil {
+			logger.Fatal("Migration failed", "error", err)
+		}
+		fmt.Printf("Applied %d migration(s)\n", len(applied))
+
+	case "down":
+		fs := flag.NewFlagSet("migrate down", flag.ExitOnError)
+		steps := fs.Int("steps", 1, "Number of migrations to revert")
+		fs.Parse(args[1:])
+
+		reverted, err := db.MigrateDown(ctx, *steps)
+		if err != nil {
+			logger.Fatal("Migration failed", "error", err)
+		}
+		for _, m := range reverted {
+			fmt.Printf("Reverted %04d_%s\n", m.Version, m.Name)
+		}
+		fmt.Printf("Reverted %d migration(s)\n", len(reverted))
+
+	case "status":
+		states, err := db.MigrationStatus(ctx)
+		if err != nil {
+			logger.Fatal("Failed to read migration status", "error", err)
+		}
+		for _, st := range states {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = "applied " + st.AppliedAt.Format("2006-01-02T15:04:05Z")
+			}
+			fmt.Printf("%04d_%-40s %s\n", st.Version, st.Name, applied)
+		}
+
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
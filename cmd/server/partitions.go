@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+)
+
+const partitionCheckInterval = time.Hour
+
+// maintainJobPartitions keeps creating the partitions of jobs ahead of time
+// until ctx is done. Old partitions are removed by maintenance:archive.
+func maintainJobPartitions(ctx context.Context, partitioner store.JobPartitioner, ahead time.Duration) {
+	ticker := time.NewTicker(partitionCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			created, err := partitioner.EnsureJobPartitions(ctx, ahead)
+			if err != nil {
+				logger.Error("Failed to create jobs partitions", "error", err)
+				continue
+			}
+			if len(created) > 0 {
+				logger.Info("Created jobs partitions", "partitions", created)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/bhanuprakaash/job-scheduler/internal/api"
 	"github.com/bhanuprakaash/job-scheduler/internal/config"
@@ -15,18 +16,28 @@ import (
 	"google.golang.org/grpc"
 )
 
-func runGRPCServer(ctx context.Context, cfg *config.Config, db store.Storer, jobRegistry *worker.Registry) {
+// runGRPCServer serves the API until ctx is done. Submissions are limited to
+// the queues that have a worker pool.
+func runGRPCServer(ctx context.Context, cfg *config.Config, db store.Storer, jobRegistry *worker.Registry, pools []config.QueueConfig) {
 	listen, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPC_PORT))
 	if err != nil {
 		logger.Fatal("Failed to listen", "error", err)
 	}
 
+	var queues []string
+	for _, q := range pools {
+		queues = append(queues, q.Name)
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
 		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
 	)
 
-	pb.RegisterJobSchedulerServer(grpcServer, api.NewServer(db, jobRegistry))
+	pb.RegisterJobSchedulerServer(grpcServer, api.NewServer(db, jobRegistry, api.ServerOptions{
+		IdempotencyTTL: time.Duration(cfg.IDEMPOTENCY_KEY_TTL_HOURS) * time.Hour,
+		Queues:         queues,
+	}))
 
 	grpc_prometheus.Register(grpcServer)
 
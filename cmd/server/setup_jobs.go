@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/bhanuprakaash/job-scheduler/internal/blob"
 	"github.com/bhanuprakaash/job-scheduler/internal/catalog/finance/invoice"
 	maintenance "github.com/bhanuprakaash/job-scheduler/internal/catalog/maintenance/archive"
@@ -20,10 +22,35 @@ func setupJobRegistry(cfg *config.Config, db store.Storer) (*worker.Registry, er
 		return nil, err
 	}
 	jobRegistry := worker.NewRegistry()
-	jobRegistry.Register("notification:email", email.NewEmailJob(resendService), 5)
-	jobRegistry.Register("media:resize_image", resize.NewImageResizeJob(minioBlob), 2)
-	jobRegistry.Register("maintenance:archive", maintenance.NewArchiveJob(db, minioBlob), 0)
-	jobRegistry.Register("finance:invoice", invoice.NewInvoiceJob(minioBlob), 10)
+	jobRegistry.Register("notification:email", email.NewEmailJob(resendService), 5,
+		worker.WithQueue("notifications"),
+		worker.WithRetryPolicy(store.RetryPolicy{
+			MaxAttempts: 5,
+			BaseDelay:   time.Second,
+			MaxDelay:    time.Minute,
+			Backoff:     store.BackoffExponential,
+			Jitter:      0.2,
+		}),
+		worker.WithTimeout(30*time.Second),
+	)
+	jobRegistry.Register("media:resize_image", resize.NewImageResizeJob(minioBlob), 2,
+		worker.WithQueue("media"),
+		worker.WithTimeout(2*time.Minute),
+	)
+	jobRegistry.Register("maintenance:archive", maintenance.NewArchiveJob(db, minioBlob), 0,
+		worker.WithRetryPolicy(store.RetryPolicy{
+			MaxAttempts: 4,
+			BaseDelay:   5 * time.Minute,
+			MaxDelay:    time.Hour,
+			Backoff:     store.BackoffExponential,
+		}),
+		worker.WithTimeout(30*time.Minute),
+		worker.WithUnique(store.UniqueSpec{States: []store.JobStatus{store.JobStatusPending}}),
+	)
+	jobRegistry.Register("finance:invoice", invoice.NewInvoiceJob(minioBlob), 10,
+		worker.WithTimeout(time.Minute),
+		worker.WithUnique(store.UniqueSpec{Fields: []string{"invoice_id"}}),
+	)
 
 	return jobRegistry, nil
 
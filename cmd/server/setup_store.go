@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/config"
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+)
+
+// setupStore opens the job store selected by STORE_DRIVER, migrating the
+// database first when MIGRATE_ON_STARTUP is set.
+func setupStore(ctx context.Context, cfg *config.Config) (store.Storer, error) {
+	switch cfg.STORE_DRIVER {
+	case "memory":
+		logger.Info("Using the in-memory store, jobs will not survive a restart")
+		return store.NewMemoryStore(), nil
+
+	case "sqlite":
+		db, err := store.NewSQLiteStore(ctx, cfg.SQLITE_PATH)
+		if err != nil {
+			return nil, fmt.Errorf("open the store: %w", err)
+		}
+		logger.Info("Opened SQLite database", "path", cfg.SQLITE_PATH)
+
+		if cfg.MIGRATE_ON_STARTUP {
+			if _, err := db.Migrate(ctx); err != nil {
+				db.Close()
+				return nil, fmt.Errorf("migrate the database: %w", err)
+			}
+		}
+		return db, nil
+
+	case "postgres":
+		db, err := store.NewStore(ctx, cfg.PG_DB_URL)
+		if err != nil {
+			return nil, fmt.Errorf("ping the store: %w", err)
+		}
+		logger.Info("Connected to Database")
+
+		if cfg.MIGRATE_ON_STARTUP {
+			if _, err := db.Migrate(ctx); err != nil {
+				db.Close()
+				return nil, fmt.Errorf("migrate the database: %w", err)
+			}
+		}
+		return db, nil
+
+	default:
+		return nil, fmt.Errorf("unknown store driver %q", cfg.STORE_DRIVER)
+	}
+}
@@ -0,0 +1,167 @@
+// Package enqueue submits jobs from inside a service's own Postgres
+// transaction. The job is inserted with the business rows it belongs to, so
+// either both are committed or neither is, and no job is lost when the
+// service fails between its commit and a SubmitJob call.
+//
+// The package only depends on pgx and does not talk to the server, so it
+// cannot see the server's job registry: the job types it accepts are the ones
+// the service lists in its JobTypes, and the unique specs it applies are the
+// ones in Options.Unique. Both must mirror the server's registrations.
+package enqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/jobdb"
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultIdempotencyTTL matches the server's default IDEMPOTENCY_KEY_TTL_HOURS.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// ErrInvalidJob is returned for a job SubmitJob would reject, e.g. one of an
+// unknown type; nothing is written to the transaction then.
+var ErrInvalidJob = errors.New("invalid job")
+
+// JobTypes tells which job types exist and the queue each one runs on, like
+// the registry of the server.
+type JobTypes = jobdb.JobTypes
+
+// Types is a JobTypes listing the job types a service may enqueue, mapped to
+// their queue; an empty queue stands for the default queue. It must mirror the
+// server's registrations: a type the server does not know fails once claimed,
+// and a job put on a queue without a worker pool is never claimed.
+type Types map[string]string
+
+func (t Types) Has(jobType string) bool {
+	_, ok := t[jobType]
+	return ok
+}
+
+func (t Types) Queue(jobType string) string {
+	if queue := t[jobType]; queue != "" {
+		return queue
+	}
+	return jobdb.DefaultQueue
+}
+
+// RetryPolicy overrides fields of the retry policy of the job's type.
+type RetryPolicy = jobdb.RetryPolicy
+
+type BackoffStrategy = jobdb.BackoffStrategy
+
+const (
+	BackoffExponential = jobdb.BackoffExponential
+	BackoffLinear      = jobdb.BackoffLinear
+	BackoffFixed       = jobdb.BackoffFixed
+)
+
+// UniqueSpec makes Enqueue return an existing job instead of inserting a
+// duplicate; see worker.WithUnique.
+type UniqueSpec = jobdb.UniqueSpec
+
+// JobStatus is a state of a job, as listed in UniqueSpec.States.
+type JobStatus = jobdb.JobStatus
+
+const (
+	JobStatusPending   = jobdb.JobStatusPending
+	JobStatusRunning   = jobdb.JobStatusRunning
+	JobStatusCompleted = jobdb.JobStatusCompleted
+	JobStatusFailed    = jobdb.JobStatusFailed
+	JobStatusCancelled = jobdb.JobStatusCancelled
+	JobStatusSkipped   = jobdb.JobStatusSkipped
+)
+
+// Job is a job submission, with the fields of SubmitJobRequest.
+type Job struct {
+	Type string
+	// Payload is a JSON document; empty means {}.
+	Payload string
+	// RunAt is when the job becomes due; nil means now.
+	RunAt    *time.Time
+	Priority int
+	// Queue defaults to the queue of the type.
+	Queue string
+
+	IdempotencyKey string
+
+	RetryPolicy *RetryPolicy
+	// Timeout overrides the execution timeout of the job type when positive.
+	Timeout time.Duration
+}
+
+// Result identifies the enqueued job, or the existing one returned for a
+// repeated idempotency key or a duplicate of a unique job.
+type Result struct {
+	JobID     int64
+	Status    string
+	Duplicate bool
+}
+
+// Options holds the tunables of an Enqueuer.
+type Options struct {
+	// IdempotencyTTL is how long an idempotency key is held after the job
+	// carrying it was enqueued. Zero uses DefaultIdempotencyTTL.
+	IdempotencyTTL time.Duration
+	// Unique holds the unique specs of job types, by type. It must mirror the
+	// types the server registers with worker.WithUnique, or duplicates of a
+	// unique type enqueued here are inserted. It is ignored for types whose
+	// JobTypes provide a spec themselves.
+	Unique map[string]UniqueSpec
+}
+
+// Enqueuer validates and inserts jobs the way the SubmitJob RPC does.
+type Enqueuer struct {
+	types JobTypes
+	opts  Options
+}
+
+func New(types JobTypes, opts Options) *Enqueuer {
+	if opts.IdempotencyTTL <= 0 {
+		opts.IdempotencyTTL = DefaultIdempotencyTTL
+	}
+	return &Enqueuer{types: types, opts: opts}
+}
+
+// Enqueue inserts job using tx. It applies the validation and defaults of
+// SubmitJob, and the job becomes visible to workers only once the caller
+// commits tx. A repeated idempotency key or a duplicate of a unique job
+// returns the existing job with Duplicate set.
+func (e *Enqueuer) Enqueue(ctx context.Context, tx pgx.Tx, job Job) (*Result, error) {
+	params, err := jobdb.Resolve(e.types, jobdb.CreateJobParams{
+		Type:     job.Type,
+		Payload:  job.Payload,
+		RunAt:    job.RunAt,
+		Priority: job.Priority,
+		Queue:    job.Queue,
+
+		IdempotencyKey: job.IdempotencyKey,
+		IdempotencyTTL: e.opts.IdempotencyTTL,
+
+		RetryPolicy: job.RetryPolicy,
+		Timeout:     job.Timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJob, err)
+	}
+	if spec, ok := e.opts.Unique[params.Type]; ok && params.Unique == nil {
+		if err := spec.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJob, err)
+		}
+		params.Unique = &spec
+	}
+
+	inserted, err := jobdb.Insert(ctx, tx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		JobID:     inserted.ID,
+		Status:    string(inserted.Status),
+		Duplicate: inserted.Duplicate,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package enqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/jobdb"
+)
+
+func TestEnqueue_Validation(t *testing.T) {
+	e := New(Types{"notification:email": "notifications"}, Options{})
+
+	tests := []struct {
+		name string
+		job  Job
+	}{
+		{"missing type", Job{}},
+		{"unknown type", Job{Type: "media:resize"}},
+		{"invalid payload", Job{Type: "notification:email", Payload: "{"}},
+		{"negative timeout", Job{Type: "notification:email", Timeout: -time.Second}},
+		{"bad retry policy", Job{Type: "notification:email", RetryPolicy: &RetryPolicy{Backoff: "random"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// invalid jobs are rejected before the transaction is used
+			if _, err := e.Enqueue(context.Background(), nil, tt.job); !errors.Is(err, ErrInvalidJob) {
+				t.Errorf("Expected ErrInvalidJob, got %v", err)
+			}
+		})
+	}
+
+	// the unique spec of a type is validated too
+	e = New(Types{"finance:invoice": ""}, Options{Unique: map[string]UniqueSpec{"finance:invoice": {Period: -time.Hour}}})
+	if _, err := e.Enqueue(context.Background(), nil, Job{Type: "finance:invoice"}); !errors.Is(err, ErrInvalidJob) {
+		t.Errorf("Expected ErrInvalidJob for an invalid unique spec, got %v", err)
+	}
+}
+
+func TestTypes(t *testing.T) {
+	types := Types{"notification:email": "notifications", "report:invoice": ""}
+
+	if !types.Has("report:invoice") || types.Has("media:resize") {
+		t.Error("Expected only the listed types to exist")
+	}
+	if q := types.Queue("notification:email"); q != "notifications" {
+		t.Errorf("Expected the notifications queue, got %q", q)
+	}
+	if q := types.Queue("report:invoice"); q != jobdb.DefaultQueue {
+		t.Errorf("Expected the default queue, got %q", q)
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ListJobAttempts(ctx context.Context, req *pb.ListJobAttemptsRequest) (*pb.ListJobAttemptsResponse, error) {
+	id, err := strconv.ParseInt(req.JobId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job id format: %v", req.JobId)
+	}
+
+	attempts, err := s.store.ListJobAttempts(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "job %d not found", id)
+		}
+		logger.Error("Failed to list job attempts", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to list job attempts: %v", err)
+	}
+
+	resp := &pb.ListJobAttemptsResponse{JobId: req.JobId}
+	for _, a := range attempts {
+		attempt := &pb.JobAttempt{
+			Attempt:    int32(a.Attempt),
+			Worker:     a.Worker,
+			StartedAt:  a.StartedAt.Format("2006-01-02T15:04:05Z"),
+			DurationMs: a.Duration().Milliseconds(),
+			Outcome:    string(a.Outcome),
+		}
+		if a.FinishedAt != nil {
+			attempt.FinishedAt = a.FinishedAt.Format("2006-01-02T15:04:05Z")
+		}
+		if a.Error.Valid {
+			attempt.Error = a.Error.String
+		}
+		resp.Attempts = append(resp.Attempts, attempt)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchSize bounds the number of jobs accepted by a single SubmitJobs call.
+const maxBatchSize = 1000
+
+func (s *Server) SubmitJobs(ctx context.Context, req *pb.SubmitJobsRequest) (*pb.SubmitJobsResponse, error) {
+	logger.Info("Received batch submission", "jobs", len(req.Jobs))
+
+	if len(req.Jobs) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "batch has no jobs")
+	}
+	if len(req.Jobs) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch holds %d jobs, at most %d are allowed", len(req.Jobs), maxBatchSize)
+	}
+
+	results := make([]*pb.SubmitJobsResult, len(req.Jobs))
+
+	// only the valid jobs are inserted; valid[k] is the request index of params[k]
+	var params []store.CreateJobParams
+	var valid []int
+	for i, j := range req.Jobs {
+		p, err := s.jobParams(j)
+		if err != nil {
+			results[i] = &pb.SubmitJobsResult{Error: err.Error()}
+			continue
+		}
+		params = append(params, p)
+		valid = append(valid, i)
+	}
+
+	if len(params) > 0 {
+		jobs, err := s.store.CreateJobs(ctx, params)
+		if err != nil {
+			logger.Error("Failed to create jobs", "error", err)
+			return nil, status.Errorf(codes.Internal, "failed to create jobs: %v", err)
+		}
+
+		for k, job := range jobs {
+			results[valid[k]] = &pb.SubmitJobsResult{
+				JobId:     strconv.FormatInt(job.ID, 10),
+				Status:    string(job.Status),
+				Duplicate: job.Duplicate,
+			}
+		}
+	}
+
+	logger.Info("batch created", "jobs", len(params), "rejected", len(req.Jobs)-len(params))
+
+	return &pb.SubmitJobsResponse{Results: results}, nil
+}
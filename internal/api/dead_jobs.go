@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) RetryDeadJob(ctx context.Context, req *pb.RetryDeadJobRequest) (*pb.RetryDeadJobResponse, error) {
+	id, err := strconv.ParseInt(req.JobId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job id format: %v", req.JobId)
+	}
+
+	var payload *string
+	if req.Payload != "" {
+		if !json.Valid([]byte(req.Payload)) {
+			return nil, status.Errorf(codes.InvalidArgument, "payload must be valid JSON")
+		}
+		payload = &req.Payload
+	}
+
+	job, err := s.store.RetryDeadJob(ctx, id, payload, s.registry.Unique)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "dead job %d not found", id)
+		}
+		if errors.Is(err, store.ErrAlreadyExists) {
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		}
+		logger.Error("Failed to retry dead job", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to retry dead job: %v", err)
+	}
+
+	return &pb.RetryDeadJobResponse{
+		JobId:       strconv.FormatInt(job.ID, 10),
+		Status:      string(job.Status),
+		ReplayCount: int32(job.ReplayCount),
+	}, nil
+}
+
+func (s *Server) RetryDeadJobs(ctx context.Context, req *pb.RetryDeadJobsRequest) (*pb.RetryDeadJobsResponse, error) {
+	filter, err := parseDeadJobFilter(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	ids, err := s.store.RetryDeadJobs(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to retry dead jobs", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to retry dead jobs: %v", err)
+	}
+
+	resp := &pb.RetryDeadJobsResponse{}
+	for _, id := range ids {
+		resp.JobIds = append(resp.JobIds, strconv.FormatInt(id, 10))
+	}
+
+	return resp, nil
+}
+
+func parseDeadJobFilter(req *pb.RetryDeadJobsRequest) (store.DeadJobFilter, error) {
+	filter := store.DeadJobFilter{
+		Type:          req.Type,
+		ErrorContains: req.ErrorContains,
+	}
+
+	for _, raw := range req.JobIds {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid job id format: %v", raw)
+		}
+		filter.IDs = append(filter.IDs, id)
+	}
+
+	if req.FailedAfter != "" {
+		t, err := time.Parse(time.RFC3339, req.FailedAfter)
+		if err != nil {
+			return filter, fmt.Errorf("invalid failed_after format (expected RFC 3339): %v", req.FailedAfter)
+		}
+		filter.FailedAfter = &t
+	}
+
+	if req.FailedBefore != "" {
+		t, err := time.Parse(time.RFC3339, req.FailedBefore)
+		if err != nil {
+			return filter, fmt.Errorf("invalid failed_before format (expected RFC 3339): %v", req.FailedBefore)
+		}
+		filter.FailedBefore = &t
+	}
+
+	if len(filter.IDs) == 0 && filter.Type == "" && filter.ErrorContains == "" &&
+		filter.FailedAfter == nil && filter.FailedBefore == nil {
+		return filter, fmt.Errorf("at least one of job_ids, type, error_contains, failed_after or failed_before is required")
+	}
+
+	return filter, nil
+}
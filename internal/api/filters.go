@@ -0,0 +1,150 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+)
+
+// parseJobFilter converts the filters of a ListJobs request.
+func parseJobFilter(req *pb.ListJobRequest) (store.JobFilter, error) {
+	filter := store.JobFilter{
+		Queue:         req.Queue,
+		Type:          req.Type,
+		ErrorContains: req.ErrorContains,
+	}
+
+	if req.MinPriority != nil {
+		minPriority := int(req.GetMinPriority())
+		filter.MinPriority = &minPriority
+	}
+
+	for _, s := range req.Status {
+		status := store.JobStatus(s)
+		switch status {
+		case store.JobStatusPending, store.JobStatusRunning, store.JobStatusCompleted, store.JobStatusFailed,
+			store.JobStatusCancelled, store.JobStatusSkipped:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			return filter, fmt.Errorf("unknown status %q", s)
+		}
+	}
+
+	var err error
+	if filter.CreatedAfter, err = parseTimeBound("created_after", req.CreatedAfter); err != nil {
+		return filter, err
+	}
+	if filter.CreatedBefore, err = parseTimeBound("created_before", req.CreatedBefore); err != nil {
+		return filter, err
+	}
+	if filter.CompletedAfter, err = parseTimeBound("completed_after", req.CompletedAfter); err != nil {
+		return filter, err
+	}
+	if filter.CompletedBefore, err = parseTimeBound("completed_before", req.CompletedBefore); err != nil {
+		return filter, err
+	}
+
+	filter.MinRetries, filter.MaxRetries = retryBounds(req)
+
+	return filter, nil
+}
+
+// parseDeadListFilter converts the filters of a ListDeadJobs request. Dead
+// jobs have no status of their own and do not keep their creation time.
+func parseDeadListFilter(req *pb.ListJobRequest) (store.DeadJobFilter, error) {
+	filter := store.DeadJobFilter{
+		Queue:         req.Queue,
+		Type:          req.Type,
+		ErrorContains: req.ErrorContains,
+	}
+
+	if len(req.Status) > 0 {
+		return filter, fmt.Errorf("status cannot be filtered on for dead jobs")
+	}
+	if req.CreatedAfter != "" || req.CreatedBefore != "" {
+		return filter, fmt.Errorf("dead jobs can only be filtered by completed_after/completed_before")
+	}
+
+	if req.MinPriority != nil {
+		minPriority := int(req.GetMinPriority())
+		filter.MinPriority = &minPriority
+	}
+
+	var err error
+	if filter.FailedAfter, err = parseTimeBound("completed_after", req.CompletedAfter); err != nil {
+		return filter, err
+	}
+	if filter.FailedBefore, err = parseTimeBound("completed_before", req.CompletedBefore); err != nil {
+		return filter, err
+	}
+
+	filter.MinRetries, filter.MaxRetries = retryBounds(req)
+
+	return filter, nil
+}
+
+// parseJobSort converts the sort options of a list request.
+func parseJobSort(req *pb.ListJobRequest) (store.JobSort, error) {
+	sort := store.JobSort{Field: store.JobSortField(req.SortBy)}
+	if !sort.Valid() {
+		return sort, fmt.Errorf("unknown sort_by %q (expected created_at, completed_at, priority or retry_count)", req.SortBy)
+	}
+
+	switch req.SortOrder {
+	case "", "desc":
+	case "asc":
+		sort.Ascending = true
+	default:
+		return sort, fmt.Errorf("unknown sort_order %q (expected asc or desc)", req.SortOrder)
+	}
+
+	return sort, nil
+}
+
+// parsePage converts the pagination options of a list request.
+func parsePage(req *pb.ListJobRequest, limit int) (store.PageParams, error) {
+	page := store.PageParams{
+		Limit:     limit,
+		Offset:    int(req.Offset),
+		PageToken: req.PageToken,
+		Total:     store.TotalCount(req.TotalCount),
+	}
+
+	if page.PageToken != "" && page.Offset > 0 {
+		return page, fmt.Errorf("offset cannot be combined with page_token")
+	}
+
+	switch page.Total {
+	case "", store.TotalExact, store.TotalEstimated, store.TotalNone:
+	default:
+		return page, fmt.Errorf("unknown total_count %q (expected exact, estimated or none)", req.TotalCount)
+	}
+
+	return page, nil
+}
+
+func retryBounds(req *pb.ListJobRequest) (minRetries, maxRetries *int) {
+	if req.MinRetries != nil {
+		n := int(req.GetMinRetries())
+		minRetries = &n
+	}
+	if req.MaxRetries != nil {
+		n := int(req.GetMaxRetries())
+		maxRetries = &n
+	}
+	return minRetries, maxRetries
+}
+
+// parseTimeBound parses an optional RFC 3339 query bound.
+func parseTimeBound(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s format (expected RFC 3339): %v", name, value)
+	}
+	return &t, nil
+}
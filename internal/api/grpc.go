@@ -2,10 +2,14 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/bhanuprakaash/job-scheduler/internal/jobdb"
 	"github.com/bhanuprakaash/job-scheduler/internal/logger"
 	"github.com/bhanuprakaash/job-scheduler/internal/store"
 	"github.com/bhanuprakaash/job-scheduler/internal/worker"
@@ -18,41 +22,55 @@ type Server struct {
 	pb.UnimplementedJobSchedulerServer
 	store    store.Storer
 	registry *worker.Registry
+	opts     ServerOptions
 }
 
-func NewServer(store store.Storer, registry *worker.Registry) *Server {
+// ServerOptions holds the tunables of the API server.
+type ServerOptions struct {
+	// IdempotencyTTL is how long an idempotency key is held after the job
+	// carrying it was submitted.
+	IdempotencyTTL time.Duration
+	// WatchInterval is how often WatchJob checks a job for changes.
+	WatchInterval time.Duration
+	// Queues are the queues served by a worker pool. Jobs submitted to any
+	// other queue would never be claimed and are rejected; when empty, every
+	// queue is accepted.
+	Queues []string
+}
+
+func NewServer(store store.Storer, registry *worker.Registry, opts ServerOptions) *Server {
 	return &Server{
 		store:    store,
 		registry: registry,
+		opts:     opts,
 	}
 }
 
 func (s *Server) SubmitJob(ctx context.Context, req *pb.SubmitJobRequest) (*pb.SubmitJobResponse, error) {
 	logger.Info("Received job submission", "type", req.Type, "payload", req.Payload)
 
-	if req.Type == "" {
-		return nil, fmt.Errorf("job type is required")
-	}
-	if !s.registry.Has(req.Type) {
-		logger.Error("Invalid job type submitted", "type", req.Type)
-		return nil, status.Errorf(codes.InvalidArgument, "job type '%s' is not registered", req.Type)
-	}
-
-	if req.Payload == "" {
-		req.Payload = "{}"
+	params, err := s.jobParams(req)
+	if err != nil {
+		logger.Error("Invalid job submitted", "type", req.Type, "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	job, err := s.store.CreateJob(ctx, req.Type, req.Payload)
+	job, err := s.store.CreateJob(ctx, params)
 	if err != nil {
 		logger.Error("Failed to create job", "error", err)
 		return nil, err
 	}
 
-	logger.Info("job created successfully", "job_id", job.ID)
+	if job.Duplicate {
+		logger.Info("duplicate submission, returning existing job", "job_id", job.ID, "idempotency_key", req.IdempotencyKey, "unique_key", job.UniqueKey.String)
+	} else {
+		logger.Info("job created successfully", "job_id", job.ID, "queue", job.Queue, "priority", job.Priority, "next_run_at", job.NextRunAt)
+	}
 
 	return &pb.SubmitJobResponse{
-		JobId:  strconv.FormatInt(job.ID, 10),
-		Status: string(job.Status),
+		JobId:     strconv.FormatInt(job.ID, 10),
+		Status:    string(job.Status),
+		Duplicate: job.Duplicate,
 	}, nil
 }
 
@@ -60,15 +78,23 @@ func (s *Server) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.GetJobR
 
 	id, err := strconv.ParseInt(req.JobId, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid job id format: %v", req.JobId)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job id format: %v", req.JobId)
 	}
 
 	job, err := s.store.GetJobByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "job %d not found", id)
+		}
 		logger.Error("Failed to get job", "error", err)
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "failed to get job: %v", err)
 	}
 
+	return jobToProto(job), nil
+
+}
+
+func jobToProto(job *store.Job) *pb.GetJobResponse {
 	resp := &pb.GetJobResponse{
 		JobId:      strconv.FormatInt(job.ID, 10),
 		Type:       job.Type,
@@ -76,18 +102,81 @@ func (s *Server) GetJob(ctx context.Context, req *pb.GetJobRequest) (*pb.GetJobR
 		Status:     string(job.Status),
 		CreatedAt:  job.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		RetryCount: strconv.Itoa(job.RetryCount),
+		NextRunAt:  job.NextRunAt.Format("2006-01-02T15:04:05Z"),
+		Priority:   int32(job.Priority),
+		Queue:      job.Queue,
 	}
 
 	if job.ErrorMessage.Valid {
 		resp.ErrorMessage = job.ErrorMessage.String
 	}
 
+	if job.IdempotencyKey.Valid {
+		resp.IdempotencyKey = job.IdempotencyKey.String
+	}
+
+	if job.TimeoutMs.Valid {
+		resp.TimeoutMs = job.TimeoutMs.Int64
+	}
+
+	if job.Result != nil {
+		resp.Result = string(job.Result)
+	}
+
+	if job.Progress.Valid {
+		progress := job.Progress.Int32
+		resp.Progress = &progress
+		resp.ProgressMessage = job.ProgressMessage.String
+	}
+
+	if job.ProgressUpdatedAt != nil {
+		resp.ProgressUpdatedAt = job.ProgressUpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	if job.LockedBy.Valid {
+		resp.LockedBy = job.LockedBy.String
+	}
+
+	if job.LeaseExpiresAt != nil {
+		resp.LeaseExpiresAt = job.LeaseExpiresAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	if job.ReplayedAt != nil {
+		resp.ReplayCount = int32(job.ReplayCount)
+		resp.ReplayedAt = job.ReplayedAt.Format("2006-01-02T15:04:05Z")
+	}
+
 	if job.CompletedAt != nil {
 		resp.CompletedAt = job.CompletedAt.Format("2006-01-02T15:04:05Z")
 	}
 
-	return resp, nil
+	return resp
+}
 
+func (s *Server) CancelJob(ctx context.Context, req *pb.CancelJobRequest) (*pb.CancelJobResponse, error) {
+	id, err := strconv.ParseInt(req.JobId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job id format: %v", req.JobId)
+	}
+
+	job, err := s.store.CancelJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "job %d not found", id)
+		}
+		if errors.Is(err, store.ErrJobFinished) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		logger.Error("Failed to cancel job", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to cancel job: %v", err)
+	}
+
+	logger.Info("job cancelled", "job_id", job.ID)
+
+	return &pb.CancelJobResponse{
+		JobId:  strconv.FormatInt(job.ID, 10),
+		Status: string(job.Status),
+	}, nil
 }
 
 func (s *Server) ListJobs(ctx context.Context, req *pb.ListJobRequest) (*pb.ListJobResponse, error) {
@@ -97,21 +186,43 @@ func (s *Server) ListJobs(ctx context.Context, req *pb.ListJobRequest) (*pb.List
 		limit = 10
 	}
 
-	jobs, err := s.store.ListJobs(ctx, int(limit), int(req.Offset))
+	filter, err := parseJobFilter(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	sort, err := parseJobSort(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	page, err := parsePage(req, int(limit))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	jobs, err := s.store.ListJobs(ctx, filter, sort, page)
 	if err != nil {
+		if errors.Is(err, store.ErrInvalidPageToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to list jobs: %v", err)
 	}
 
 	var pbJobs []*pb.GetJobResponse
 	for _, j := range jobs.Jobs {
 		jobResp := &pb.GetJobResponse{
-			JobId:        fmt.Sprintf("%d", j.ID),
-			Type:         j.Type,
-			Payload:      j.Payload,
-			Status:       string(j.Status),
-			CreatedAt:    j.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			ErrorMessage: j.ErrorMessage.String,
-			RetryCount:   strconv.Itoa(j.RetryCount),
+			JobId:          fmt.Sprintf("%d", j.ID),
+			Type:           j.Type,
+			Payload:        j.Payload,
+			Status:         string(j.Status),
+			CreatedAt:      j.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			ErrorMessage:   j.ErrorMessage.String,
+			RetryCount:     strconv.Itoa(j.RetryCount),
+			NextRunAt:      j.NextRunAt.Format("2006-01-02T15:04:05Z"),
+			Priority:       int32(j.Priority),
+			Queue:          j.Queue,
+			IdempotencyKey: j.IdempotencyKey.String,
+			Result:         string(j.Result),
 		}
 
 		if j.CompletedAt != nil {
@@ -127,11 +238,13 @@ func (s *Server) ListJobs(ctx context.Context, req *pb.ListJobRequest) (*pb.List
 		TotalPages:   int32(jobs.Meta.TotalPages),
 		TotalRecords: jobs.Meta.TotalRecords,
 		Limit:        int32(jobs.Meta.Limit),
+		TotalCount:   string(jobs.Meta.TotalCount),
 	}
 
 	return &pb.ListJobResponse{
-		Jobs: pbJobs,
-		Meta: pagination,
+		Jobs:          pbJobs,
+		Meta:          pagination,
+		NextPageToken: jobs.NextPageToken,
 	}, nil
 
 }
@@ -141,11 +254,26 @@ func (s *Server) ListDeadJobs(ctx context.Context, req *pb.ListJobRequest) (*pb.
 	if limit <= 0 {
 		limit = 10
 	}
-	offset := int(req.Offset)
 
+	filter, err := parseDeadListFilter(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	sort, err := parseJobSort(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 
-	paginatedJobs, err := s.store.ListDeadJobs(ctx, limit, offset)
+	page, err := parsePage(req, limit)
 	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	paginatedJobs, err := s.store.ListDeadJobs(ctx, filter, sort, page)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidPageToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to list dead jobs: %v", err)
 	}
 
@@ -157,8 +285,11 @@ func (s *Server) ListDeadJobs(ctx context.Context, req *pb.ListJobRequest) (*pb.
 			Payload:      j.Payload,
 			Status:       "failed",
 			CreatedAt:    j.CreatedAt.Format(time.RFC3339),
+			CompletedAt:  j.CreatedAt.Format(time.RFC3339),
 			ErrorMessage: j.ErrorMessage.String,
 			RetryCount:   strconv.Itoa(j.RetryCount),
+			Queue:        j.Queue,
+			Priority:     int32(j.Priority),
 		})
 	}
 
@@ -169,7 +300,9 @@ func (s *Server) ListDeadJobs(ctx context.Context, req *pb.ListJobRequest) (*pb.
 			TotalPages:   int32(paginatedJobs.Meta.TotalPages),
 			TotalRecords: paginatedJobs.Meta.TotalRecords,
 			Limit:        int32(paginatedJobs.Meta.Limit),
+			TotalCount:   string(paginatedJobs.Meta.TotalCount),
 		},
+		NextPageToken: paginatedJobs.NextPageToken,
 	}, nil
 }
 
@@ -183,7 +316,98 @@ func (s *Server) GetJobStats(ctx context.Context, req *pb.GetJobStatsRequest) (*
 		PendingJobs:   stats.Pending,
 		RunningJobs:   stats.Running,
 		CompletedJobs: stats.Completed,
-		FailedJobs:    stats.Failed,
-		TotalJobs:     stats.Pending + stats.Running + stats.Completed + stats.Failed,
+		FailedJobs:    stats.Failed + stats.Dead,
+		CancelledJobs: stats.Cancelled,
+		SkippedJobs:   stats.Skipped,
+		DeadJobs:      stats.Dead,
+		TotalJobs:     stats.Total(),
 	}, nil
 }
+
+// jobParams validates a job submission against the registry and resolves its
+// defaults (see jobdb.Resolve). The job's queue must be one of the served
+// queues.
+func (s *Server) jobParams(req *pb.SubmitJobRequest) (store.CreateJobParams, error) {
+	runAt, err := parseRunAt(req.RunAt, req.DelaySeconds)
+	if err != nil {
+		return store.CreateJobParams{}, err
+	}
+
+	retryPolicy, err := parseRetryPolicy(req.RetryPolicy)
+	if err != nil {
+		return store.CreateJobParams{}, err
+	}
+
+	if req.TimeoutMs < 0 {
+		return store.CreateJobParams{}, fmt.Errorf("timeout_ms must not be negative")
+	}
+
+	params, err := jobdb.Resolve(s.registry, store.CreateJobParams{
+		Type:     req.Type,
+		Payload:  req.Payload,
+		RunAt:    runAt,
+		Priority: int(req.Priority),
+		Queue:    req.Queue,
+
+		IdempotencyKey: req.IdempotencyKey,
+		IdempotencyTTL: s.opts.IdempotencyTTL,
+
+		RetryPolicy: retryPolicy,
+		Timeout:     time.Duration(req.TimeoutMs) * time.Millisecond,
+	})
+	if err != nil {
+		return store.CreateJobParams{}, err
+	}
+	if len(s.opts.Queues) > 0 && !slices.Contains(s.opts.Queues, params.Queue) {
+		return store.CreateJobParams{}, fmt.Errorf("queue '%s' has no worker pool (configured queues: %s)", params.Queue, strings.Join(s.opts.Queues, ", "))
+	}
+	return params, nil
+}
+
+// parseRunAt resolves the requested scheduling time of a job. It returns nil
+// when the job should run as soon as possible.
+func parseRunAt(runAt string, delaySeconds int64) (*time.Time, error) {
+	if runAt != "" && delaySeconds != 0 {
+		return nil, fmt.Errorf("run_at and delay_seconds are mutually exclusive")
+	}
+
+	if delaySeconds < 0 {
+		return nil, fmt.Errorf("delay_seconds must not be negative")
+	}
+
+	if delaySeconds > 0 {
+		t := time.Now().Add(time.Duration(delaySeconds) * time.Second)
+		return &t, nil
+	}
+
+	if runAt != "" {
+		t, err := time.Parse(time.RFC3339, runAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid run_at format (expected RFC 3339): %v", runAt)
+		}
+		return &t, nil
+	}
+
+	return nil, nil
+}
+
+// parseRetryPolicy converts a per-job retry policy override. It returns nil when
+// the job uses the policy of its type.
+func parseRetryPolicy(p *pb.RetryPolicy) (*store.RetryPolicy, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	policy := store.RetryPolicy{
+		MaxAttempts: int(p.MaxAttempts),
+		BaseDelay:   time.Duration(p.BaseDelayMs) * time.Millisecond,
+		MaxDelay:    time.Duration(p.MaxDelayMs) * time.Millisecond,
+		Backoff:     store.BackoffStrategy(p.Backoff),
+		Jitter:      p.Jitter,
+	}
+	if policy == (store.RetryPolicy{}) {
+		return nil, nil
+	}
+
+	return &policy, nil
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/scheduler"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CreateSchedule(ctx context.Context, req *pb.CreateScheduleRequest) (*pb.ScheduleResponse, error) {
+	logger.Info("Received schedule creation", "name", req.Name, "cron", req.CronExpr, "type", req.JobType)
+
+	if req.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "schedule name is required")
+	}
+	if !s.registry.Has(req.JobType) {
+		return nil, status.Errorf(codes.InvalidArgument, "job type '%s' is not registered", req.JobType)
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+	if req.PayloadTemplate == "" {
+		req.PayloadTemplate = "{}"
+	}
+	policy := store.CatchupPolicy(req.CatchupPolicy)
+	if policy == "" {
+		policy = store.CatchupSkip
+	}
+
+	if err := scheduler.Validate(req.CronExpr, req.Timezone, policy, req.PayloadTemplate); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	nextRunAt, err := scheduler.NextRun(req.CronExpr, req.Timezone, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	sc, err := s.store.CreateSchedule(ctx, store.CreateScheduleParams{
+		Name:            req.Name,
+		CronExpr:        req.CronExpr,
+		Timezone:        req.Timezone,
+		JobType:         req.JobType,
+		PayloadTemplate: req.PayloadTemplate,
+		CatchupPolicy:   policy,
+		NextRunAt:       nextRunAt,
+	})
+	if errors.Is(err, store.ErrAlreadyExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "schedule %q already exists", req.Name)
+	}
+	if err != nil {
+		logger.Error("Failed to create schedule", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create schedule: %v", err)
+	}
+
+	logger.Info("schedule created successfully", "schedule_id", sc.ID, "next_run_at", sc.NextRunAt)
+
+	return scheduleToProto(sc), nil
+}
+
+func (s *Server) ListSchedules(ctx context.Context, req *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+	schedules, err := s.store.ListSchedules(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list schedules: %v", err)
+	}
+
+	var pbSchedules []*pb.ScheduleResponse
+	for i := range schedules {
+		pbSchedules = append(pbSchedules, scheduleToProto(&schedules[i]))
+	}
+
+	return &pb.ListSchedulesResponse{Schedules: pbSchedules}, nil
+}
+
+func (s *Server) PauseSchedule(ctx context.Context, req *pb.PauseScheduleRequest) (*pb.ScheduleResponse, error) {
+	id, err := strconv.ParseInt(req.ScheduleId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid schedule id format: %v", req.ScheduleId)
+	}
+
+	current, err := s.store.GetSchedule(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "schedule %d not found", id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get schedule: %v", err)
+	}
+
+	// Resuming restarts the schedule from its next tick so the paused period is not replayed.
+	nextRunAt := current.NextRunAt
+	if req.Resume {
+		nextRunAt, err = scheduler.NextRun(current.CronExpr, current.Timezone, time.Now())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+	}
+
+	sc, err := s.store.SetSchedulePaused(ctx, id, !req.Resume, nextRunAt)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "schedule %d not found", id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update schedule: %v", err)
+	}
+
+	logger.Info("schedule updated", "schedule_id", sc.ID, "paused", sc.Paused)
+
+	return scheduleToProto(sc), nil
+}
+
+func (s *Server) DeleteSchedule(ctx context.Context, req *pb.DeleteScheduleRequest) (*pb.DeleteScheduleResponse, error) {
+	id, err := strconv.ParseInt(req.ScheduleId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid schedule id format: %v", req.ScheduleId)
+	}
+
+	if err := s.store.DeleteSchedule(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "schedule %d not found", id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete schedule: %v", err)
+	}
+
+	logger.Info("schedule deleted", "schedule_id", id)
+
+	return &pb.DeleteScheduleResponse{}, nil
+}
+
+func scheduleToProto(sc *store.Schedule) *pb.ScheduleResponse {
+	resp := &pb.ScheduleResponse{
+		ScheduleId:      strconv.FormatInt(sc.ID, 10),
+		Name:            sc.Name,
+		CronExpr:        sc.CronExpr,
+		Timezone:        sc.Timezone,
+		JobType:         sc.JobType,
+		PayloadTemplate: sc.PayloadTemplate,
+		CatchupPolicy:   string(sc.CatchupPolicy),
+		Paused:          sc.Paused,
+		NextRunAt:       sc.NextRunAt.Format("2006-01-02T15:04:05Z"),
+		CreatedAt:       sc.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if sc.LastRunAt != nil {
+		resp.LastRunAt = sc.LastRunAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return resp
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultStatsWindow = time.Hour
+	defaultStatsBucket = time.Minute
+	// maxStatsBuckets bounds the size of a GetJobTypeStats response.
+	maxStatsBuckets = 1440
+)
+
+func (s *Server) GetJobTypeStats(ctx context.Context, req *pb.GetJobTypeStatsRequest) (*pb.GetJobTypeStatsResponse, error) {
+	params := store.TypeStatsParams{
+		Type:   req.Type,
+		Window: time.Duration(req.WindowSeconds) * time.Second,
+		Bucket: defaultStatsBucket,
+	}
+	if req.BucketSeconds != nil {
+		params.Bucket = time.Duration(req.GetBucketSeconds()) * time.Second
+	}
+
+	if params.Window < 0 || params.Bucket < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "window_seconds and bucket_seconds must not be negative")
+	}
+	if params.Window == 0 {
+		params.Window = defaultStatsWindow
+	}
+	if params.Bucket > params.Window {
+		params.Bucket = params.Window
+	}
+	if params.Bucket > 0 && params.Window/params.Bucket > maxStatsBuckets {
+		return nil, status.Errorf(codes.InvalidArgument, "window holds more than %d buckets, use a larger bucket_seconds", maxStatsBuckets)
+	}
+
+	stats, err := s.store.GetJobTypeStats(ctx, params)
+	if err != nil {
+		logger.Error("Failed to get job type stats", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get job type stats: %v", err)
+	}
+
+	resp := &pb.GetJobTypeStatsResponse{}
+	for _, st := range stats {
+		types := &pb.JobTypeStats{Type: st.Type, Total: windowStatsToProto(st.Total)}
+		for _, b := range st.Buckets {
+			types.Buckets = append(types.Buckets, windowStatsToProto(b))
+		}
+		resp.Types = append(resp.Types, types)
+	}
+
+	return resp, nil
+}
+
+func windowStatsToProto(w store.WindowStats) *pb.JobWindowStats {
+	return &pb.JobWindowStats{
+		Start:               w.Start.Format("2006-01-02T15:04:05Z"),
+		End:                 w.End.Format("2006-01-02T15:04:05Z"),
+		Completed:           w.Completed,
+		Failed:              w.Failed,
+		Started:             w.Started,
+		ThroughputPerMinute: w.ThroughputPerMinute(),
+		ThroughputPerHour:   w.ThroughputPerMinute() * 60,
+		SuccessRatio:        w.SuccessRatio(),
+		AvgWaitMs:           w.AvgWait.Milliseconds(),
+		P95WaitMs:           w.P95Wait.Milliseconds(),
+		AvgDurationMs:       w.AvgDuration.Milliseconds(),
+		P95DurationMs:       w.P95Duration.Milliseconds(),
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultWatchInterval = time.Second
+
+func (s *Server) WatchJob(req *pb.GetJobRequest, stream pb.JobScheduler_WatchJobServer) error {
+	id, err := strconv.ParseInt(req.JobId, 10, 64)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid job id format: %v", req.JobId)
+	}
+
+	interval := s.opts.WatchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ctx := stream.Context()
+	var last *pb.GetJobResponse
+	for {
+		job, err := s.store.GetJobByID(ctx, id)
+		if errors.Is(err, store.ErrNotFound) {
+			if last == nil {
+				return status.Errorf(codes.NotFound, "job %d not found", id)
+			}
+			// an unfinished job only leaves the jobs table by being dead-lettered
+			dead := proto.Clone(last).(*pb.GetJobResponse)
+			dead.Status = string(store.JobStatusDead)
+			return stream.Send(dead)
+		}
+		if err != nil {
+			logger.Error("Failed to watch job", "job_id", id, "error", err)
+			return status.Errorf(codes.Internal, "failed to get job: %v", err)
+		}
+
+		resp := jobToProto(job)
+		if last == nil || watchChanged(last, resp) {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			last = resp
+		}
+
+		if job.Status.Finished() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchChanged reports whether a watcher should be sent the new state of a job.
+// Lease renewals alone are not worth an update.
+func watchChanged(prev, next *pb.GetJobResponse) bool {
+	return prev.Status != next.Status ||
+		prev.RetryCount != next.RetryCount ||
+		prev.ErrorMessage != next.ErrorMessage ||
+		prev.GetProgress() != next.GetProgress() ||
+		prev.ProgressMessage != next.ProgressMessage ||
+		prev.Result != next.Result
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	pb "github.com/bhanuprakaash/job-scheduler/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) SubmitWorkflow(ctx context.Context, req *pb.SubmitWorkflowRequest) (*pb.SubmitWorkflowResponse, error) {
+	logger.Info("Received workflow submission", "name", req.Name, "jobs", len(req.Jobs))
+
+	if len(req.Jobs) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "workflow has no jobs")
+	}
+
+	ordered, err := orderWorkflowJobs(req.Jobs)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	params := store.CreateWorkflowParams{Name: req.Name}
+	for _, j := range ordered {
+		// workflow jobs are validated like single submissions
+		job, err := s.jobParams(&pb.SubmitJobRequest{
+			Type:        j.Type,
+			Payload:     j.Payload,
+			Priority:    j.Priority,
+			Queue:       j.Queue,
+			RetryPolicy: j.RetryPolicy,
+			TimeoutMs:   j.TimeoutMs,
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "job %q: %v", j.Key, err)
+		}
+
+		params.Steps = append(params.Steps, store.CreateWorkflowStepParams{
+			Key:       j.Key,
+			DependsOn: j.DependsOn,
+			Job:       job,
+		})
+	}
+
+	wf, err := s.store.CreateWorkflow(ctx, params)
+	if errors.Is(err, store.ErrAlreadyExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+	}
+	if err != nil {
+		logger.Error("Failed to create workflow", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create workflow: %v", err)
+	}
+
+	logger.Info("workflow created successfully", "workflow_id", wf.ID, "jobs", len(wf.Steps))
+
+	return &pb.SubmitWorkflowResponse{
+		WorkflowId: strconv.FormatInt(wf.ID, 10),
+		Jobs:       workflowStepsToProto(wf.Steps),
+	}, nil
+}
+
+func (s *Server) GetWorkflow(ctx context.Context, req *pb.GetWorkflowRequest) (*pb.GetWorkflowResponse, error) {
+	id, err := strconv.ParseInt(req.WorkflowId, 10, 64)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid workflow id format: %v", req.WorkflowId)
+	}
+
+	wf, err := s.store.GetWorkflow(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "workflow %d not found", id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get workflow: %v", err)
+	}
+
+	counts := make(map[string]int32)
+	for _, step := range wf.Steps {
+		counts[string(step.Status)]++
+	}
+
+	return &pb.GetWorkflowResponse{
+		WorkflowId:   strconv.FormatInt(wf.ID, 10),
+		Name:         wf.Name,
+		Status:       string(wf.Status()),
+		CreatedAt:    wf.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		Jobs:         workflowStepsToProto(wf.Steps),
+		StatusCounts: counts,
+	}, nil
+}
+
+// orderWorkflowJobs validates the dependency graph and returns the jobs ordered
+// so that every job comes after the jobs it depends on. Jobs without an ordering
+// constraint keep their submission order.
+func orderWorkflowJobs(jobs []*pb.WorkflowJob) ([]*pb.WorkflowJob, error) {
+	byKey := make(map[string]*pb.WorkflowJob, len(jobs))
+	for _, j := range jobs {
+		if j.Key == "" {
+			return nil, fmt.Errorf("every workflow job needs a key")
+		}
+		if _, ok := byKey[j.Key]; ok {
+			return nil, fmt.Errorf("duplicate workflow job key %q", j.Key)
+		}
+		byKey[j.Key] = j
+	}
+
+	for _, j := range jobs {
+		for _, dep := range j.DependsOn {
+			if dep == j.Key {
+				return nil, fmt.Errorf("job %q depends on itself", j.Key)
+			}
+			if _, ok := byKey[dep]; !ok {
+				return nil, fmt.Errorf("job %q depends on unknown job %q", j.Key, dep)
+			}
+		}
+	}
+
+	ordered := make([]*pb.WorkflowJob, 0, len(jobs))
+	placed := make(map[string]bool, len(jobs))
+	for len(ordered) < len(jobs) {
+		progress := false
+		for _, j := range jobs {
+			if placed[j.Key] {
+				continue
+			}
+			ready := true
+			for _, dep := range j.DependsOn {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, j)
+				placed[j.Key] = true
+				progress = true
+			}
+		}
+		if !progress {
+			return nil, fmt.Errorf("workflow contains a dependency cycle")
+		}
+	}
+
+	return ordered, nil
+}
+
+func workflowStepsToProto(steps []store.WorkflowStep) []*pb.WorkflowJobStatus {
+	var out []*pb.WorkflowJobStatus
+	for _, step := range steps {
+		out = append(out, &pb.WorkflowJobStatus{
+			Key:          step.Key,
+			JobId:        strconv.FormatInt(step.JobID, 10),
+			Type:         step.Type,
+			Status:       string(step.Status),
+			ErrorMessage: step.ErrorMessage.String,
+			DependsOn:    step.DependsOn,
+		})
+	}
+	return out
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/bhanuprakaash/job-scheduler/internal/blob"
 	"github.com/bhanuprakaash/job-scheduler/internal/logger"
 	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	"github.com/bhanuprakaash/job-scheduler/internal/worker"
 )
 
 type InvoiceItem struct {
@@ -29,6 +30,13 @@ type InvoiceJobPayload struct {
 	TotalAmount float32       `json:"amount"`
 }
 
+// InvoiceResult is stored with a completed invoice job.
+type InvoiceResult struct {
+	ObjectPath string `json:"object_path"`
+	// AlreadyExisted is set when an earlier run had generated the invoice.
+	AlreadyExisted bool `json:"already_existed,omitempty"`
+}
+
 type InvoiceJob struct {
 	storageClient blob.StorageClient
 }
@@ -40,9 +48,14 @@ func NewInvoiceJob(storageClient blob.StorageClient) *InvoiceJob {
 }
 
 func (e *InvoiceJob) Handle(ctx context.Context, job store.Job) error {
+	_, err := e.HandleResult(ctx, job)
+	return err
+}
+
+func (e *InvoiceJob) HandleResult(ctx context.Context, job store.Job) (any, error) {
 	var payload InvoiceJobPayload
 	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
-		return fmt.Errorf("parse invoice payload: %w", err)
+		return nil, fmt.Errorf("parse invoice payload: %w", err)
 	}
 	startTime := time.Now()
 
@@ -51,14 +64,15 @@ func (e *InvoiceJob) Handle(ctx context.Context, job store.Job) error {
 	// check: invoice exists
 	exists, err := e.storageClient.Exists(ctx, objectName)
 	if err != nil {
-		return fmt.Errorf("error checking object: %w", err)
+		return nil, fmt.Errorf("error checking object: %w", err)
 	}
 	if exists {
 		logger.Info("Invoice already exists, skipping", "invoice", payload.InvoiceId)
-		return nil
+		return InvoiceResult{ObjectPath: objectName, AlreadyExisted: true}, nil
 	}
 
 	// generate pdf
+	worker.ReportProgress(ctx, 20, "generating pdf")
 	var buf bytes.Buffer
 	pdf := fpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
@@ -66,19 +80,21 @@ func (e *InvoiceJob) Handle(ctx context.Context, job store.Job) error {
 	generateInvoicePdf(pdf, payload)
 
 	if err := pdf.Output(&buf); err != nil {
-		return fmt.Errorf("failed to generate PDF: %w", err)
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
 	}
 
 	// upload to storage client
+	worker.ReportProgress(ctx, 70, "uploading pdf")
 	if err = e.storageClient.Upload(ctx, &buf, int64(buf.Len()), objectName, "application/pdf"); err != nil {
-		return fmt.Errorf("upload pdf: %w", err)
+		return nil, fmt.Errorf("upload pdf: %w", err)
 	}
 
 	duration := time.Since(startTime)
 
 	logger.Info("Invoice Generated Successfully", "invoice id", payload.InvoiceId, "duration", duration)
+	worker.ReportProgress(ctx, 100, "invoice generated")
 
-	return nil
+	return InvoiceResult{ObjectPath: objectName}, nil
 }
 
 func generateInvoicePdf(pdf *fpdf.Fpdf, payload InvoiceJobPayload) {
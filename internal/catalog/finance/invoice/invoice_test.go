@@ -95,4 +95,32 @@ func TestInvoiceJob_Handle_Idempotency(t *testing.T) {
 		t.Errorf("Unexpected error: %v", err)
 	}
 	// The assertion is inside the mock (UploadFunc fails test if called)
-}
\ No newline at end of file
+}
+
+func TestInvoiceJob_HandleResult_ObjectPath(t *testing.T) {
+	logger.Init()
+	job := NewInvoiceJob(&MockStorageClient{})
+
+	// 1. A fresh invoice reports where it was uploaded
+	payload := `{"user_id": "u1", "invoice_id": "inv_002", "date": "2026-01-27", "amount": 100.00, "currency": "USD", "items": [{"description": "Service", "quantity": 1, "unit_price": 100}]}`
+	result, err := job.HandleResult(context.Background(), store.Job{Payload: payload})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := result.(InvoiceResult)
+	if got.ObjectPath != "secure/invoices/inv_002.pdf" || got.AlreadyExisted {
+		t.Errorf("Expected a new invoice at secure/invoices/inv_002.pdf, got %+v", got)
+	}
+
+	// 2. An existing invoice reports the same path
+	job = NewInvoiceJob(&MockStorageClient{
+		ExistsFunc: func(ctx context.Context, path string) (bool, error) { return true, nil },
+	})
+	result, err = job.HandleResult(context.Background(), store.Job{Payload: `{"invoice_id": "inv_002"}`})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := result.(InvoiceResult); !got.AlreadyExisted || got.ObjectPath != "secure/invoices/inv_002.pdf" {
+		t.Errorf("Expected the existing invoice to be reported, got %+v", got)
+	}
+}
@@ -1,15 +1,20 @@
 package archive
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/bhanuprakaash/job-scheduler/internal/blob"
 	"github.com/bhanuprakaash/job-scheduler/internal/logger"
 	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	"github.com/bhanuprakaash/job-scheduler/internal/worker"
 )
 
 type ArchiveJobPayload struct {
@@ -42,6 +47,11 @@ func (a *ArchiveJob) Handle(ctx context.Context, job store.Job) error {
 		return fmt.Errorf("invalid duration format '%s': %w", payload.OlderThanStr, err)
 	}
 
+	if partitioner, ok := a.store.(store.JobPartitioner); ok {
+		return a.archivePartitions(ctx, partitioner, time.Now().Add(-olderThan), startTime)
+	}
+
+	worker.ReportProgress(ctx, 0, "selecting jobs to archive")
 	jobs, err := a.store.GetArchivedJobs(ctx, olderThan, payload.BatchSize)
 	if err != nil {
 		return fmt.Errorf("get archive jobs: %w", err)
@@ -49,9 +59,12 @@ func (a *ArchiveJob) Handle(ctx context.Context, job store.Job) error {
 
 	if len(jobs) == 0 {
 		logger.Info("No jobs to archive")
+		worker.ReportProgress(ctx, 100, "no jobs to archive")
 		return nil
 	}
 
+	worker.ReportProgress(ctx, 25, fmt.Sprintf("exporting %d jobs", len(jobs)))
+
 	data, err := json.MarshalIndent(jobs, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal jobs: %w", err)
@@ -59,6 +72,8 @@ func (a *ArchiveJob) Handle(ctx context.Context, job store.Job) error {
 
 	filename := fmt.Sprintf("archives/jobs_%s.json", time.Now().Format("2006-01-02_15-04-05"))
 
+	worker.ReportProgress(ctx, 50, fmt.Sprintf("uploading %s", filename))
+
 	err = a.uploader.Upload(
 		ctx,
 		bytes.NewReader(data),
@@ -76,6 +91,7 @@ func (a *ArchiveJob) Handle(ctx context.Context, job store.Job) error {
 		ids = append(ids, j.ID)
 	}
 
+	worker.ReportProgress(ctx, 75, fmt.Sprintf("deleting %d archived jobs", len(ids)))
 	if err = a.store.BatchDeleteJobs(ctx, ids); err != nil {
 		return fmt.Errorf("failed to delete archived jobs: %w", err)
 	}
@@ -83,7 +99,103 @@ func (a *ArchiveJob) Handle(ctx context.Context, job store.Job) error {
 	duration := time.Since(startTime)
 
 	logger.Info("Archived and Deleted jobs", "count", len(ids), "duration", duration)
+	worker.ReportProgress(ctx, 100, fmt.Sprintf("archived %d jobs", len(ids)))
 
 	return nil
 
 }
+
+// archivePartitions removes the partitions of jobs that ended before cutoff:
+// each one is detached, exported to the archive bucket as NDJSON and dropped.
+// Partitions left detached by an earlier run are exported and dropped too;
+// partitions that still hold pending or running jobs are kept.
+func (a *ArchiveJob) archivePartitions(ctx context.Context, partitioner store.JobPartitioner, cutoff time.Time, startTime time.Time) error {
+	worker.ReportProgress(ctx, 0, "selecting partitions to archive")
+	partitions, err := partitioner.ListJobPartitions(ctx)
+	if err != nil {
+		return fmt.Errorf("list partitions: %w", err)
+	}
+
+	var expired []store.JobPartition
+	for _, p := range partitions {
+		if !p.Attached || (p.To != nil && !p.To.After(cutoff)) {
+			expired = append(expired, p)
+		}
+	}
+
+	if len(expired) == 0 {
+		logger.Info("No partitions to archive")
+		worker.ReportProgress(ctx, 100, "no partitions to archive")
+		return nil
+	}
+
+	var archived int
+	var jobs int64
+	for i, p := range expired {
+		worker.ReportProgress(ctx, i*100/len(expired), fmt.Sprintf("archiving %s", p.Name))
+
+		if p.Attached {
+			err := partitioner.DetachJobPartition(ctx, p.Name)
+			if errors.Is(err, store.ErrPartitionActive) {
+				logger.Info("Keeping partition with active jobs", "partition", p.Name)
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("detach partition: %w", err)
+			}
+		}
+
+		n, err := a.exportPartition(ctx, partitioner, p.Name)
+		if err != nil {
+			return err
+		}
+
+		if err := partitioner.DropJobPartition(ctx, p.Name); err != nil {
+			return fmt.Errorf("drop partition: %w", err)
+		}
+
+		logger.Info("Archived and dropped partition", "partition", p.Name, "jobs", n)
+		archived++
+		jobs += n
+	}
+
+	logger.Info("Archived partitions", "count", archived, "jobs", jobs, "duration", time.Since(startTime))
+	worker.ReportProgress(ctx, 100, fmt.Sprintf("archived %d partitions (%d jobs)", archived, jobs))
+
+	return nil
+}
+
+// exportPartition uploads the jobs of a detached partition, staging them in a
+// temporary file since a partition can be much larger than memory.
+func (a *ArchiveJob) exportPartition(ctx context.Context, partitioner store.JobPartitioner, name string) (int64, error) {
+	f, err := os.CreateTemp("", name+"-*.ndjson")
+	if err != nil {
+		return 0, fmt.Errorf("create export file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	n, err := partitioner.ExportJobPartition(ctx, name, w)
+	if err != nil {
+		return 0, fmt.Errorf("export partition: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return 0, fmt.Errorf("write export file: %w", err)
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, fmt.Errorf("write export file: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("rewind export file: %w", err)
+	}
+
+	filename := fmt.Sprintf("archives/%s.ndjson", name)
+	if err := a.uploader.Upload(ctx, f, size, filename, "application/x-ndjson"); err != nil {
+		return 0, fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	return n, nil
+}
@@ -2,8 +2,12 @@ package archive
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,87 +34,76 @@ func (m *MockUploader) Exists(ctx context.Context, path string) (bool, error) {
 	return false, nil
 }
 
-type MockStore struct {
-	GetArchivedJobsFunc func(ctx context.Context, duration time.Duration, limit int) ([]store.Job, error)
-	BatchDeleteJobsFunc func(ctx context.Context, ids []int64) error
-	// Embed the interface to satisfy unused methods automatically (panic if called unexpectedly)
-	store.Storer
-}
-
-func (m *MockStore) GetArchivedJobs(ctx context.Context, d time.Duration, l int) ([]store.Job, error) {
-	if m.GetArchivedJobsFunc != nil {
-		return m.GetArchivedJobsFunc(ctx, d, l)
+// completedJobs stores n jobs that ran to completion.
+func completedJobs(t *testing.T, s *store.MemoryStore, n int) []int64 {
+	t.Helper()
+	ctx := context.Background()
+	var ids []int64
+	for i := 0; i < n; i++ {
+		if _, err := s.CreateJob(ctx, store.CreateJobParams{Type: "test:archive", Payload: "{}"}); err != nil {
+			t.Fatalf("CreateJob failed: %v", err)
+		}
 	}
-	return nil, nil
-}
-
-func (m *MockStore) BatchDeleteJobs(ctx context.Context, ids []int64) error {
-	if m.BatchDeleteJobsFunc != nil {
-		return m.BatchDeleteJobsFunc(ctx, ids)
+	jobs, err := s.GetPendingJobs(ctx, store.ClaimParams{Limit: n, Owner: "test-worker", LeaseDuration: time.Minute})
+	if err != nil {
+		t.Fatalf("GetPendingJobs failed: %v", err)
 	}
-	return nil
+	for _, j := range jobs {
+		if err := s.CompleteJob(ctx, j.ID, j.Lease(), nil); err != nil {
+			t.Fatalf("CompleteJob failed: %v", err)
+		}
+		ids = append(ids, j.ID)
+	}
+	return ids
 }
 
 // --- Tests ---
 
 func TestArchiveJob_Handle_Success(t *testing.T) {
 	logger.Init()
-	// 1. Setup Data
-	mockJobs := []store.Job{
-		{ID: 101, Status: "completed"},
-		{ID: 102, Status: "completed"},
-	}
+	// 1. Setup Data: two jobs old enough to archive and a pending one
+	memStore := store.NewMemoryStore()
+	ids := completedJobs(t, memStore, 2)
+	pending, _ := memStore.CreateJob(context.Background(), store.CreateJobParams{Type: "test:archive", Payload: "{}"})
+	time.Sleep(20 * time.Millisecond)
 
 	// 2. Setup Mocks
-	uploaded := false
-	deleted := false
-
-	storeMock := &MockStore{
-		GetArchivedJobsFunc: func(ctx context.Context, d time.Duration, l int) ([]store.Job, error) {
-			return mockJobs, nil
-		},
-		BatchDeleteJobsFunc: func(ctx context.Context, ids []int64) error {
-			if len(ids) == 2 && ids[0] == 101 && ids[1] == 102 {
-				deleted = true
-			}
-			return nil
-		},
-	}
-
+	var archived []store.Job
 	uploaderMock := &MockUploader{
 		UploadFunc: func(ctx context.Context, data io.Reader, size int64, path, contentType string) error {
-			uploaded = true
-			return nil
+			return json.NewDecoder(data).Decode(&archived)
 		},
 	}
 
-	job := NewArchiveJob(storeMock, uploaderMock)
+	job := NewArchiveJob(memStore, uploaderMock)
 
 	// 3. Execute
 	err := job.Handle(context.Background(), store.Job{
-		Payload: `{"older_than": "24h", "batch": 100}`,
+		Payload: `{"older_than": "10ms", "batch": 100}`,
 	})
 
 	// 4. Verify
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
-	if !uploaded {
-		t.Error("Expected archive file to be uploaded")
+	if len(archived) != 2 || archived[0].ID != ids[0] || archived[1].ID != ids[1] {
+		t.Errorf("Expected jobs %v to be uploaded, got %+v", ids, archived)
+	}
+	for _, id := range ids {
+		if _, err := memStore.GetJobByID(context.Background(), id); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Expected job %d to be deleted from DB, got %v", id, err)
+		}
 	}
-	if !deleted {
-		t.Error("Expected processed jobs to be deleted from DB")
+	if _, err := memStore.GetJobByID(context.Background(), pending.ID); err != nil {
+		t.Errorf("Expected the pending job to be kept, got %v", err)
 	}
 }
 
 func TestArchiveJob_Handle_NoJobs(t *testing.T) {
 	logger.Init()
-	// Setup: Store returns empty list
-	storeMock := &MockStore{
-		GetArchivedJobsFunc: func(ctx context.Context, d time.Duration, l int) ([]store.Job, error) {
-			return []store.Job{}, nil
-		},
-	}
+	// Setup: the only completed job is too recent
+	memStore := store.NewMemoryStore()
+	completedJobs(t, memStore, 1)
 
 	uploaderMock := &MockUploader{
 		UploadFunc: func(ctx context.Context, data io.Reader, size int64, path, contentType string) error {
@@ -119,7 +112,7 @@ func TestArchiveJob_Handle_NoJobs(t *testing.T) {
 		},
 	}
 
-	job := NewArchiveJob(storeMock, uploaderMock)
+	job := NewArchiveJob(memStore, uploaderMock)
 
 	err := job.Handle(context.Background(), store.Job{
 		Payload: `{"older_than": "24h", "batch": 100}`,
@@ -133,29 +126,140 @@ func TestArchiveJob_Handle_NoJobs(t *testing.T) {
 func TestArchiveJob_Handle_UploadFails_NoDelete(t *testing.T) {
 	logger.Init()
 
-	storeMock := &MockStore{
-		GetArchivedJobsFunc: func(ctx context.Context, d time.Duration, l int) ([]store.Job, error) {
-			return []store.Job{{ID: 1}}, nil
+	memStore := store.NewMemoryStore()
+	ids := completedJobs(t, memStore, 1)
+	time.Sleep(20 * time.Millisecond)
+
+	uploaderMock := &MockUploader{
+		UploadFunc: func(ctx context.Context, data io.Reader, size int64, path, contentType string) error {
+			return errors.New("s3 bucket unavailable")
 		},
-		BatchDeleteJobsFunc: func(ctx context.Context, ids []int64) error {
-			t.Error("BatchDeleteJobs should NOT be called if upload fails")
+	}
+
+	job := NewArchiveJob(memStore, uploaderMock)
+
+	err := job.Handle(context.Background(), store.Job{
+		Payload: `{"older_than": "10ms", "batch": 100}`,
+	})
+
+	if err == nil {
+		t.Error("Expected error from upload failure, got nil")
+	}
+	if _, err := memStore.GetJobByID(context.Background(), ids[0]); err != nil {
+		t.Errorf("Expected the job to be kept if upload fails, got %v", err)
+	}
+}
+
+type MockPartitionStore struct {
+	*store.MemoryStore
+	Partitions []store.JobPartition
+	Active     map[string]bool
+	Calls      []string
+}
+
+func (m *MockPartitionStore) GetArchivedJobs(ctx context.Context, d time.Duration, l int) ([]store.Job, error) {
+	m.Calls = append(m.Calls, "get archived jobs")
+	return nil, nil
+}
+
+func (m *MockPartitionStore) EnsureJobPartitions(ctx context.Context, ahead time.Duration) ([]string, error) {
+	return nil, nil
+}
+
+func (m *MockPartitionStore) ListJobPartitions(ctx context.Context) ([]store.JobPartition, error) {
+	return m.Partitions, nil
+}
+
+func (m *MockPartitionStore) DetachJobPartition(ctx context.Context, name string) error {
+	if m.Active[name] {
+		return fmt.Errorf("partition %s: %w", name, store.ErrPartitionActive)
+	}
+	m.Calls = append(m.Calls, "detach "+name)
+	return nil
+}
+
+func (m *MockPartitionStore) ExportJobPartition(ctx context.Context, name string, w io.Writer) (int64, error) {
+	m.Calls = append(m.Calls, "export "+name)
+	io.WriteString(w, `{"id": 1}`+"\n")
+	return 1, nil
+}
+
+func (m *MockPartitionStore) DropJobPartition(ctx context.Context, name string) error {
+	m.Calls = append(m.Calls, "drop "+name)
+	return nil
+}
+
+func TestArchiveJob_Handle_Partitions(t *testing.T) {
+	logger.Init()
+	day := func(offset int) *time.Time {
+		d := time.Now().Truncate(24*time.Hour).AddDate(0, 0, offset)
+		return &d
+	}
+
+	// 1. Two expired partitions (one with active jobs), a leftover detached one and a recent one
+	storeMock := &MockPartitionStore{
+		MemoryStore: store.NewMemoryStore(),
+		Partitions: []store.JobPartition{
+			{Name: "jobs_legacy", To: day(-40), Attached: true},
+			{Name: "jobs_p20260101", Attached: false},
+			{Name: "jobs_p20260102", From: day(-32), To: day(-31), Attached: true},
+			{Name: "jobs_p20260201", From: day(-1), To: day(0), Attached: true},
+		},
+		Active: map[string]bool{"jobs_legacy": true},
+	}
+
+	var uploaded []string
+	uploaderMock := &MockUploader{
+		UploadFunc: func(ctx context.Context, data io.Reader, size int64, path, contentType string) error {
+			body, _ := io.ReadAll(data)
+			if int64(len(body)) != size {
+				t.Errorf("Expected %d bytes, got %d", size, len(body))
+			}
+			uploaded = append(uploaded, path)
 			return nil
 		},
 	}
 
+	err := NewArchiveJob(storeMock, uploaderMock).Handle(context.Background(), store.Job{
+		Payload: `{"older_than": "720h", "batch": 100}`,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 2. Expired partitions are detached, exported and dropped; the others are kept
+	want := []string{
+		"export jobs_p20260101", "drop jobs_p20260101",
+		"detach jobs_p20260102", "export jobs_p20260102", "drop jobs_p20260102",
+	}
+	if strings.Join(storeMock.Calls, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, storeMock.Calls)
+	}
+	if len(uploaded) != 2 || uploaded[1] != "archives/jobs_p20260102.ndjson" {
+		t.Errorf("Expected two partition exports, got %v", uploaded)
+	}
+}
+
+func TestArchiveJob_Handle_Partitions_UploadFails_NoDrop(t *testing.T) {
+	logger.Init()
+	old := time.Now().AddDate(0, 0, -60)
+	storeMock := &MockPartitionStore{
+		MemoryStore: store.NewMemoryStore(),
+		Partitions:  []store.JobPartition{{Name: "jobs_p20260101", To: &old, Attached: true}},
+	}
 	uploaderMock := &MockUploader{
 		UploadFunc: func(ctx context.Context, data io.Reader, size int64, path, contentType string) error {
 			return errors.New("s3 bucket unavailable")
 		},
 	}
 
-	job := NewArchiveJob(storeMock, uploaderMock)
-
-	err := job.Handle(context.Background(), store.Job{
-		Payload: `{"older_than": "24h", "batch": 100}`,
+	err := NewArchiveJob(storeMock, uploaderMock).Handle(context.Background(), store.Job{
+		Payload: `{"older_than": "720h"}`,
 	})
-
 	if err == nil {
 		t.Error("Expected error from upload failure, got nil")
 	}
+	if slices.Contains(storeMock.Calls, "drop jobs_p20260101") {
+		t.Error("DropJobPartition should NOT be called if upload fails")
+	}
 }
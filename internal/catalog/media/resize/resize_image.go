@@ -26,6 +26,13 @@ type imageResizePayload struct {
 	OutputPath string `json:"output_path"`
 }
 
+// ImageResizeResult is stored with a completed resize job.
+type ImageResizeResult struct {
+	OutputPath string `json:"output_path"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+}
+
 type ImageResizeJob struct {
 	blobUploader blob.Uploader
 }
@@ -37,26 +44,31 @@ func NewImageResizeJob(blobUploader blob.Uploader) *ImageResizeJob {
 }
 
 func (r *ImageResizeJob) Handle(ctx context.Context, job store.Job) error {
+	_, err := r.HandleResult(ctx, job)
+	return err
+}
+
+func (r *ImageResizeJob) HandleResult(ctx context.Context, job store.Job) (any, error) {
 	var payload imageResizePayload
 	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
-		return fmt.Errorf("invalid payload: %w", err)
+		return nil, fmt.Errorf("invalid payload: %w", err)
 	}
 
 	start := time.Now()
 
 	resp, err := http.Get(payload.ImageSrc)
 	if err != nil {
-		return fmt.Errorf("error downloading image: %w", err)
+		return nil, fmt.Errorf("error downloading image: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("received non-200 status code:%d", resp.StatusCode)
+		return nil, fmt.Errorf("received non-200 status code:%d", resp.StatusCode)
 	}
 
 	srcImg, _, err := image.Decode(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to decode image: %w", err)
+		return nil, fmt.Errorf("failed to decode image: %w", err)
 	}
 
 	bounds := srcImg.Bounds()
@@ -69,18 +81,18 @@ func (r *ImageResizeJob) Handle(ctx context.Context, job store.Job) error {
 
 	var buf bytes.Buffer
 	if err := jpeg.Encode(&buf, dstImg, &jpeg.Options{Quality: 80}); err != nil {
-		return fmt.Errorf("failed to encode jpeg: %w", err)
+		return nil, fmt.Errorf("failed to encode jpeg: %w", err)
 	}
 
 	err = r.blobUploader.Upload(ctx, &buf, int64(buf.Len()), payload.OutputPath, "image/jpeg")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	duration := time.Since(start)
 
 	logger.Info("Image Resized Successfully", "job_id", job.ID, "duration", duration, "output path", payload.OutputPath)
 
-	return nil
+	return ImageResizeResult{OutputPath: payload.OutputPath, Width: payload.Width, Height: newHeight}, nil
 
 }
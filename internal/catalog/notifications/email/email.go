@@ -16,6 +16,12 @@ type EmailPayload struct {
 	Subject string `json:"subject"`
 	Body    string `json:"body"`
 }
+
+// EmailResult is stored with a completed email job.
+type EmailResult struct {
+	MessageID string `json:"message_id"`
+}
+
 type EmailJob struct {
 	sender mailer.Sender
 }
@@ -27,10 +33,15 @@ func NewEmailJob(sender mailer.Sender) *EmailJob {
 }
 
 func (e *EmailJob) Handle(ctx context.Context, job store.Job) error {
+	_, err := e.HandleResult(ctx, job)
+	return err
+}
+
+func (e *EmailJob) HandleResult(ctx context.Context, job store.Job) (any, error) {
 	var payload EmailPayload
 	startTime := time.Now()
 	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
-		return fmt.Errorf("failed to parse email payload: %w", err)
+		return nil, fmt.Errorf("failed to parse email payload: %w", err)
 	}
 
 	logger.Info("Sending email",
@@ -39,18 +50,19 @@ func (e *EmailJob) Handle(ctx context.Context, job store.Job) error {
 		"subject", payload.Subject,
 		"retry", job.RetryCount)
 
-	err := e.sender.Send(ctx, payload.To, payload.Subject, payload.Body)
+	messageID, err := e.sender.Send(ctx, payload.To, payload.Subject, payload.Body)
 	if err != nil {
 		logger.Error("Email send failed",
 			"job_id", job.ID,
 			"to", payload.To,
 			"duration", time.Since(startTime),
 			"error", err)
-		return fmt.Errorf("error sending mail: %w", err)
+		return nil, fmt.Errorf("error sending mail: %w", err)
 	}
 	logger.Info("Email sent successfully",
 		"job_id", job.ID,
 		"to", payload.To,
+		"message_id", messageID,
 		"duration", time.Since(startTime))
-	return nil
+	return EmailResult{MessageID: messageID}, nil
 }
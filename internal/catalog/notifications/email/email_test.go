@@ -10,14 +10,17 @@ import (
 )
 
 type MockSender struct {
-	SendFunc func(ctx context.Context, to, subject, body string) error
+	SendFunc  func(ctx context.Context, to, subject, body string) error
+	MessageID string
 }
 
-func (m *MockSender) Send(ctx context.Context, to, subject, body string) error {
+func (m *MockSender) Send(ctx context.Context, to, subject, body string) (string, error) {
 	if m.SendFunc != nil {
-		return m.SendFunc(ctx, to, subject, body)
+		if err := m.SendFunc(ctx, to, subject, body); err != nil {
+			return "", err
+		}
 	}
-	return nil
+	return m.MessageID, nil
 }
 
 func TestEmailJob_Handle_Success(t *testing.T) {
@@ -66,3 +69,20 @@ func TestEmailJob_Handle_Failure_Retries(t *testing.T) {
 		t.Error("Expected error when email service fails, got nil")
 	}
 }
+
+func TestEmailJob_HandleResult_MessageID(t *testing.T) {
+	logger.Init()
+	jobHandler := NewEmailJob(&MockSender{MessageID: "msg_123"})
+
+	result, err := jobHandler.HandleResult(context.Background(), store.Job{
+		ID:      1,
+		Payload: `{"to": "test@example.com", "subject": "Hello", "body": "World"}`,
+	})
+
+	if err != nil {
+		t.Fatalf("HandleResult() returned error: %v", err)
+	}
+	if got := result.(EmailResult).MessageID; got != "msg_123" {
+		t.Errorf("Expected message id 'msg_123', got %q", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -18,6 +19,34 @@ type Config struct {
 	HTTP_PORT             string
 	METRICS_PORT          string
 
+	// job store backend: "postgres", "sqlite" (single node) or "memory" (nothing survives a restart)
+	STORE_DRIVER string
+	// database file of the sqlite store
+	SQLITE_PATH string
+
+	// apply pending schema migrations when the server starts
+	MIGRATE_ON_STARTUP bool
+
+	// days of daily jobs partitions kept created ahead of time
+	JOB_PARTITIONS_AHEAD_DAYS int
+
+	// cron schedules
+	SCHEDULER_POLL_INTERVAL_SECONDS int
+	SCHEDULER_MISFIRE_GRACE_SECONDS int
+
+	// dispatch: seconds a pending job waits to gain one priority point (0 disables aging)
+	PRIORITY_AGING_SECONDS int
+
+	// named queues, e.g. "default:5:2,notifications:10:1,media:2:5" (name:workers:poll_seconds).
+	// Workers and poll interval fall back to WORKERS_COUNT and POLL_INTERVAL_SECONDS.
+	QUEUES []QueueConfig
+
+	// hours an idempotency key stays reserved after its job was submitted
+	IDEMPOTENCY_KEY_TTL_HOURS int
+
+	// seconds a claimed job stays leased to a worker without a heartbeat before it is reclaimed
+	LEASE_DURATION_SECONDS int
+
 	// email
 	RESEND_EMAIL_API_KEY string
 	RESEND_FROM_EMAIL    string
@@ -31,6 +60,12 @@ type Config struct {
 	MINIO_USE_SSL  bool
 }
 
+type QueueConfig struct {
+	Name                string
+	Workers             int
+	PollIntervalSeconds int
+}
+
 func Load() (*Config, error) {
 	_ = godotenv.Load()
 
@@ -44,6 +79,21 @@ func Load() (*Config, error) {
 		HTTP_PORT:             getEnv("HTTP_PORT", "8080"),
 		METRICS_PORT:          getEnv("METRICS_PORT", "9090"),
 
+		STORE_DRIVER: getEnv("STORE_DRIVER", "postgres"),
+		SQLITE_PATH:  getEnv("SQLITE_PATH", "job-scheduler.db"),
+
+		MIGRATE_ON_STARTUP:        getEnvAsBool("MIGRATE_ON_STARTUP", true),
+		JOB_PARTITIONS_AHEAD_DAYS: getEnvAsInt("JOB_PARTITIONS_AHEAD_DAYS", 7),
+
+		SCHEDULER_POLL_INTERVAL_SECONDS: getEnvAsInt("SCHEDULER_POLL_INTERVAL_SECONDS", 10),
+		SCHEDULER_MISFIRE_GRACE_SECONDS: getEnvAsInt("SCHEDULER_MISFIRE_GRACE_SECONDS", 60),
+
+		PRIORITY_AGING_SECONDS: getEnvAsInt("PRIORITY_AGING_SECONDS", 0),
+
+		IDEMPOTENCY_KEY_TTL_HOURS: getEnvAsInt("IDEMPOTENCY_KEY_TTL_HOURS", 24),
+
+		LEASE_DURATION_SECONDS: getEnvAsInt("LEASE_DURATION_SECONDS", 30),
+
 		RESEND_EMAIL_API_KEY: getEnv("RESEND_EMAIL_API_KEY", ""),
 		RESEND_FROM_EMAIL:    getEnv("RESEND_FROM_EMAIL", ""),
 
@@ -54,8 +104,24 @@ func Load() (*Config, error) {
 		MINIO_USE_SSL:  getEnvAsBool("MINIO_USE_SSL", false),
 	}
 
-	if cfg.PG_DB_URL == "" {
-		return nil, fmt.Errorf("PG_DB_URL is required")
+	queues, err := parseQueues(getEnv("QUEUES", ""), cfg.WORKERS_COUNT, cfg.POLL_INTERVAL_SECONDS)
+	if err != nil {
+		return nil, err
+	}
+	cfg.QUEUES = queues
+
+	switch cfg.STORE_DRIVER {
+	case "postgres":
+		if cfg.PG_DB_URL == "" {
+			return nil, fmt.Errorf("PG_DB_URL is required")
+		}
+	case "sqlite":
+		if cfg.SQLITE_PATH == "" {
+			return nil, fmt.Errorf("SQLITE_PATH is required")
+		}
+	case "memory":
+	default:
+		return nil, fmt.Errorf("invalid STORE_DRIVER %q (expected postgres, sqlite or memory)", cfg.STORE_DRIVER)
 	}
 
 	if cfg.APP_ENV == "production" {
@@ -70,6 +136,54 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// Queue returns the configuration of the named queue, falling back to the
+// global worker count and poll interval for queues that are not listed in QUEUES.
+func (c *Config) Queue(name string) QueueConfig {
+	for _, q := range c.QUEUES {
+		if q.Name == name {
+			return q
+		}
+	}
+	return QueueConfig{
+		Name:                name,
+		Workers:             c.WORKERS_COUNT,
+		PollIntervalSeconds: c.POLL_INTERVAL_SECONDS,
+	}
+}
+
+func parseQueues(value string, defaultWorkers, defaultPoll int) ([]QueueConfig, error) {
+	if strings.TrimSpace(value) == "" {
+		return []QueueConfig{{Name: "default", Workers: defaultWorkers, PollIntervalSeconds: defaultPoll}}, nil
+	}
+
+	var queues []QueueConfig
+	for _, spec := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(spec), ":")
+		if parts[0] == "" || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid QUEUES entry %q (expected name:workers:poll_seconds)", spec)
+		}
+
+		q := QueueConfig{Name: parts[0], Workers: defaultWorkers, PollIntervalSeconds: defaultPoll}
+		if len(parts) > 1 {
+			workers, err := strconv.Atoi(parts[1])
+			if err != nil || workers <= 0 {
+				return nil, fmt.Errorf("invalid worker count in QUEUES entry %q", spec)
+			}
+			q.Workers = workers
+		}
+		if len(parts) > 2 {
+			poll, err := strconv.Atoi(parts[2])
+			if err != nil || poll <= 0 {
+				return nil, fmt.Errorf("invalid poll interval in QUEUES entry %q", spec)
+			}
+			q.PollIntervalSeconds = poll
+		}
+		queues = append(queues, q)
+	}
+
+	return queues, nil
+}
+
 func getEnvAsInt(key string, fallback int) int {
 	valueStr, exists := os.LookupEnv(key)
 	if !exists {
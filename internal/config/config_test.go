@@ -0,0 +1,40 @@
+package config
+
+import "testing"
+
+func TestParseQueues(t *testing.T) {
+	queues, err := parseQueues("default, notifications:10:1,media:2", 5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []QueueConfig{
+		{Name: "default", Workers: 5, PollIntervalSeconds: 2},
+		{Name: "notifications", Workers: 10, PollIntervalSeconds: 1},
+		{Name: "media", Workers: 2, PollIntervalSeconds: 2},
+	}
+	if len(queues) != len(want) {
+		t.Fatalf("Expected %d queues, got %d", len(want), len(queues))
+	}
+	for i := range want {
+		if queues[i] != want[i] {
+			t.Errorf("Queue %d: expected %+v, got %+v", i, want[i], queues[i])
+		}
+	}
+}
+
+func TestParseQueues_DefaultsAndErrors(t *testing.T) {
+	queues, err := parseQueues("", 5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queues) != 1 || queues[0].Name != "default" || queues[0].Workers != 5 {
+		t.Errorf("Expected a single default queue, got %+v", queues)
+	}
+
+	for _, value := range []string{":3", "media:zero", "media:0", "media:2:x", "a:1:1:1"} {
+		if _, err := parseQueues(value, 5, 2); err == nil {
+			t.Errorf("Expected an error for QUEUES=%q", value)
+		}
+	}
+}
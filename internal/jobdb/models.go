@@ -0,0 +1,134 @@
+// Package jobdb holds the job model shared by the store and the enqueue
+// client, validates job submissions and inserts jobs into Postgres. It only
+// depends on pgx, so services that enqueue jobs do not import the server.
+package jobdb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+	// JobStatusSkipped marks a workflow job that will never run because one
+	// of the jobs it depends on failed or was dead-lettered.
+	JobStatusSkipped JobStatus = "skipped"
+	// JobStatusDead is reported for jobs that were moved to dead_jobs. It is
+	// never stored in jobs.status.
+	JobStatusDead JobStatus = "dead"
+)
+
+// Finished reports whether a job in this status will not run again.
+func (s JobStatus) Finished() bool {
+	switch s {
+	case JobStatusCompleted, JobStatusFailed, JobStatusCancelled, JobStatusSkipped, JobStatusDead:
+		return true
+	}
+	return false
+}
+
+// DefaultQueue receives jobs whose type does not declare a queue.
+const DefaultQueue = "default"
+
+type Job struct {
+	ID           int64          `db:"id"`
+	Type         string         `db:"type"`
+	Payload      string         `db:"payload"`
+	Status       JobStatus      `db:"status"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+	StartedAt    *time.Time     `db:"started_at"`
+	CompletedAt  *time.Time     `db:"completed_at"`
+	ErrorMessage sql.NullString `db:"last_err"`
+	RetryCount   int            `db:"retry_count"`
+	NextRunAt    time.Time      `db:"next_run_at"`
+	Priority     int            `db:"priority"`
+	Queue        string         `db:"queue"`
+
+	IdempotencyKey       sql.NullString `db:"idempotency_key"`
+	IdempotencyExpiresAt *time.Time     `db:"idempotency_expires_at"`
+
+	// ReplayCount is how many times the job was requeued from the dead letter queue.
+	ReplayCount int        `db:"replay_count"`
+	ReplayedAt  *time.Time `db:"replayed_at"`
+
+	// RetryPolicy overrides fields of the job type's retry policy for this job.
+	RetryPolicy *RetryPolicy `db:"retry_policy"`
+	// TimeoutMs overrides the execution timeout of the job type for this job.
+	TimeoutMs sql.NullInt64 `db:"timeout_ms"`
+
+	// LockedBy is the worker holding the lease of a running job. The lease
+	// lapses at LeaseExpiresAt unless the worker heartbeats it; LeaseToken is
+	// incremented on every claim and fences the writes that finish an attempt.
+	LockedBy       sql.NullString `db:"locked_by"`
+	LeaseExpiresAt *time.Time     `db:"lease_expires_at"`
+	LeaseToken     int64          `db:"lease_token"`
+
+	// Result is the JSON output of a completed job whose handler produces one.
+	Result json.RawMessage `db:"result"`
+
+	// Progress is the percentage (0-100) last reported by the handler of the
+	// current or last attempt, with an optional short message.
+	Progress          sql.NullInt32  `db:"progress"`
+	ProgressMessage   sql.NullString `db:"progress_message"`
+	ProgressUpdatedAt *time.Time     `db:"progress_updated_at"`
+
+	// UniqueKey identifies the duplicates of a job created with a UniqueSpec.
+	UniqueKey sql.NullString `db:"unique_key"`
+
+	// Duplicate is set by CreateJob when an existing job was returned for a
+	// repeated idempotency key or a unique job instead of inserting a new one.
+	Duplicate bool `db:"-" json:"-"`
+}
+
+// CreateJobParams describes a job to be inserted into the queue.
+// A nil RunAt makes the job eligible for dispatch immediately.
+type CreateJobParams struct {
+	Type     string
+	Payload  string
+	RunAt    *time.Time
+	Priority int
+	Queue    string
+
+	// IdempotencyKey deduplicates submissions: while a job holding the key is
+	// retained (IdempotencyTTL after creation), CreateJob returns that job.
+	IdempotencyKey string
+	IdempotencyTTL time.Duration
+
+	RetryPolicy *RetryPolicy
+	// Timeout overrides the execution timeout of the job type when positive.
+	Timeout time.Duration
+
+	// Unique, usually the spec of the job type, makes CreateJob return an
+	// existing duplicate of the job instead of inserting it.
+	Unique *UniqueSpec
+}
+
+// Timeout returns the job's own execution timeout, or 0 if it uses the
+// timeout of its type.
+func (j Job) Timeout() time.Duration {
+	if !j.TimeoutMs.Valid {
+		return 0
+	}
+	return time.Duration(j.TimeoutMs.Int64) * time.Millisecond
+}
+
+// Lease returns the lease under which the job was claimed.
+func (j Job) Lease() Lease {
+	return Lease{Owner: j.LockedBy.String, Token: j.LeaseToken}
+}
+
+// Lease identifies a single claim of a job by a worker.
+type Lease struct {
+	Owner string
+	Token int64
+}
+
+// AttemptOutcome is how a single run of a job ended.
@@ -0,0 +1,202 @@
+package jobdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DB is satisfied by *pgxpool.Pool, *pgx.Conn and pgx.Tx.
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Columns lists the columns of jobs scanned by ScanJob, in order.
+const Columns = `id, type, payload, status, created_at, updated_at, started_at, completed_at, last_err, retry_count, next_run_at, priority, queue, idempotency_key, idempotency_expires_at, replay_count, replayed_at, retry_policy, timeout_ms, locked_by, lease_expires_at, lease_token, result, progress, progress_message, progress_updated_at, unique_key`
+
+// ScanJob scans a row selected with Columns, followed by the columns
+// scanned into extra.
+func ScanJob(row pgx.Row, extra ...any) (*Job, error) {
+	var job Job
+	dest := []any{
+		&job.ID,
+		&job.Type,
+		&job.Payload,
+		&job.Status,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+		&job.ErrorMessage,
+		&job.RetryCount,
+		&job.NextRunAt,
+		&job.Priority,
+		&job.Queue,
+		&job.IdempotencyKey,
+		&job.IdempotencyExpiresAt,
+		&job.ReplayCount,
+		&job.ReplayedAt,
+		&job.RetryPolicy,
+		&job.TimeoutMs,
+		&job.LockedBy,
+		&job.LeaseExpiresAt,
+		&job.LeaseToken,
+		&job.Result,
+		&job.Progress,
+		&job.ProgressMessage,
+		&job.ProgressUpdatedAt,
+		&job.UniqueKey,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// UniqueDuplicate locks the unique key until db commits and returns the oldest
+// job with that key that blocks a duplicate under spec, or nil.
+func UniqueDuplicate(ctx context.Context, db DB, key string, spec *UniqueSpec) (*Job, error) {
+	// submissions of the same key wait for each other until commit
+	if _, err := db.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, key); err != nil {
+		return nil, fmt.Errorf("lock unique key: %w", err)
+	}
+
+	states := make([]string, 0, len(spec.BlockingStates()))
+	for _, state := range spec.BlockingStates() {
+		states = append(states, string(state))
+	}
+	job, err := ScanJob(db.QueryRow(ctx, `
+		SELECT `+Columns+` FROM jobs
+		WHERE unique_key = $1 AND status = ANY($2)
+			AND ($3::FLOAT8 = 0 OR created_at > NOW() - $3 * INTERVAL '1 second')
+		ORDER BY id
+		LIMIT 1
+	`, key, states, spec.Period.Seconds()))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get unique job: %w", err)
+	}
+	return job, nil
+}
+
+// Insert inserts a job using db, which may be a pool or an open transaction.
+// If the job carries an idempotency key that is already held by an unexpired
+// job, or is unique and has a duplicate, that job is returned with Duplicate
+// set instead of inserting a new row. Unique jobs must be created in a
+// transaction.
+func Insert(ctx context.Context, db DB, params CreateJobParams) (*Job, error) {
+	if !json.Valid([]byte(params.Payload)) {
+		return nil, fmt.Errorf("insert job: payload is not valid JSON")
+	}
+
+	queue := params.Queue
+	if queue == "" {
+		queue = DefaultQueue
+	}
+
+	var uniqueKey *string
+	if params.Unique != nil {
+		key, err := params.UniqueKey()
+		if err != nil {
+			return nil, fmt.Errorf("insert job: %w", err)
+		}
+
+		job, err := UniqueDuplicate(ctx, db, key, params.Unique)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			job.Duplicate = true
+			return job, nil
+		}
+		uniqueKey = &key
+	}
+
+	var idempotencyKey *string
+	var idempotencyTTL *float64
+	if params.IdempotencyKey != "" {
+		idempotencyKey = &params.IdempotencyKey
+		ttl := params.IdempotencyTTL.Seconds()
+		idempotencyTTL = &ttl
+
+		// release the key if the job holding it has outlived its retention window
+		_, err := db.Exec(ctx, `
+			WITH released AS (
+				DELETE FROM job_idempotency_keys
+				WHERE key = $1 AND expires_at <= NOW()
+				RETURNING job_id
+			)
+			UPDATE jobs
+			SET idempotency_key = NULL, idempotency_expires_at = NULL
+			WHERE id IN (SELECT job_id FROM released)
+		`, params.IdempotencyKey)
+		if err != nil {
+			return nil, fmt.Errorf("release idempotency key: %w", err)
+		}
+	}
+
+	var timeoutMs *int64
+	if params.Timeout > 0 {
+		ms := params.Timeout.Milliseconds()
+		timeoutMs = &ms
+	}
+
+	query :=
+		`
+		INSERT INTO jobs (type, payload, next_run_at, priority, queue, idempotency_key, idempotency_expires_at, retry_policy, timeout_ms, unique_key)
+		VALUES ($1, $2, COALESCE($3::TIMESTAMPTZ, NOW()), $4, $5, $6, NOW() + $7 * INTERVAL '1 second', $8, $9, $10)
+		RETURNING ` + Columns
+	if idempotencyKey != nil {
+		// jobs is partitioned, so the key is reserved in job_idempotency_keys
+		// together with the id of the job it is inserted for
+		query =
+			`
+			WITH reserved AS (
+				INSERT INTO job_idempotency_keys (key, job_id, expires_at)
+				VALUES ($6, nextval('jobs_id_seq'), NOW() + $7 * INTERVAL '1 second')
+				ON CONFLICT (key) DO NOTHING
+				RETURNING job_id, expires_at
+			)
+			INSERT INTO jobs (id, type, payload, next_run_at, priority, queue, idempotency_key, idempotency_expires_at, retry_policy, timeout_ms, unique_key)
+			SELECT reserved.job_id, $1, $2, COALESCE($3::TIMESTAMPTZ, NOW()), $4, $5, $6, reserved.expires_at, $8, $9, $10
+			FROM reserved
+			RETURNING ` + Columns
+	}
+
+	job, err := ScanJob(db.QueryRow(ctx, query,
+		params.Type,
+		params.Payload,
+		params.RunAt,
+		params.Priority,
+		queue,
+		idempotencyKey,
+		idempotencyTTL,
+		params.RetryPolicy,
+		timeoutMs,
+		uniqueKey,
+	))
+	if err == pgx.ErrNoRows && idempotencyKey != nil {
+		job, err = ScanJob(db.QueryRow(ctx, `
+			SELECT `+Columns+` FROM jobs
+			WHERE id = (SELECT job_id FROM job_idempotency_keys WHERE key = $1)
+		`, params.IdempotencyKey))
+		if err != nil {
+			return nil, fmt.Errorf("get job by idempotency key: %w", err)
+		}
+		job.Duplicate = true
+		return job, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("insert job: %w", err)
+	}
+
+	// the jobs_notify_ready trigger has announced the job
+	return job, nil
+}
@@ -0,0 +1,106 @@
+package jobdb
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+type BackoffStrategy string
+
+const (
+	// BackoffExponential doubles the delay after every attempt.
+	BackoffExponential BackoffStrategy = "exponential"
+	// BackoffLinear grows the delay by BaseDelay after every attempt.
+	BackoffLinear BackoffStrategy = "linear"
+	// BackoffFixed waits BaseDelay between all attempts.
+	BackoffFixed BackoffStrategy = "fixed"
+)
+
+// RetryPolicy decides how often a failed job is retried and how long to wait
+// before each retry. Zero-valued fields mean "not set" so that a per-job
+// policy can override only some fields of the job type's policy.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times the job may fail before it is dead-lettered.
+	MaxAttempts int             `json:"max_attempts,omitempty"`
+	BaseDelay   time.Duration   `json:"base_delay,omitempty"`
+	MaxDelay    time.Duration   `json:"max_delay,omitempty"`
+	Backoff     BackoffStrategy `json:"backoff,omitempty"`
+	// Jitter randomizes each delay by up to this fraction (0-1) in either direction.
+	Jitter float64 `json:"jitter,omitempty"`
+}
+
+// DefaultRetryPolicy matches the original behaviour: three attempts, waiting
+// 2s, 4s, 8s... between them.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   2 * time.Second,
+	Backoff:     BackoffExponential,
+}
+
+// Merge returns p with every field that is set in override replaced.
+func (p RetryPolicy) Merge(override RetryPolicy) RetryPolicy {
+	if override.MaxAttempts > 0 {
+		p.MaxAttempts = override.MaxAttempts
+	}
+	if override.BaseDelay > 0 {
+		p.BaseDelay = override.BaseDelay
+	}
+	if override.MaxDelay > 0 {
+		p.MaxDelay = override.MaxDelay
+	}
+	if override.Backoff != "" {
+		p.Backoff = override.Backoff
+	}
+	if override.Jitter > 0 {
+		p.Jitter = override.Jitter
+	}
+	return p
+}
+
+func (p RetryPolicy) Validate() error {
+	switch p.Backoff {
+	case "", BackoffExponential, BackoffLinear, BackoffFixed:
+	default:
+		return fmt.Errorf("unknown backoff %q (expected exponential, linear or fixed)", p.Backoff)
+	}
+	if p.MaxAttempts < 0 || p.BaseDelay < 0 || p.MaxDelay < 0 {
+		return fmt.Errorf("retry policy values must not be negative")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// Delay returns how long to wait before retrying after the given failed
+// attempt (starting at 1).
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	var delay float64
+	switch p.Backoff {
+	case BackoffFixed:
+		delay = float64(p.BaseDelay)
+	case BackoffLinear:
+		delay = float64(p.BaseDelay) * float64(attempt)
+	default:
+		delay = float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	}
+
+	if p.Jitter > 0 {
+		delay *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if delay > float64(math.MaxInt64) {
+		delay = float64(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
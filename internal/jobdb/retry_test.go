@@ -0,0 +1,51 @@
+package jobdb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{"default first retry", DefaultRetryPolicy, 1, 2 * time.Second},
+		{"default second retry", DefaultRetryPolicy, 2, 4 * time.Second},
+		{"linear", RetryPolicy{BaseDelay: time.Second, Backoff: BackoffLinear}, 3, 3 * time.Second},
+		{"fixed", RetryPolicy{BaseDelay: time.Second, Backoff: BackoffFixed}, 5, time.Second},
+		{"capped", RetryPolicy{BaseDelay: time.Minute, MaxDelay: 5 * time.Minute, Backoff: BackoffExponential}, 10, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Delay(tt.attempt); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Jitter(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 10 * time.Second, Backoff: BackoffFixed, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		got := policy.Delay(1)
+		if got < 5*time.Second || got > 15*time.Second {
+			t.Fatalf("Expected delay within 5s-15s, got %v", got)
+		}
+	}
+}
+
+func TestRetryPolicy_Merge(t *testing.T) {
+	merged := DefaultRetryPolicy.Merge(RetryPolicy{MaxAttempts: 10, Backoff: BackoffFixed})
+
+	if merged.MaxAttempts != 10 || merged.Backoff != BackoffFixed {
+		t.Errorf("Expected overridden fields to win, got %+v", merged)
+	}
+	if merged.BaseDelay != DefaultRetryPolicy.BaseDelay {
+		t.Errorf("Expected unset fields to be kept, got %+v", merged)
+	}
+}
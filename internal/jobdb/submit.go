@@ -0,0 +1,59 @@
+package jobdb
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JobTypes tells which job types exist and the queue each one runs on, like
+// the registry of the server.
+type JobTypes interface {
+	Has(jobType string) bool
+	Queue(jobType string) string
+}
+
+// UniqueJobTypes is implemented by JobTypes that declare unique job types, like
+// the registry of the server.
+type UniqueJobTypes interface {
+	Unique(jobType string) *UniqueSpec
+}
+
+// Resolve validates a job submission the way SubmitJob does and fills in its
+// defaults: the type must be known to types, the payload must be JSON and
+// defaults to {}, and the queue to the type's queue. If types implements
+// UniqueJobTypes, the job gets its type's unique spec.
+func Resolve(types JobTypes, params CreateJobParams) (CreateJobParams, error) {
+	if params.Type == "" {
+		return CreateJobParams{}, fmt.Errorf("job type is required")
+	}
+	if !types.Has(params.Type) {
+		return CreateJobParams{}, fmt.Errorf("job type '%s' is not registered", params.Type)
+	}
+
+	if params.Payload == "" {
+		params.Payload = "{}"
+	}
+	if !json.Valid([]byte(params.Payload)) {
+		return CreateJobParams{}, fmt.Errorf("payload must be valid JSON")
+	}
+
+	if params.Queue == "" {
+		params.Queue = types.Queue(params.Type)
+	}
+
+	if params.RetryPolicy != nil {
+		if err := params.RetryPolicy.Validate(); err != nil {
+			return CreateJobParams{}, fmt.Errorf("invalid retry_policy: %w", err)
+		}
+	}
+
+	if params.Timeout < 0 {
+		return CreateJobParams{}, fmt.Errorf("timeout must not be negative")
+	}
+
+	if u, ok := types.(UniqueJobTypes); ok {
+		params.Unique = u.Unique(params.Type)
+	}
+
+	return params, nil
+}
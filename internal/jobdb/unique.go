@@ -0,0 +1,103 @@
+package jobdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// UniqueSpec makes CreateJob return an existing job instead of inserting a
+// duplicate. Two jobs are duplicates when they have the same type and the same
+// values of Fields in their payload; without Fields there is one job per
+// type. Only existing jobs in one of States, created less than Period ago,
+// count.
+type UniqueSpec struct {
+	// Fields are top-level payload fields; a field missing from the payload
+	// counts as null.
+	Fields []string
+	// States defaults to pending and running.
+	States []JobStatus
+	// Period is unlimited when zero.
+	Period time.Duration
+}
+
+// defaultUniqueStates are the states in which a job blocks its duplicates
+// when the spec does not name any.
+var defaultUniqueStates = []JobStatus{JobStatusPending, JobStatusRunning}
+
+func (u UniqueSpec) Validate() error {
+	for _, state := range u.States {
+		switch state {
+		case JobStatusPending, JobStatusRunning, JobStatusCompleted, JobStatusFailed, JobStatusCancelled, JobStatusSkipped:
+		default:
+			return fmt.Errorf("unique state %q is not a job status", state)
+		}
+	}
+	for _, field := range u.Fields {
+		if field == "" {
+			return fmt.Errorf("unique fields must not be empty")
+		}
+	}
+	if u.Period < 0 {
+		return fmt.Errorf("unique period must not be negative")
+	}
+	return nil
+}
+
+// BlockingStates returns the states in which a job blocks its duplicates.
+func (u UniqueSpec) BlockingStates() []JobStatus {
+	if len(u.States) == 0 {
+		return defaultUniqueStates
+	}
+	return u.States
+}
+
+// Blocks reports whether an existing job in status, created at createdAt,
+// blocks a duplicate submitted at now.
+func (u UniqueSpec) Blocks(status JobStatus, createdAt, now time.Time) bool {
+	if !slices.Contains(u.BlockingStates(), status) {
+		return false
+	}
+	return u.Period == 0 || createdAt.After(now.Add(-u.Period))
+}
+
+// key returns the value of jobs.unique_key for a job of jobType with payload:
+// the type followed by the JSON values of the unique fields. Values are
+// re-encoded, so formatting and key order within objects do not matter.
+func (u UniqueSpec) key(jobType, payload string) (string, error) {
+	if len(u.Fields) == 0 {
+		return jobType, nil
+	}
+
+	fields := map[string]any{}
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return "", fmt.Errorf("unique job payload must be a JSON object: %w", err)
+	}
+
+	values := make([]any, len(u.Fields))
+	for i, field := range u.Fields {
+		values[i] = fields[field]
+	}
+
+	var key bytes.Buffer
+	key.WriteString(jobType)
+	key.WriteByte(':')
+	if err := json.NewEncoder(&key).Encode(values); err != nil {
+		return "", fmt.Errorf("encode unique key: %w", err)
+	}
+	return strings.TrimSuffix(key.String(), "\n"), nil
+}
+
+// UniqueKey validates the unique spec of params and returns the job's
+// unique key.
+func (p CreateJobParams) UniqueKey() (string, error) {
+	if err := p.Unique.Validate(); err != nil {
+		return "", err
+	}
+	return p.Unique.key(p.Type, p.Payload)
+}
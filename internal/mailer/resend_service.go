@@ -20,7 +20,7 @@ func NewResendEmailService(apikey string, from string) *ResendEmailService {
 	}
 }
 
-func (r *ResendEmailService) Send(ctx context.Context, to, subject, body string) error {
+func (r *ResendEmailService) Send(ctx context.Context, to, subject, body string) (string, error) {
 	params := &resend.SendEmailRequest{
 		From:    r.from,
 		To:      []string{to},
@@ -28,10 +28,10 @@ func (r *ResendEmailService) Send(ctx context.Context, to, subject, body string)
 		Html:    body,
 	}
 
-	_, err := r.client.Emails.Send(params)
+	sent, err := r.client.Emails.Send(params)
 	if err != nil {
-		return fmt.Errorf("sending to email: %w", err)
+		return "", fmt.Errorf("sending to email: %w", err)
 	}
 
-	return nil
+	return sent.Id, nil
 }
@@ -3,5 +3,6 @@ package mailer
 import "context"
 
 type Sender interface {
-	Send(ctx context.Context, to, subject, body string) error
+	// Send delivers an email and returns the provider's message ID.
+	Send(ctx context.Context, to, subject, body string) (string, error)
 }
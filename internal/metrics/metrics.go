@@ -25,10 +25,35 @@ var (
 		[]string{"job_type"},
 	)
 
+	JobWait = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "job_scheduler_job_wait_seconds",
+			Help:    "Time from a job becoming ready to run until a worker claimed it",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14), // 5ms to ~41s
+		},
+		[]string{"queue", "job_type"},
+	)
+
+	JobTimeouts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_scheduler_job_timeouts_total",
+			Help: "The total number of job executions that exceeded their timeout",
+		},
+		[]string{"job_type"},
+	)
+
 	ActiveWorkers = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "job_scheduler_active_workers",
 			Help: "Current number of workers processing jobs",
 		},
 	)
+
+	ScheduleRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_scheduler_schedule_runs_total",
+			Help: "The total number of jobs enqueued by cron schedules",
+		},
+		[]string{"schedule"},
+	)
 )
\ No newline at end of file
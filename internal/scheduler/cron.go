@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	"github.com/robfig/cron/v3"
+)
+
+// maxCatchupRuns bounds how many missed ticks CatchupAll replays at once.
+const maxCatchupRuns = 100
+
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// PayloadData is exposed to payload templates as the dot value.
+type PayloadData struct {
+	Name        string
+	ScheduledAt string
+	Date        string
+}
+
+// NextRun returns the first tick of a cron expression after the given time,
+// evaluated in the schedule's time zone. It returns the zero time for an
+// expression that never fires, such as "0 0 30 2 *".
+func NextRun(expr, timezone string, after time.Time) (time.Time, error) {
+	sched, loc, err := parse(expr, timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(after.In(loc)), nil
+}
+
+// Validate checks that the cron expression, time zone, catch-up policy and
+// payload template of a schedule are usable.
+func Validate(expr, timezone string, policy store.CatchupPolicy, payloadTemplate string) error {
+	sched, loc, err := parse(expr, timezone)
+	if err != nil {
+		return err
+	}
+	if sched.Next(time.Now().In(loc)).IsZero() {
+		return fmt.Errorf("cron expression %q never fires", expr)
+	}
+
+	switch policy {
+	case store.CatchupSkip, store.CatchupOnce, store.CatchupAll:
+	default:
+		return fmt.Errorf("unknown catch-up policy: %q", policy)
+	}
+
+	_, err = RenderPayload(payloadTemplate, PayloadData{
+		Name:        "validate",
+		ScheduledAt: time.Now().UTC().Format(time.RFC3339),
+		Date:        time.Now().UTC().Format("2006-01-02"),
+	})
+	return err
+}
+
+// Plan works out which ticks of a due schedule should be enqueued at now,
+// according to its catch-up policy, and when the schedule is due next.
+// Under CatchupSkip only a tick that is at most grace old is run.
+func Plan(s store.Schedule, now time.Time, grace time.Duration) ([]time.Time, time.Time, error) {
+	sched, loc, err := parse(s.CronExpr, s.Timezone)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var missed []time.Time
+	next := s.NextRunAt.In(loc)
+	for !next.IsZero() && !next.After(now) {
+		missed = append(missed, next)
+		if len(missed) > maxCatchupRuns {
+			missed = missed[1:]
+		}
+		next = sched.Next(next)
+	}
+
+	if len(missed) == 0 {
+		return nil, next, nil
+	}
+
+	latest := missed[len(missed)-1]
+
+	switch s.CatchupPolicy {
+	case store.CatchupAll:
+		return missed, next, nil
+	case store.CatchupOnce:
+		return []time.Time{latest}, next, nil
+	default:
+		if now.Sub(latest) <= grace {
+			return []time.Time{latest}, next, nil
+		}
+		return nil, next, nil
+	}
+}
+
+// RenderPayload executes a payload template and checks that the result is JSON.
+func RenderPayload(payloadTemplate string, data PayloadData) (string, error) {
+	if payloadTemplate == "" {
+		return "{}", nil
+	}
+
+	tmpl, err := template.New("payload").Option("missingkey=error").Parse(payloadTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parse payload template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render payload template: %w", err)
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return "", fmt.Errorf("payload template does not render valid JSON")
+	}
+
+	return buf.String(), nil
+}
+
+func parse(expr, timezone string) (cron.Schedule, *time.Location, error) {
+	sched, err := cronParser.Parse(expr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid time zone %q: %w", timezone, err)
+	}
+
+	return sched, loc, nil
+}
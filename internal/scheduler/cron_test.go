@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+)
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestNextRun_TimeZone(t *testing.T) {
+	after := mustTime(t, "2026-01-10T00:00:00Z")
+
+	// 02:00 in Kolkata is 20:30 UTC on the previous day
+	next, err := NextRun("0 2 * * *", "Asia/Kolkata", after)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := mustTime(t, "2026-01-10T20:30:00Z")
+	if !next.Equal(want) {
+		t.Errorf("Expected next run %v, got %v", want, next.UTC())
+	}
+}
+
+func TestPlan_CatchupPolicies(t *testing.T) {
+	// hourly schedule that was last due at 10:00 and is evaluated at 13:30,
+	// so the 10:00, 11:00, 12:00 and 13:00 ticks were missed
+	now := mustTime(t, "2026-01-10T13:30:00Z")
+	sc := store.Schedule{
+		CronExpr:  "0 * * * *",
+		Timezone:  "UTC",
+		NextRunAt: mustTime(t, "2026-01-10T10:00:00Z"),
+	}
+	wantNext := mustTime(t, "2026-01-10T14:00:00Z")
+
+	tests := []struct {
+		policy   store.CatchupPolicy
+		wantRuns int
+	}{
+		{store.CatchupAll, 4},
+		{store.CatchupOnce, 1},
+		{store.CatchupSkip, 0},
+	}
+
+	for _, tt := range tests {
+		sc.CatchupPolicy = tt.policy
+		runs, next, err := Plan(sc, now, time.Minute)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.policy, err)
+		}
+		if len(runs) != tt.wantRuns {
+			t.Errorf("%s: expected %d runs, got %d", tt.policy, tt.wantRuns, len(runs))
+		}
+		if !next.Equal(wantNext) {
+			t.Errorf("%s: expected next run %v, got %v", tt.policy, wantNext, next)
+		}
+	}
+}
+
+func TestPlan_SkipRunsOnTimeTick(t *testing.T) {
+	now := mustTime(t, "2026-01-10T13:00:05Z")
+	sc := store.Schedule{
+		CronExpr:      "0 * * * *",
+		Timezone:      "UTC",
+		CatchupPolicy: store.CatchupSkip,
+		NextRunAt:     mustTime(t, "2026-01-10T13:00:00Z"),
+	}
+
+	runs, _, err := Plan(sc, now, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 {
+		t.Errorf("Expected the on-time tick to run, got %d runs", len(runs))
+	}
+}
+
+func TestPlan_CatchupAllIsBounded(t *testing.T) {
+	now := mustTime(t, "2026-01-10T13:00:00Z")
+	sc := store.Schedule{
+		CronExpr:      "* * * * *",
+		Timezone:      "UTC",
+		CatchupPolicy: store.CatchupAll,
+		NextRunAt:     mustTime(t, "2026-01-01T00:00:00Z"),
+	}
+
+	runs, next, err := Plan(sc, now, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != maxCatchupRuns {
+		t.Errorf("Expected %d runs, got %d", maxCatchupRuns, len(runs))
+	}
+	if !runs[len(runs)-1].Equal(now) {
+		t.Errorf("Expected the most recent ticks to be kept, last run is %v", runs[len(runs)-1])
+	}
+	if !next.After(now) {
+		t.Errorf("Expected next run after %v, got %v", now, next)
+	}
+}
+
+func TestNeverFiringSchedule(t *testing.T) {
+	// February never has a 30th, so the expression has no ticks
+	const expr = "0 0 30 2 *"
+
+	if err := Validate(expr, "UTC", store.CatchupSkip, "{}"); err == nil {
+		t.Error("Expected a cron expression that never fires to be rejected")
+	}
+
+	now := mustTime(t, "2026-01-10T13:00:00Z")
+	next, err := NextRun(expr, "UTC", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !next.IsZero() {
+		t.Errorf("Expected no next run, got %v", next)
+	}
+
+	sc := store.Schedule{
+		CronExpr:      expr,
+		Timezone:      "UTC",
+		CatchupPolicy: store.CatchupAll,
+		NextRunAt:     mustTime(t, "2026-01-10T12:00:00Z"),
+	}
+
+	runs, next, err := Plan(sc, now, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 {
+		t.Errorf("Expected the due tick to run once, got %d runs", len(runs))
+	}
+	if !next.IsZero() {
+		t.Errorf("Expected no next run, got %v", next)
+	}
+}
+
+func TestRenderPayload(t *testing.T) {
+	payload, err := RenderPayload(`{"older_than": "24h", "label": "{{.Date}}"}`, PayloadData{Date: "2026-01-10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload != `{"older_than": "24h", "label": "2026-01-10"}` {
+		t.Errorf("Unexpected payload: %s", payload)
+	}
+
+	if _, err := RenderPayload(`{"bad": {{.Date}}}`, PayloadData{Date: "2026-01-10"}); err == nil {
+		t.Error("Expected an error for a template that renders invalid JSON")
+	}
+
+	if _, err := RenderPayload(`{"x": "{{.Unknown}}"}`, PayloadData{}); err == nil {
+		t.Error("Expected an error for an unknown template field")
+	}
+}
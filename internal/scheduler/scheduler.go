@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/metrics"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	"github.com/bhanuprakaash/job-scheduler/internal/worker"
+)
+
+// Scheduler periodically turns due cron schedules into jobs. Any number of
+// replicas may run a Scheduler against the same store; each tick is enqueued once.
+type Scheduler struct {
+	store        store.Storer
+	registry     *worker.Registry
+	pollInterval time.Duration
+	grace        time.Duration
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+}
+
+func NewScheduler(s store.Storer, registry *worker.Registry, pollInterval, grace time.Duration) *Scheduler {
+	return &Scheduler{
+		store:        s,
+		registry:     registry,
+		pollInterval: pollInterval,
+		grace:        grace,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	logger.Info("scheduler started", "poll_interval", s.pollInterval)
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+func (s *Scheduler) Stop() {
+	close(s.stopCh)
+	s.wg.Wait()
+	logger.Info("scheduler stopped")
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Tick(ctx, time.Now())
+		}
+	}
+}
+
+// Tick enqueues jobs for every schedule that is due at now.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) {
+	due, err := s.store.GetDueSchedules(ctx, now, 100)
+	if err != nil {
+		logger.Error("fetching due schedules", "error", err)
+		return
+	}
+
+	for _, sc := range due {
+		if err := s.fire(ctx, sc, now); err != nil {
+			logger.Error("failed to fire schedule", "schedule", sc.Name, "error", err)
+		}
+	}
+}
+
+func (s *Scheduler) fire(ctx context.Context, sc store.Schedule, now time.Time) error {
+	ticks, next, err := Plan(sc, now, s.grace)
+	if err != nil {
+		return err
+	}
+
+	runs := make([]store.CreateJobParams, 0, len(ticks))
+	for _, tick := range ticks {
+		payload, err := RenderPayload(sc.PayloadTemplate, PayloadData{
+			Name:        sc.Name,
+			ScheduledAt: tick.Format(time.RFC3339),
+			Date:        tick.Format("2006-01-02"),
+		})
+		if err != nil {
+			return err
+		}
+		runs = append(runs, store.CreateJobParams{
+			Type:    sc.JobType,
+			Payload: payload,
+			Queue:   s.registry.Queue(sc.JobType),
+			Unique:  s.registry.Unique(sc.JobType),
+		})
+	}
+
+	fired, err := s.store.FireSchedule(ctx, sc, runs, next)
+	if err != nil {
+		return err
+	}
+
+	if !fired {
+		// another replica handled this tick
+		return nil
+	}
+
+	if len(runs) > 0 {
+		metrics.ScheduleRuns.WithLabelValues(sc.Name).Add(float64(len(runs)))
+		logger.Info("Schedule fired", "schedule", sc.Name, "jobs", len(runs), "next_run_at", next)
+	} else {
+		logger.Info("Schedule skipped missed ticks", "schedule", sc.Name, "next_run_at", next)
+	}
+
+	return nil
+}
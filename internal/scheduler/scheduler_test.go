@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+	"github.com/bhanuprakaash/job-scheduler/internal/worker"
+)
+
+func TestScheduler_Tick_ExactlyOnceAcrossReplicas(t *testing.T) {
+	logger.Init()
+
+	ctx := context.Background()
+	now := mustTime(t, "2026-01-10T02:00:10Z")
+	memStore := store.NewMemoryStore()
+	sc, err := memStore.CreateSchedule(ctx, store.CreateScheduleParams{
+		Name:            "nightly-archive",
+		CronExpr:        "0 2 * * *",
+		Timezone:        "UTC",
+		JobType:         "maintenance:archive",
+		PayloadTemplate: `{"older_than": "24h", "batch": 100}`,
+		CatchupPolicy:   store.CatchupSkip,
+		NextRunAt:       mustTime(t, "2026-01-10T02:00:00Z"),
+	})
+	if err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+
+	registry := worker.NewRegistry()
+	registry.Register("maintenance:archive", nil, 0, worker.WithQueue("maintenance"))
+
+	// several replicas tick at the same moment
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			NewScheduler(memStore, registry, time.Second, time.Minute).Tick(ctx, now)
+		}()
+	}
+	wg.Wait()
+
+	page, err := memStore.ListJobs(ctx, store.JobFilter{}, store.JobSort{}, store.PageParams{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if len(page.Jobs) != 1 {
+		t.Fatalf("Expected exactly 1 job to be enqueued, got %d", len(page.Jobs))
+	}
+	if page.Jobs[0].Type != "maintenance:archive" {
+		t.Errorf("Unexpected job type: %s", page.Jobs[0].Type)
+	}
+	if page.Jobs[0].Queue != "maintenance" {
+		t.Errorf("Expected job in the type's default queue, got %s", page.Jobs[0].Queue)
+	}
+
+	want := mustTime(t, "2026-01-11T02:00:00Z")
+	got, err := memStore.GetSchedule(ctx, sc.ID)
+	if err != nil {
+		t.Fatalf("GetSchedule failed: %v", err)
+	}
+	if !got.NextRunAt.Equal(want) {
+		t.Errorf("Expected schedule to advance to %v, got %v", want, got.NextRunAt)
+	}
+}
@@ -0,0 +1,928 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testStorerConformance runs the behaviour every Storer must share against
+// the stores returned by newStore, which must be empty.
+func testStorerConformance(t *testing.T, newStore func(t *testing.T) Storer) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s Storer)
+	}{
+		{"CreateJob", conformanceCreateJob},
+		{"IdempotencyKey", conformanceIdempotencyKey},
+		{"CreateJobs", conformanceCreateJobs},
+		{"UniqueJobs", conformanceUniqueJobs},
+		{"Claim", conformanceClaim},
+		{"ClaimConcurrency", conformanceClaimConcurrency},
+		{"CompleteJob", conformanceCompleteJob},
+		{"RetryAndDeadLetter", conformanceRetryAndDeadLetter},
+		{"Leases", conformanceLeases},
+		{"CancelJob", conformanceCancelJob},
+		{"ListJobs", conformanceListJobs},
+		{"ListJobsPagination", conformanceListJobsPagination},
+		{"RetryDeadJobs", conformanceRetryDeadJobs},
+		{"Stats", conformanceStats},
+		{"ArchivedJobs", conformanceArchivedJobs},
+		{"Schedules", conformanceSchedules},
+		{"Workflow", conformanceWorkflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStore(t)
+			defer s.Close()
+			tt.run(t, s)
+		})
+	}
+}
+
+// claimOne claims the next job of queue for owner and fails the test if there
+// is none.
+func claimOne(t *testing.T, s Storer, queue, owner string) Job {
+	t.Helper()
+	jobs, err := s.GetPendingJobs(context.Background(), ClaimParams{
+		Queue: queue, Limit: 1, Owner: owner, LeaseDuration: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("GetPendingJobs failed: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("Expected to claim 1 job from %q, got %d", queue, len(jobs))
+	}
+	return jobs[0]
+}
+
+func mustCreate(t *testing.T, s Storer, params CreateJobParams) *Job {
+	t.Helper()
+	job, err := s.CreateJob(context.Background(), params)
+	if err != nil {
+		t.Fatalf("CreateJob failed: %v", err)
+	}
+	return job
+}
+
+func conformanceCreateJob(t *testing.T, s Storer) {
+	ctx := context.Background()
+
+	// 1. Defaults are filled in
+	job := mustCreate(t, s, CreateJobParams{Type: "test:create", Payload: `{"foo": "bar"}`, Timeout: 5 * time.Second})
+	if job.ID == 0 || job.Status != JobStatusPending || job.Queue != DefaultQueue {
+		t.Errorf("Expected a pending job in the default queue, got id %d, %s, %q", job.ID, job.Status, job.Queue)
+	}
+	if job.Timeout() != 5*time.Second {
+		t.Errorf("Expected a 5s timeout, got %v", job.Timeout())
+	}
+
+	// 2. The job reads back
+	got, err := s.GetJobByID(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("GetJobByID failed: %v", err)
+	}
+	if got.Type != "test:create" || got.Payload != `{"foo": "bar"}` {
+		t.Errorf("Expected the stored job, got %s %s", got.Type, got.Payload)
+	}
+
+	// 3. Unknown ids are ErrNotFound
+	if _, err := s.GetJobByID(ctx, job.ID+1000); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.ListJobAttempts(ctx, job.ID+1000); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for attempts, got %v", err)
+	}
+}
+
+func conformanceIdempotencyKey(t *testing.T, s Storer) {
+	// 1. The same key returns the same job
+	first := mustCreate(t, s, CreateJobParams{Type: "test:idem", Payload: `{}`, IdempotencyKey: "k1", IdempotencyTTL: time.Hour})
+	again := mustCreate(t, s, CreateJobParams{Type: "test:idem", Payload: `{}`, IdempotencyKey: "k1", IdempotencyTTL: time.Hour})
+	if first.Duplicate || !again.Duplicate || again.ID != first.ID {
+		t.Errorf("Expected the second submission to return job %d as a duplicate, got %d (duplicate=%v)", first.ID, again.ID, again.Duplicate)
+	}
+
+	// 2. An expired key is released for a new job
+	short := mustCreate(t, s, CreateJobParams{Type: "test:idem", Payload: `{}`, IdempotencyKey: "k2", IdempotencyTTL: time.Millisecond})
+	time.Sleep(10 * time.Millisecond)
+	fresh := mustCreate(t, s, CreateJobParams{Type: "test:idem", Payload: `{}`, IdempotencyKey: "k2", IdempotencyTTL: time.Hour})
+	if fresh.Duplicate || fresh.ID == short.ID {
+		t.Errorf("Expected a new job once the key expired, got %d (duplicate=%v)", fresh.ID, fresh.Duplicate)
+	}
+}
+
+func conformanceCreateJobs(t *testing.T, s Storer) {
+	ctx := context.Background()
+	existing := mustCreate(t, s, CreateJobParams{Type: "test:batch", Payload: `{}`, IdempotencyKey: "dup", IdempotencyTTL: time.Hour})
+
+	jobs, err := s.CreateJobs(ctx, []CreateJobParams{
+		{Type: "test:batch", Payload: `{"n": 1}`},
+		{Type: "test:batch", Payload: `{}`, IdempotencyKey: "dup", IdempotencyTTL: time.Hour},
+		{Type: "test:batch", Payload: `{"n": 3}`, Queue: "other"},
+	})
+	if err != nil {
+		t.Fatalf("CreateJobs failed: %v", err)
+	}
+
+	// jobs come back in the order of params
+	if len(jobs) != 3 || jobs[0].Payload != `{"n": 1}` || jobs[2].Queue != "other" {
+		t.Fatalf("Expected the jobs in input order, got %+v", jobs)
+	}
+	if !jobs[1].Duplicate || jobs[1].ID != existing.ID {
+		t.Errorf("Expected the keyed job to be a duplicate of %d, got %d", existing.ID, jobs[1].ID)
+	}
+	if jobs[0].ID == jobs[2].ID {
+		t.Error("Expected distinct ids")
+	}
+
+	// a payload that is not JSON fails the whole batch before anything is written
+	before, _ := s.GetStats(ctx)
+	if _, err := s.CreateJobs(ctx, []CreateJobParams{
+		{Type: "test:batch", Payload: `{}`},
+		{Type: "test:batch", Payload: `{not json`},
+	}); err == nil {
+		t.Error("Expected an error for an invalid payload")
+	}
+	if after, _ := s.GetStats(ctx); after.Total() != before.Total() {
+		t.Errorf("Expected no job to be inserted, got %d more", after.Total()-before.Total())
+	}
+}
+
+func conformanceUniqueJobs(t *testing.T, s Storer) {
+	ctx := context.Background()
+	byType := &UniqueSpec{States: []JobStatus{JobStatusPending}}
+	byField := &UniqueSpec{Fields: []string{"invoice_id"}}
+
+	// 1. One pending job per type
+	first := mustCreate(t, s, CreateJobParams{Type: "test:unique", Payload: `{}`, Unique: byType})
+	again := mustCreate(t, s, CreateJobParams{Type: "test:unique", Payload: `{"n": 2}`, Unique: byType})
+	if first.Duplicate || !again.Duplicate || again.ID != first.ID {
+		t.Errorf("Expected job %d to be returned as a duplicate, got %d (duplicate=%v)", first.ID, again.ID, again.Duplicate)
+	}
+
+	// 2. Once claimed, the job no longer blocks a pending duplicate
+	claimed := claimOne(t, s, DefaultQueue, "w1")
+	next := mustCreate(t, s, CreateJobParams{Type: "test:unique", Payload: `{}`, Unique: byType})
+	if next.Duplicate || next.ID == claimed.ID {
+		t.Errorf("Expected a new job while %d is running, got %d (duplicate=%v)", claimed.ID, next.ID, next.Duplicate)
+	}
+
+	// 3. Field values decide duplicates, regardless of formatting and other fields
+	inv := mustCreate(t, s, CreateJobParams{Type: "test:invoice", Payload: `{"invoice_id": "a", "amount": 1}`, Unique: byField})
+	jobs, err := s.CreateJobs(ctx, []CreateJobParams{
+		{Type: "test:invoice", Payload: `{"amount":2,"invoice_id":"a"}`, Unique: byField},
+		{Type: "test:invoice", Payload: `{"invoice_id": "b"}`, Unique: byField},
+		{Type: "test:invoice", Payload: `{"invoice_id": "b"}`, Unique: byField},
+	})
+	if err != nil {
+		t.Fatalf("CreateJobs failed: %v", err)
+	}
+	if !jobs[0].Duplicate || jobs[0].ID != inv.ID {
+		t.Errorf("Expected a duplicate of %d, got %d (duplicate=%v)", inv.ID, jobs[0].ID, jobs[0].Duplicate)
+	}
+	if jobs[1].Duplicate || !jobs[2].Duplicate || jobs[2].ID != jobs[1].ID {
+		t.Errorf("Expected one new job for invoice b within the batch, got %+v", jobs[1:])
+	}
+
+	// 4. Jobs older than the period do not count
+	period := &UniqueSpec{Period: 5 * time.Millisecond}
+	old := mustCreate(t, s, CreateJobParams{Type: "test:period", Payload: `{}`, Unique: period})
+	time.Sleep(20 * time.Millisecond)
+	fresh := mustCreate(t, s, CreateJobParams{Type: "test:period", Payload: `{}`, Unique: period})
+	if fresh.Duplicate || fresh.ID == old.ID {
+		t.Errorf("Expected a new job once the period passed, got %d (duplicate=%v)", fresh.ID, fresh.Duplicate)
+	}
+
+	// 5. Field uniqueness needs an object payload, and specs are validated
+	if _, err := s.CreateJob(ctx, CreateJobParams{Type: "test:invoice", Payload: `[1]`, Unique: byField}); err == nil {
+		t.Error("Expected an error for a payload that is not an object")
+	}
+	if _, err := s.CreateJob(ctx, CreateJobParams{Type: "test:unique", Payload: `{}`, Unique: &UniqueSpec{States: []JobStatus{"done"}}}); err == nil {
+		t.Error("Expected an error for an unknown state")
+	}
+
+	// 6. A replayed dead job keeps its key and blocks duplicates again
+	replay := &UniqueSpec{}
+	mustCreate(t, s, CreateJobParams{Type: "test:replay", Payload: `{}`, Queue: "replay", Unique: replay})
+	claimed = claimOne(t, s, "replay", "w1")
+	if err := s.HandleJobFailure(ctx, claimed.ID, "boom", RetryPolicy{MaxAttempts: 1}, claimed.Lease()); err != nil {
+		t.Fatalf("HandleJobFailure failed: %v", err)
+	}
+	if _, err := s.RetryDeadJob(ctx, claimed.ID, nil, nil); err != nil {
+		t.Fatalf("RetryDeadJob failed: %v", err)
+	}
+	dup := mustCreate(t, s, CreateJobParams{Type: "test:replay", Payload: `{}`, Queue: "replay", Unique: replay})
+	if !dup.Duplicate || dup.ID != claimed.ID {
+		t.Errorf("Expected the replayed job %d to be returned as a duplicate, got %d (duplicate=%v)", claimed.ID, dup.ID, dup.Duplicate)
+	}
+
+	// 7. A replay with a new payload takes the key of that payload, unless a job holds it
+	lookup := func(string) *UniqueSpec { return byField }
+	mustCreate(t, s, CreateJobParams{Type: "test:invoice", Payload: `{"invoice_id": "x"}`, Queue: "replay-payload", Unique: byField})
+	claimed = claimOne(t, s, "replay-payload", "w1")
+	if err := s.HandleJobFailure(ctx, claimed.ID, "boom", RetryPolicy{MaxAttempts: 1}, claimed.Lease()); err != nil {
+		t.Fatalf("HandleJobFailure failed: %v", err)
+	}
+	taken := `{"invoice_id": "b"}`
+	if _, err := s.RetryDeadJob(ctx, claimed.ID, &taken, lookup); !errors.Is(err, ErrAlreadyExists) {
+		t.Fatalf("Expected ErrAlreadyExists for the key of job %d, got %v", jobs[1].ID, err)
+	}
+	changed := `{"invoice_id": "y"}`
+	if _, err := s.RetryDeadJob(ctx, claimed.ID, &changed, lookup); err != nil {
+		t.Fatalf("RetryDeadJob failed: %v", err)
+	}
+	dup = mustCreate(t, s, CreateJobParams{Type: "test:invoice", Payload: changed, Unique: byField})
+	if !dup.Duplicate || dup.ID != claimed.ID {
+		t.Errorf("Expected the replayed job %d to hold the new key, got %d (duplicate=%v)", claimed.ID, dup.ID, dup.Duplicate)
+	}
+	if freed := mustCreate(t, s, CreateJobParams{Type: "test:invoice", Payload: `{"invoice_id": "x"}`, Unique: byField}); freed.Duplicate {
+		t.Errorf("Expected the old key to be free, got a duplicate of %d", freed.ID)
+	}
+
+	// 8. A workflow with a duplicate unique step is rejected as a whole
+	before, err := s.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	for name, steps := range map[string][]CreateWorkflowStepParams{
+		"existing": {
+			{Key: "fresh", Job: CreateJobParams{Type: "test:fresh", Payload: `{}`}},
+			{Key: "dup", Job: CreateJobParams{Type: "test:unique", Payload: `{}`, Unique: byType}},
+		},
+		"within": {
+			{Key: "a", Job: CreateJobParams{Type: "test:wf", Payload: `{"invoice_id": "c"}`, Unique: byField}},
+			{Key: "b", Job: CreateJobParams{Type: "test:wf", Payload: `{"invoice_id": "c"}`, Unique: byField}},
+		},
+	} {
+		if _, err := s.CreateWorkflow(ctx, CreateWorkflowParams{Name: name, Steps: steps}); !errors.Is(err, ErrAlreadyExists) {
+			t.Errorf("%s: expected ErrAlreadyExists, got %v", name, err)
+		}
+	}
+	if after, _ := s.GetStats(ctx); after.Total() != before.Total() {
+		t.Errorf("Expected no jobs from rejected workflows, got %d more", after.Total()-before.Total())
+	}
+}
+
+func conformanceClaim(t *testing.T, s Storer) {
+	ctx := context.Background()
+	later := time.Now().Add(time.Hour)
+
+	low := mustCreate(t, s, CreateJobParams{Type: "test:claim", Payload: `{}`, Priority: 1})
+	high := mustCreate(t, s, CreateJobParams{Type: "test:claim", Payload: `{}`, Priority: 10})
+	mustCreate(t, s, CreateJobParams{Type: "test:claim", Payload: `{}`, Priority: 100, RunAt: &later})
+	mustCreate(t, s, CreateJobParams{Type: "test:claim", Payload: `{}`, Priority: 100, Queue: "other"})
+
+	// 1. Higher priority first; delayed jobs and other queues are not claimed
+	jobs, err := s.GetPendingJobs(ctx, ClaimParams{Queue: DefaultQueue, Limit: 10, Owner: "w1", LeaseDuration: time.Minute})
+	if err != nil {
+		t.Fatalf("GetPendingJobs failed: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].ID != high.ID || jobs[1].ID != low.ID {
+		t.Fatalf("Expected jobs [%d %d], got %+v", high.ID, low.ID, jobs)
+	}
+
+	// 2. Claimed jobs are running under a lease with an open attempt
+	claimed := jobs[0]
+	if claimed.Status != JobStatusRunning || claimed.LockedBy.String != "w1" || claimed.LeaseToken != 1 || claimed.LeaseExpiresAt == nil {
+		t.Errorf("Expected a running job leased to w1, got %s %q token %d", claimed.Status, claimed.LockedBy.String, claimed.LeaseToken)
+	}
+	attempts, err := s.ListJobAttempts(ctx, claimed.ID)
+	if err != nil {
+		t.Fatalf("ListJobAttempts failed: %v", err)
+	}
+	if len(attempts) != 1 || attempts[0].Worker != "w1" || attempts[0].Outcome != AttemptRunning {
+		t.Errorf("Expected one running attempt by w1, got %+v", attempts)
+	}
+
+	// 3. A running job is not claimed twice
+	again, err := s.GetPendingJobs(ctx, ClaimParams{Queue: DefaultQueue, Limit: 10, Owner: "w2", LeaseDuration: time.Minute})
+	if err != nil {
+		t.Fatalf("GetPendingJobs failed: %v", err)
+	}
+	if len(again) != 0 {
+		t.Errorf("Expected nothing left to claim, got %d jobs", len(again))
+	}
+}
+
+func conformanceClaimConcurrency(t *testing.T, s Storer) {
+	ctx := context.Background()
+	const total = 50
+	for i := 0; i < total; i++ {
+		mustCreate(t, s, CreateJobParams{Type: "test:concurrent", Payload: `{}`})
+	}
+
+	var mu sync.Mutex
+	seen := map[int64]int{}
+	var wg sync.WaitGroup
+	for w := 0; w < 5; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for {
+				jobs, err := s.GetPendingJobs(ctx, ClaimParams{Limit: 3, Owner: fmt.Sprintf("w%d", w), LeaseDuration: time.Minute})
+				if err != nil {
+					t.Errorf("GetPendingJobs failed: %v", err)
+					return
+				}
+				if len(jobs) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, j := range jobs {
+					seen[j.ID]++
+				}
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if len(seen) != total {
+		t.Errorf("Expected %d distinct jobs claimed, got %d", total, len(seen))
+	}
+	for id, n := range seen {
+		if n != 1 {
+			t.Errorf("Job %d was claimed %d times", id, n)
+		}
+	}
+}
+
+func conformanceCompleteJob(t *testing.T, s Storer) {
+	ctx := context.Background()
+	mustCreate(t, s, CreateJobParams{Type: "test:complete", Payload: `{}`})
+	job := claimOne(t, s, DefaultQueue, "w1")
+
+	// 1. Progress is fenced on the lease
+	if err := s.UpdateJobProgress(ctx, job.ID, job.Lease(), 40, "halfway"); err != nil {
+		t.Fatalf("UpdateJobProgress failed: %v", err)
+	}
+	stale := Lease{Owner: "w1", Token: job.LeaseToken + 1}
+	if err := s.UpdateJobProgress(ctx, job.ID, stale, 50, ""); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost for a stale lease, got %v", err)
+	}
+
+	// 2. Completing stores the result and releases the lease
+	if err := s.CompleteJob(ctx, job.ID, job.Lease(), json.RawMessage(`{"ok": true}`)); err != nil {
+		t.Fatalf("CompleteJob failed: %v", err)
+	}
+	got, _ := s.GetJobByID(ctx, job.ID)
+	if got.Status != JobStatusCompleted || got.CompletedAt == nil || got.LockedBy.Valid {
+		t.Errorf("Expected a completed, unlocked job, got %s locked=%v", got.Status, got.LockedBy.Valid)
+	}
+	if string(got.Result) != `{"ok": true}` {
+		t.Errorf("Expected the result to be stored, got %s", got.Result)
+	}
+	if got.Progress.Int32 != 40 || got.ProgressMessage.String != "halfway" {
+		t.Errorf("Expected progress 40 halfway, got %d %q", got.Progress.Int32, got.ProgressMessage.String)
+	}
+
+	// 3. The lease cannot finish the job twice
+	if err := s.UpdateJobStatus(ctx, JobStatusFailed, job.ID, job.Lease()); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost, got %v", err)
+	}
+
+	attempts, _ := s.ListJobAttempts(ctx, job.ID)
+	if len(attempts) != 1 || attempts[0].Outcome != AttemptCompleted || attempts[0].FinishedAt == nil {
+		t.Errorf("Expected one completed attempt, got %+v", attempts)
+	}
+}
+
+func conformanceRetryAndDeadLetter(t *testing.T, s Storer) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour, Backoff: BackoffFixed}
+	job := mustCreate(t, s, CreateJobParams{Type: "test:retry", Payload: `{}`, Priority: 3})
+
+	// 1. The first failure schedules a retry after the backoff
+	claimed := claimOne(t, s, DefaultQueue, "w1")
+	before := time.Now()
+	if err := s.HandleJobFailure(ctx, claimed.ID, "boom 1", policy, claimed.Lease()); err != nil {
+		t.Fatalf("HandleJobFailure failed: %v", err)
+	}
+	got, _ := s.GetJobByID(ctx, job.ID)
+	if got.Status != JobStatusPending || got.RetryCount != 1 || got.ErrorMessage.String != "boom 1" {
+		t.Errorf("Expected a pending retry with the error, got %s retry %d %q", got.Status, got.RetryCount, got.ErrorMessage.String)
+	}
+	if got.NextRunAt.Before(before.Add(59 * time.Minute)) {
+		t.Errorf("Expected the retry an hour later, got %v", got.NextRunAt)
+	}
+	if jobs, _ := s.GetPendingJobs(ctx, ClaimParams{Limit: 1, Owner: "w1", LeaseDuration: time.Minute}); len(jobs) != 0 {
+		t.Error("Expected the retry not to be claimable before its backoff")
+	}
+
+	// 2. A stale lease cannot fail the job
+	if err := s.HandleJobFailure(ctx, claimed.ID, "late", policy, claimed.Lease()); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost, got %v", err)
+	}
+
+	// 3. The last attempt moves the job to the dead letter queue
+	if _, err := s.RetryDeadJob(ctx, job.ID, nil, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a live job not to be retryable from the DLQ, got %v", err)
+	}
+	second := mustCreate(t, s, CreateJobParams{Type: "test:retry", Payload: `{}`, Queue: "dlq"})
+	claimed = claimOne(t, s, "dlq", "w1")
+	if err := s.HandleJobFailure(ctx, claimed.ID, "boom", RetryPolicy{MaxAttempts: 1}, claimed.Lease()); err != nil {
+		t.Fatalf("HandleJobFailure failed: %v", err)
+	}
+	if _, err := s.GetJobByID(ctx, second.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the dead job to leave jobs, got %v", err)
+	}
+	dead, err := s.ListDeadJobs(ctx, DeadJobFilter{}, JobSort{}, PageParams{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListDeadJobs failed: %v", err)
+	}
+	if len(dead.Jobs) != 1 || dead.Jobs[0].ID != second.ID || dead.Jobs[0].RetryCount != 1 || dead.Jobs[0].ErrorMessage.String != "boom" {
+		t.Errorf("Expected job %d in the DLQ, got %+v", second.ID, dead.Jobs)
+	}
+
+	// 4. Its attempt history survives
+	attempts, err := s.ListJobAttempts(ctx, second.ID)
+	if err != nil || len(attempts) != 1 || attempts[0].Outcome != AttemptFailed || attempts[0].Error.String != "boom" {
+		t.Errorf("Expected one failed attempt, got %+v (%v)", attempts, err)
+	}
+}
+
+func conformanceLeases(t *testing.T, s Storer) {
+	ctx := context.Background()
+	mustCreate(t, s, CreateJobParams{Type: "test:lease", Payload: `{}`})
+	jobs, err := s.GetPendingJobs(ctx, ClaimParams{Limit: 1, Owner: "w1", LeaseDuration: time.Millisecond})
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("Expected to claim 1 job, got %d (%v)", len(jobs), err)
+	}
+	first := jobs[0]
+
+	policy := func(Job) RetryPolicy { return RetryPolicy{MaxAttempts: 2} }
+
+	// 1. An expired lease is reclaimed and its attempt closed
+	time.Sleep(20 * time.Millisecond)
+	reclaimed, err := s.ReclaimExpiredJobs(ctx, policy)
+	if err != nil || reclaimed != 1 {
+		t.Fatalf("Expected 1 reclaimed job, got %d (%v)", reclaimed, err)
+	}
+
+	// 2. The new owner holds the lease; the old one lost it. The reclaim counted as a failure
+	second := claimOne(t, s, DefaultQueue, "w2")
+	if second.ID != first.ID || second.LeaseToken <= first.LeaseToken {
+		t.Fatalf("Expected job %d to be claimed again with a newer token, got %d token %d", first.ID, second.ID, second.LeaseToken)
+	}
+	if second.RetryCount != 1 {
+		t.Errorf("Expected retry count 1 after the reclaim, got %d", second.RetryCount)
+	}
+	lost, err := s.ExtendLeases(ctx, map[int64]Lease{first.ID: first.Lease()}, time.Minute)
+	if err != nil || !slices.Equal(lost, []int64{first.ID}) {
+		t.Errorf("Expected the old lease to be lost, got %v (%v)", lost, err)
+	}
+	lost, err = s.ExtendLeases(ctx, map[int64]Lease{second.ID: second.Lease()}, time.Minute)
+	if err != nil || len(lost) != 0 {
+		t.Errorf("Expected the new lease to be held, got %v (%v)", lost, err)
+	}
+	if err := s.CompleteJob(ctx, first.ID, first.Lease(), nil); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost for the old owner, got %v", err)
+	}
+	if err := s.CompleteJob(ctx, second.ID, second.Lease(), nil); err != nil {
+		t.Errorf("CompleteJob by the new owner failed: %v", err)
+	}
+
+	attempts, _ := s.ListJobAttempts(ctx, first.ID)
+	if len(attempts) != 2 || attempts[0].Outcome != AttemptLeaseExpired || attempts[1].Outcome != AttemptCompleted {
+		t.Errorf("Expected a lease_expired then a completed attempt, got %+v", attempts)
+	}
+
+	// 3. A job that keeps losing its worker is dead-lettered once its attempts run out
+	crasher := mustCreate(t, s, CreateJobParams{Type: "test:lease", Payload: `{}`, Queue: "crash"})
+	for i := 0; i < 2; i++ {
+		if _, err := s.GetPendingJobs(ctx, ClaimParams{Limit: 1, Queue: "crash", Owner: "w1", LeaseDuration: time.Millisecond}); err != nil {
+			t.Fatalf("GetPendingJobs failed: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+		if _, err := s.ReclaimExpiredJobs(ctx, policy); err != nil {
+			t.Fatalf("ReclaimExpiredJobs failed: %v", err)
+		}
+	}
+	if _, err := s.GetJobByID(ctx, crasher.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the crashing job to leave jobs, got %v", err)
+	}
+	dead, err := s.ListDeadJobs(ctx, DeadJobFilter{}, JobSort{}, PageParams{Limit: 10})
+	if err != nil || len(dead.Jobs) != 1 || dead.Jobs[0].ID != crasher.ID || dead.Jobs[0].RetryCount != 2 {
+		t.Errorf("Expected job %d in the DLQ after 2 attempts, got %+v (%v)", crasher.ID, dead, err)
+	}
+}
+
+func conformanceCancelJob(t *testing.T, s Storer) {
+	ctx := context.Background()
+	pending := mustCreate(t, s, CreateJobParams{Type: "test:cancel", Payload: `{}`, Queue: "pending"})
+	mustCreate(t, s, CreateJobParams{Type: "test:cancel", Payload: `{}`, Queue: "running"})
+	running := claimOne(t, s, "running", "w1")
+
+	// 1. Pending jobs are never claimed once cancelled
+	cancelled, err := s.CancelJob(ctx, pending.ID)
+	if err != nil || cancelled.Status != JobStatusCancelled {
+		t.Fatalf("Expected the job to be cancelled, got %v", err)
+	}
+	if jobs, _ := s.GetPendingJobs(ctx, ClaimParams{Queue: "pending", Limit: 1, Owner: "w1", LeaseDuration: time.Minute}); len(jobs) != 0 {
+		t.Error("Expected a cancelled job not to be claimed")
+	}
+
+	// 2. A running job keeps its cancellation when its worker finishes
+	if _, err := s.CancelJob(ctx, running.ID); err != nil {
+		t.Fatalf("CancelJob failed: %v", err)
+	}
+	ids, err := s.GetCancelledJobIDs(ctx, []int64{running.ID, pending.ID + 1000})
+	if err != nil || !slices.Equal(ids, []int64{running.ID}) {
+		t.Errorf("Expected [%d] cancelled, got %v (%v)", running.ID, ids, err)
+	}
+	if err := s.CompleteJob(ctx, running.ID, running.Lease(), nil); err != nil {
+		t.Errorf("Expected finishing a cancelled job to be a no-op, got %v", err)
+	}
+	if err := s.HandleJobFailure(ctx, running.ID, "boom", RetryPolicy{}, running.Lease()); err != nil {
+		t.Errorf("Expected failing a cancelled job to be a no-op, got %v", err)
+	}
+	got, _ := s.GetJobByID(ctx, running.ID)
+	if got.Status != JobStatusCancelled {
+		t.Errorf("Expected the job to stay cancelled, got %s", got.Status)
+	}
+
+	// 3. Finished jobs cannot be cancelled
+	if _, err := s.CancelJob(ctx, running.ID); !errors.Is(err, ErrJobFinished) {
+		t.Errorf("Expected ErrJobFinished, got %v", err)
+	}
+	if _, err := s.CancelJob(ctx, pending.ID+1000); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func conformanceListJobs(t *testing.T, s Storer) {
+	ctx := context.Background()
+	mustCreate(t, s, CreateJobParams{Type: "test:a", Payload: `{}`, Priority: 1})
+	mustCreate(t, s, CreateJobParams{Type: "test:a", Payload: `{}`, Priority: 5, Queue: "media"})
+	mustCreate(t, s, CreateJobParams{Type: "test:b", Payload: `{}`, Priority: 9, Queue: "failing"})
+	failed := claimOne(t, s, "failing", "w1")
+	if err := s.HandleJobFailure(ctx, failed.ID, "rate limit exceeded", RetryPolicy{MaxAttempts: 5}, failed.Lease()); err != nil {
+		t.Fatalf("HandleJobFailure failed: %v", err)
+	}
+
+	minPriority := 2
+	minRetries := 1
+	tests := []struct {
+		name   string
+		filter JobFilter
+		want   int
+	}{
+		{"all", JobFilter{}, 3},
+		{"type", JobFilter{Type: "test:a"}, 2},
+		{"queue", JobFilter{Queue: "media"}, 1},
+		{"priority", JobFilter{MinPriority: &minPriority}, 2},
+		{"status", JobFilter{Statuses: []JobStatus{JobStatusRunning, JobStatusCompleted}}, 0},
+		{"retries", JobFilter{MinRetries: &minRetries}, 1},
+		{"error", JobFilter{ErrorContains: "rate limit"}, 1},
+		{"created", JobFilter{CreatedAfter: ptr(time.Now().Add(time.Hour))}, 0},
+		{"completed", JobFilter{CompletedBefore: ptr(time.Now().Add(time.Hour))}, 0},
+	}
+	for _, tt := range tests {
+		page, err := s.ListJobs(ctx, tt.filter, JobSort{}, PageParams{Limit: 10})
+		if err != nil {
+			t.Fatalf("ListJobs(%s) failed: %v", tt.name, err)
+		}
+		if len(page.Jobs) != tt.want || page.Meta.TotalRecords != int64(tt.want) {
+			t.Errorf("ListJobs(%s): expected %d jobs, got %d (total %d)", tt.name, tt.want, len(page.Jobs), page.Meta.TotalRecords)
+		}
+	}
+
+	// sorting by priority, highest first
+	page, err := s.ListJobs(ctx, JobFilter{}, JobSort{Field: SortByPriority}, PageParams{Limit: 10})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	var priorities []int
+	for _, j := range page.Jobs {
+		priorities = append(priorities, j.Priority)
+	}
+	if !slices.Equal(priorities, []int{9, 5, 1}) {
+		t.Errorf("Expected priorities [9 5 1], got %v", priorities)
+	}
+}
+
+func conformanceListJobsPagination(t *testing.T, s Storer) {
+	ctx := context.Background()
+	var created []int64
+	for i := 0; i < 5; i++ {
+		created = append(created, mustCreate(t, s, CreateJobParams{Type: "test:page", Payload: `{}`}).ID)
+	}
+
+	// 1. Token pages walk the whole listing, oldest first, without repeats
+	var seen []int64
+	sort := JobSort{Field: SortByCreatedAt, Ascending: true}
+	page := PageParams{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("Expected the listing to end")
+		}
+		result, err := s.ListJobs(ctx, JobFilter{}, sort, page)
+		if err != nil {
+			t.Fatalf("ListJobs failed: %v", err)
+		}
+		for _, j := range result.Jobs {
+			seen = append(seen, j.ID)
+		}
+		if result.NextPageToken == "" {
+			break
+		}
+		page = PageParams{Limit: 2, PageToken: result.NextPageToken}
+	}
+	if !slices.Equal(seen, created) {
+		t.Errorf("Expected %v, got %v", created, seen)
+	}
+
+	// 2. Offset pages report their page number and total
+	result, err := s.ListJobs(ctx, JobFilter{}, JobSort{}, PageParams{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if result.Meta.CurrentPage != 2 || result.Meta.TotalPages != 3 || result.Meta.TotalRecords != 5 {
+		t.Errorf("Expected page 2 of 3 with 5 jobs, got %+v", result.Meta)
+	}
+	if len(result.Jobs) != 2 || result.Jobs[0].ID != created[2] {
+		t.Errorf("Expected the newest-first page to start at job %d, got %+v", created[2], result.Jobs)
+	}
+
+	// 3. Tokens cannot be combined with offsets or reused for another sort
+	first, _ := s.ListJobs(ctx, JobFilter{}, JobSort{}, PageParams{Limit: 2})
+	if _, err := s.ListJobs(ctx, JobFilter{}, JobSort{}, PageParams{Limit: 2, Offset: 2, PageToken: first.NextPageToken}); !errors.Is(err, ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken for token and offset, got %v", err)
+	}
+	if _, err := s.ListJobs(ctx, JobFilter{}, JobSort{Field: SortByPriority}, PageParams{Limit: 2, PageToken: first.NextPageToken}); !errors.Is(err, ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken for another sort, got %v", err)
+	}
+}
+
+func conformanceRetryDeadJobs(t *testing.T, s Storer) {
+	ctx := context.Background()
+	var dead []int64
+	for i, errMsg := range []string{"rate limit", "rate limit", "bad request"} {
+		job := mustCreate(t, s, CreateJobParams{Type: "test:dlq", Payload: fmt.Sprintf(`{"n": %d}`, i)})
+		claimed := claimOne(t, s, DefaultQueue, "w1")
+		if err := s.HandleJobFailure(ctx, claimed.ID, errMsg, RetryPolicy{MaxAttempts: 1}, claimed.Lease()); err != nil {
+			t.Fatalf("HandleJobFailure failed: %v", err)
+		}
+		dead = append(dead, job.ID)
+	}
+
+	// 1. A single job is requeued under its id with a new payload
+	payload := `{"n": 42}`
+	job, err := s.RetryDeadJob(ctx, dead[2], &payload, nil)
+	if err != nil {
+		t.Fatalf("RetryDeadJob failed: %v", err)
+	}
+	if job.ID != dead[2] || job.Status != JobStatusPending || job.Payload != payload || job.ReplayCount != 1 || job.RetryCount != 0 {
+		t.Errorf("Expected a fresh pending replay of job %d, got %+v", dead[2], job)
+	}
+	if _, err := s.RetryDeadJob(ctx, dead[2], nil, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a requeued job, got %v", err)
+	}
+
+	// 2. A filter requeues the matching jobs only
+	ids, err := s.RetryDeadJobs(ctx, DeadJobFilter{ErrorContains: "rate"})
+	if err != nil {
+		t.Fatalf("RetryDeadJobs failed: %v", err)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, dead[:2]) {
+		t.Errorf("Expected %v requeued, got %v", dead[:2], ids)
+	}
+	stats, _ := s.GetStats(ctx)
+	if stats.Pending != 3 || stats.Dead != 0 {
+		t.Errorf("Expected 3 pending and no dead jobs, got %+v", stats)
+	}
+}
+
+func conformanceStats(t *testing.T, s Storer) {
+	ctx := context.Background()
+	mustCreate(t, s, CreateJobParams{Type: "test:stats", Payload: `{}`, Queue: "pending"})
+	mustCreate(t, s, CreateJobParams{Type: "test:stats", Payload: `{}`, Queue: "running"})
+	mustCreate(t, s, CreateJobParams{Type: "test:stats", Payload: `{}`, Queue: "done"})
+	mustCreate(t, s, CreateJobParams{Type: "test:other", Payload: `{}`, Queue: "dead"})
+	claimOne(t, s, "running", "w1")
+	done := claimOne(t, s, "done", "w1")
+	if err := s.CompleteJob(ctx, done.ID, done.Lease(), nil); err != nil {
+		t.Fatalf("CompleteJob failed: %v", err)
+	}
+	dead := claimOne(t, s, "dead", "w1")
+	if err := s.HandleJobFailure(ctx, dead.ID, "boom", RetryPolicy{MaxAttempts: 1}, dead.Lease()); err != nil {
+		t.Fatalf("HandleJobFailure failed: %v", err)
+	}
+
+	// 1. Counts per status
+	stats, err := s.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	want := JobStats{Pending: 1, Running: 1, Completed: 1, Dead: 1}
+	if *stats != want {
+		t.Errorf("Expected %+v, got %+v", want, *stats)
+	}
+
+	// 2. Per-type activity in the window
+	types, err := s.GetJobTypeStats(ctx, TypeStatsParams{Window: time.Hour, Bucket: time.Minute})
+	if err != nil {
+		t.Fatalf("GetJobTypeStats failed: %v", err)
+	}
+	if len(types) != 2 || types[0].Type != "test:other" || types[1].Type != "test:stats" {
+		t.Fatalf("Expected stats for test:other and test:stats, got %+v", types)
+	}
+	if types[0].Total.Failed != 1 || types[1].Total.Completed != 1 || types[1].Total.Started != 2 {
+		t.Errorf("Unexpected totals: %+v, %+v", types[0].Total, types[1].Total)
+	}
+	if len(types[1].Buckets) != 60 || types[1].Buckets[59].Completed != 1 {
+		t.Errorf("Expected the completion in the last of 60 buckets, got %d buckets", len(types[1].Buckets))
+	}
+}
+
+func conformanceArchivedJobs(t *testing.T, s Storer) {
+	ctx := context.Background()
+	mustCreate(t, s, CreateJobParams{Type: "test:archive", Payload: `{}`})
+	mustCreate(t, s, CreateJobParams{Type: "test:archive", Payload: `{}`})
+	done := claimOne(t, s, DefaultQueue, "w1")
+	if err := s.CompleteJob(ctx, done.ID, done.Lease(), nil); err != nil {
+		t.Fatalf("CompleteJob failed: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// 1. Only completed jobs older than the duration are selected
+	jobs, err := s.GetArchivedJobs(ctx, 10*time.Millisecond, 10)
+	if err != nil {
+		t.Fatalf("GetArchivedJobs failed: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != done.ID {
+		t.Fatalf("Expected job %d to be archivable, got %+v", done.ID, jobs)
+	}
+	if jobs, _ := s.GetArchivedJobs(ctx, time.Hour, 10); len(jobs) != 0 {
+		t.Errorf("Expected nothing older than an hour, got %d jobs", len(jobs))
+	}
+
+	// 2. Deleting removes the job and its history
+	if err := s.BatchDeleteJobs(ctx, []int64{done.ID}); err != nil {
+		t.Fatalf("BatchDeleteJobs failed: %v", err)
+	}
+	if _, err := s.GetJobByID(ctx, done.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the job to be deleted, got %v", err)
+	}
+	if _, err := s.ListJobAttempts(ctx, done.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected the attempts to be deleted, got %v", err)
+	}
+}
+
+func conformanceSchedules(t *testing.T, s Storer) {
+	ctx := context.Background()
+	due := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	sc, err := s.CreateSchedule(ctx, CreateScheduleParams{
+		Name: "nightly", CronExpr: "0 2 * * *", Timezone: "UTC", JobType: "test:cron",
+		PayloadTemplate: `{}`, CatchupPolicy: CatchupOnce, NextRunAt: due,
+	})
+	if err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+	if _, err := s.CreateSchedule(ctx, CreateScheduleParams{Name: "nightly", CronExpr: "0 3 * * *", Timezone: "UTC", JobType: "test:cron", PayloadTemplate: `{}`, CatchupPolicy: CatchupSkip, NextRunAt: due}); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists for a taken schedule name, got %v", err)
+	}
+
+	// 1. A due schedule fires exactly once for its tick
+	schedules, err := s.GetDueSchedules(ctx, time.Now(), 10)
+	if err != nil || len(schedules) != 1 || schedules[0].ID != sc.ID {
+		t.Fatalf("Expected schedule %d to be due, got %+v (%v)", sc.ID, schedules, err)
+	}
+	next := due.Add(24 * time.Hour)
+	runs := []CreateJobParams{{Type: "test:cron", Payload: `{}`}}
+	fired, err := s.FireSchedule(ctx, schedules[0], runs, next)
+	if err != nil || !fired {
+		t.Fatalf("Expected the schedule to fire, got %v (%v)", fired, err)
+	}
+	fired, err = s.FireSchedule(ctx, schedules[0], runs, next)
+	if err != nil || fired {
+		t.Errorf("Expected a second fire of the same tick to be refused, got %v (%v)", fired, err)
+	}
+	if stats, _ := s.GetStats(ctx); stats.Pending != 1 {
+		t.Errorf("Expected 1 enqueued run, got %d", stats.Pending)
+	}
+
+	got, err := s.GetSchedule(ctx, sc.ID)
+	if err != nil || !got.NextRunAt.Equal(next) || got.LastRunAt == nil {
+		t.Errorf("Expected the schedule to advance to %v, got %+v (%v)", next, got, err)
+	}
+
+	// 2. Paused schedules are not due
+	if _, err := s.SetSchedulePaused(ctx, sc.ID, true, due); err != nil {
+		t.Fatalf("SetSchedulePaused failed: %v", err)
+	}
+	if schedules, _ := s.GetDueSchedules(ctx, time.Now(), 10); len(schedules) != 0 {
+		t.Errorf("Expected no due schedules while paused, got %d", len(schedules))
+	}
+
+	// 3. Deleting is final
+	if err := s.DeleteSchedule(ctx, sc.ID); err != nil {
+		t.Fatalf("DeleteSchedule failed: %v", err)
+	}
+	if err := s.DeleteSchedule(ctx, sc.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if list, _ := s.ListSchedules(ctx); len(list) != 0 {
+		t.Errorf("Expected no schedules, got %d", len(list))
+	}
+}
+
+func conformanceWorkflow(t *testing.T, s Storer) {
+	ctx := context.Background()
+	wf, err := s.CreateWorkflow(ctx, CreateWorkflowParams{
+		Name: "pipeline",
+		Steps: []CreateWorkflowStepParams{
+			{Key: "extract", Job: CreateJobParams{Type: "test:extract", Payload: `{}`}},
+			{Key: "load", DependsOn: []string{"extract"}, Job: CreateJobParams{Type: "test:load", Payload: `{}`}},
+			{Key: "report", DependsOn: []string{"load"}, Job: CreateJobParams{Type: "test:report", Payload: `{}`}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateWorkflow failed: %v", err)
+	}
+	if _, err := s.CreateWorkflow(ctx, CreateWorkflowParams{Name: "broken", Steps: []CreateWorkflowStepParams{
+		{Key: "a", DependsOn: []string{"b"}, Job: CreateJobParams{Type: "test:a", Payload: `{}`}},
+	}}); err == nil {
+		t.Error("Expected a dependency on an undeclared step to be rejected")
+	}
+
+	// 1. Only the root is claimable
+	extract := claimOne(t, s, DefaultQueue, "w1")
+	if extract.ID != wf.Steps[0].JobID {
+		t.Fatalf("Expected the root job %d, got %d", wf.Steps[0].JobID, extract.ID)
+	}
+	if jobs, _ := s.GetPendingJobs(ctx, ClaimParams{Limit: 10, Owner: "w1", LeaseDuration: time.Minute}); len(jobs) != 0 {
+		t.Fatalf("Expected dependants to wait, got %d jobs", len(jobs))
+	}
+
+	// 2. Completing the root releases its dependant
+	if err := s.CompleteJob(ctx, extract.ID, extract.Lease(), nil); err != nil {
+		t.Fatalf("CompleteJob failed: %v", err)
+	}
+	load := claimOne(t, s, DefaultQueue, "w1")
+	if load.ID != wf.Steps[1].JobID {
+		t.Fatalf("Expected job %d, got %d", wf.Steps[1].JobID, load.ID)
+	}
+
+	// 3. Dead-lettering a step skips what depends on it
+	if err := s.HandleJobFailure(ctx, load.ID, "boom", RetryPolicy{MaxAttempts: 1}, load.Lease()); err != nil {
+		t.Fatalf("HandleJobFailure failed: %v", err)
+	}
+	got, err := s.GetWorkflow(ctx, wf.ID)
+	if err != nil {
+		t.Fatalf("GetWorkflow failed: %v", err)
+	}
+	var statuses []JobStatus
+	for _, step := range got.Steps {
+		statuses = append(statuses, step.Status)
+	}
+	want := []JobStatus{JobStatusCompleted, JobStatusDead, JobStatusSkipped}
+	if !slices.Equal(statuses, want) || got.Status() != WorkflowStatusFailed {
+		t.Errorf("Expected %v (failed), got %v (%s)", want, statuses, got.Status())
+	}
+	if !slices.Equal(got.Steps[2].DependsOn, []string{"load"}) {
+		t.Errorf("Expected report to depend on load, got %v", got.Steps[2].DependsOn)
+	}
+
+	// 4. Replaying the dead step restores its dependants
+	if _, err := s.RetryDeadJob(ctx, load.ID, nil, nil); err != nil {
+		t.Fatalf("RetryDeadJob failed: %v", err)
+	}
+	report, _ := s.GetJobByID(ctx, wf.Steps[2].JobID)
+	if report.Status != JobStatusPending {
+		t.Errorf("Expected the skipped step to be pending again, got %s", report.Status)
+	}
+
+	// 5. Archived steps keep their final status, not just completed ones
+	if _, err := s.CancelJob(ctx, report.ID); err != nil {
+		t.Fatalf("CancelJob failed: %v", err)
+	}
+	if err := s.BatchDeleteJobs(ctx, []int64{extract.ID, report.ID}); err != nil {
+		t.Fatalf("BatchDeleteJobs failed: %v", err)
+	}
+	got, _ = s.GetWorkflow(ctx, wf.ID)
+	statuses = statuses[:0]
+	for _, step := range got.Steps {
+		statuses = append(statuses, step.Status)
+	}
+	want = []JobStatus{JobStatusCompleted, JobStatusPending, JobStatusCancelled}
+	if !slices.Equal(statuses, want) {
+		t.Errorf("Expected %v, got %v", want, statuses)
+	}
+
+	if _, err := s.GetWorkflow(ctx, wf.ID+1000); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
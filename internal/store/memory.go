@@ -0,0 +1,841 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+)
+
+// defaultMaxRetries mirrors the default of the jobs.max_retries column.
+const defaultMaxRetries = 3
+
+// MemoryStore is a Storer that keeps every job, schedule and workflow in
+// process memory. It implements the claim, lease, retry and dead-letter
+// semantics of the Postgres store and is meant for local development and
+// tests: nothing survives a restart and it cannot be shared between
+// processes. It is safe for concurrent use.
+type MemoryStore struct {
+	mu sync.Mutex
+
+	nextJobID      int64
+	nextScheduleID int64
+	nextWorkflowID int64
+
+	jobs     map[int64]*Job
+	dead     map[int64]*deadJob
+	attempts map[int64][]JobAttempt
+	keys     map[string]idempotencyKey
+	// deps holds the dependencies of each job, keyed by the dependant's id.
+	deps      map[int64][]dependency
+	schedules map[int64]*Schedule
+	workflows map[int64]*memoryWorkflow
+
+	listener jobListener
+}
+
+var (
+	_ Storer   = (*MemoryStore)(nil)
+	_ Listener = (*MemoryStore)(nil)
+)
+
+// deadJob is a row of dead_jobs.
+type deadJob struct {
+	job      Job
+	failedAt time.Time
+}
+
+// idempotencyKey is a row of job_idempotency_keys.
+type idempotencyKey struct {
+	jobID     int64
+	expiresAt time.Time
+}
+
+// dependency is a row of job_dependencies.
+type dependency struct {
+	parent   int64
+	resolved bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		jobs:      make(map[int64]*Job),
+		dead:      make(map[int64]*deadJob),
+		attempts:  make(map[int64][]JobAttempt),
+		keys:      make(map[string]idempotencyKey),
+		deps:      make(map[int64][]dependency),
+		schedules: make(map[int64]*Schedule),
+		workflows: make(map[int64]*memoryWorkflow),
+	}
+}
+
+func (m *MemoryStore) Close() {
+	m.listener.close()
+}
+
+// ListenJobs returns a channel that receives a value whenever jobs in queue
+// may have become ready, like the Postgres store's LISTEN. The channel is
+// closed when ctx is done.
+func (m *MemoryStore) ListenJobs(ctx context.Context, queue string) <-chan struct{} {
+	return m.listener.subscribe(ctx, queue)
+}
+
+// now returns the current time the way Postgres stores it: in UTC, at
+// microsecond precision and without a monotonic clock reading.
+func (m *MemoryStore) now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// notifyJobsReady wakes the listeners of the queues of the given jobs that are due.
+func (m *MemoryStore) notifyJobsReady(now time.Time, ids ...int64) {
+	for _, id := range ids {
+		if j, ok := m.jobs[id]; ok && j.Status == JobStatusPending && !j.NextRunAt.After(now) {
+			m.listener.wake(j.Queue)
+		}
+	}
+}
+
+func (m *MemoryStore) CreateJob(ctx context.Context, params CreateJobParams) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, err := m.createJob(m.now(), params)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// uniqueDuplicate returns the oldest job with unique key that blocks a
+// duplicate submitted at now under spec, or nil.
+func (m *MemoryStore) uniqueDuplicate(now time.Time, key string, spec *UniqueSpec) *Job {
+	var existing *Job
+	for _, j := range m.jobs {
+		if j.UniqueKey.String != key || !spec.Blocks(j.Status, j.CreatedAt, now) {
+			continue
+		}
+		if existing == nil || j.ID < existing.ID {
+			existing = j
+		}
+	}
+	return existing
+}
+
+// createJob inserts a job and returns a copy of it. If the job carries an
+// idempotency key that is already held by an unexpired job, or is unique and
+// has a duplicate, that job is returned with Duplicate set instead.
+func (m *MemoryStore) createJob(now time.Time, params CreateJobParams) (Job, error) {
+	if !json.Valid([]byte(params.Payload)) {
+		return Job{}, fmt.Errorf("insert job: payload is not valid JSON")
+	}
+
+	queue := params.Queue
+	if queue == "" {
+		queue = DefaultQueue
+	}
+
+	var uniqueKey string
+	if params.Unique != nil {
+		key, err := params.UniqueKey()
+		if err != nil {
+			return Job{}, fmt.Errorf("insert job: %w", err)
+		}
+
+		if existing := m.uniqueDuplicate(now, key, params.Unique); existing != nil {
+			job := *existing
+			job.Duplicate = true
+			return job, nil
+		}
+		uniqueKey = key
+	}
+
+	if params.IdempotencyKey != "" {
+		if held, ok := m.keys[params.IdempotencyKey]; ok {
+			if j, ok := m.jobs[held.jobID]; ok && held.expiresAt.After(now) {
+				job := *j
+				job.Duplicate = true
+				return job, nil
+			}
+			// the job holding the key has outlived its retention window
+			m.releaseKey(params.IdempotencyKey)
+		}
+	}
+
+	m.nextJobID++
+	job := &Job{
+		ID:        m.nextJobID,
+		Type:      params.Type,
+		Payload:   params.Payload,
+		Status:    JobStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+		NextRunAt: now,
+		Priority:  params.Priority,
+		Queue:     queue,
+
+		RetryPolicy: params.RetryPolicy,
+	}
+	if params.RunAt != nil {
+		job.NextRunAt = params.RunAt.UTC().Truncate(time.Microsecond)
+	}
+	if params.Timeout > 0 {
+		job.TimeoutMs = sql.NullInt64{Int64: params.Timeout.Milliseconds(), Valid: true}
+	}
+	if params.IdempotencyKey != "" {
+		expiresAt := now.Add(params.IdempotencyTTL)
+		job.IdempotencyKey = sql.NullString{String: params.IdempotencyKey, Valid: true}
+		job.IdempotencyExpiresAt = &expiresAt
+		m.keys[params.IdempotencyKey] = idempotencyKey{jobID: job.ID, expiresAt: expiresAt}
+	}
+	if uniqueKey != "" {
+		job.UniqueKey = sql.NullString{String: uniqueKey, Valid: true}
+	}
+	m.jobs[job.ID] = job
+
+	m.notifyJobsReady(now, job.ID)
+	return *job, nil
+}
+
+// releaseKey frees an idempotency key and clears it on the job holding it.
+func (m *MemoryStore) releaseKey(key string) {
+	held, ok := m.keys[key]
+	if !ok {
+		return
+	}
+	delete(m.keys, key)
+	if j, ok := m.jobs[held.jobID]; ok {
+		j.IdempotencyKey = sql.NullString{}
+		j.IdempotencyExpiresAt = nil
+	}
+}
+
+// releaseKeysOf frees the idempotency keys held by the given jobs.
+func (m *MemoryStore) releaseKeysOf(ids ...int64) {
+	for key, held := range m.keys {
+		if slices.Contains(ids, held.jobID) {
+			delete(m.keys, key)
+		}
+	}
+}
+
+// CreateJobs inserts many jobs atomically and returns them in the order of
+// params. Like the Postgres store, jobs with an idempotency key or unique spec
+// get their ids before the others.
+func (m *MemoryStore) CreateJobs(ctx context.Context, params []CreateJobParams) ([]Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, p := range params {
+		if !json.Valid([]byte(p.Payload)) {
+			return nil, fmt.Errorf("insert jobs: payload is not valid JSON")
+		}
+		if p.Unique != nil {
+			if _, err := p.UniqueKey(); err != nil {
+				return nil, fmt.Errorf("insert jobs: %w", err)
+			}
+		}
+	}
+
+	now := m.now()
+	jobs := make([]Job, len(params))
+	for _, keyed := range []bool{true, false} {
+		for i, p := range params {
+			if (p.IdempotencyKey != "" || p.Unique != nil) != keyed {
+				continue
+			}
+			job, err := m.createJob(now, p)
+			if err != nil {
+				return nil, err
+			}
+			jobs[i] = job
+		}
+	}
+
+	return jobs, nil
+}
+
+func (m *MemoryStore) GetJobByID(ctx context.Context, id int64) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("job %d: %w", id, ErrNotFound)
+	}
+	job := *j
+	return &job, nil
+}
+
+// ready reports whether every dependency of the job is resolved.
+func (m *MemoryStore) ready(id int64) bool {
+	return !slices.ContainsFunc(m.deps[id], func(d dependency) bool { return !d.resolved })
+}
+
+func (m *MemoryStore) GetPendingJobs(ctx context.Context, params ClaimParams) ([]Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	queue := params.Queue
+	if queue == "" {
+		queue = DefaultQueue
+	}
+
+	now := m.now()
+	var candidates []*Job
+	for _, j := range m.jobs {
+		if j.Status == JobStatusPending && j.Queue == queue && !j.NextRunAt.After(now) && m.ready(j.ID) {
+			candidates = append(candidates, j)
+		}
+	}
+
+	// every PriorityAging a job has been waiting counts as one extra priority point
+	priority := func(j *Job) int64 {
+		p := int64(j.Priority)
+		if params.PriorityAging > 0 {
+			p += int64(math.Floor(float64(now.Sub(j.NextRunAt)) / float64(params.PriorityAging)))
+		}
+		return p
+	}
+	slices.SortFunc(candidates, func(a, b *Job) int {
+		return cmp.Or(
+			cmp.Compare(priority(b), priority(a)),
+			a.NextRunAt.Compare(b.NextRunAt),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+	candidates = candidates[:min(len(candidates), max(params.Limit, 0))]
+
+	var jobs []Job
+	for _, j := range candidates {
+		leaseExpiresAt := now.Add(params.LeaseDuration)
+		j.Status = JobStatusRunning
+		j.StartedAt = &now
+		j.LockedBy = sql.NullString{String: params.Owner, Valid: true}
+		j.LeaseExpiresAt = &leaseExpiresAt
+		j.LeaseToken++
+		j.Progress = sql.NullInt32{}
+		j.ProgressMessage = sql.NullString{}
+		j.ProgressUpdatedAt = nil
+
+		m.startAttempt(now, j.ID, params.Owner)
+		jobs = append(jobs, *j)
+	}
+
+	return jobs, nil
+}
+
+// holds reports whether lease is the current lease of the running job j.
+func holds(j *Job, lease Lease) bool {
+	return j.Status == JobStatusRunning && j.LockedBy.Valid && j.LockedBy.String == lease.Owner && j.LeaseToken == lease.Token
+}
+
+// UpdateJobStatus records the outcome of an attempt and releases its lease.
+// The update only applies while lease is still the job's current lease;
+// otherwise ErrLeaseLost is returned. A job cancelled while running keeps
+// its cancellation.
+func (m *MemoryStore) UpdateJobStatus(ctx context.Context, status JobStatus, id int64, lease Lease) error {
+	return m.finishJob(status, id, lease, nil)
+}
+
+// CompleteJob marks a job completed like UpdateJobStatus and stores the JSON
+// result its handler produced.
+func (m *MemoryStore) CompleteJob(ctx context.Context, id int64, lease Lease, result json.RawMessage) error {
+	return m.finishJob(JobStatusCompleted, id, lease, result)
+}
+
+func (m *MemoryStore) finishJob(status JobStatus, id int64, lease Lease, result json.RawMessage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok || !holds(j, lease) {
+		if ok && j.Status == JobStatusCancelled {
+			// the job was cancelled while running; keep the cancellation
+			return nil
+		}
+		return fmt.Errorf("job %d: %w", id, ErrLeaseLost)
+	}
+
+	now := m.now()
+	j.Status = status
+	switch status {
+	case JobStatusRunning:
+		j.StartedAt = &now
+	case JobStatusCompleted, JobStatusFailed:
+		j.CompletedAt = &now
+	}
+	j.LockedBy = sql.NullString{}
+	j.LeaseExpiresAt = nil
+	j.Result = slices.Clone(result)
+
+	m.finishAttempt(now, id, AttemptOutcome(status), "")
+
+	switch status {
+	case JobStatusCompleted:
+		var dependants []int64
+		for dependant, deps := range m.deps {
+			for i := range deps {
+				if deps[i].parent == id {
+					deps[i].resolved = true
+					dependants = append(dependants, dependant)
+				}
+			}
+		}
+		m.notifyJobsReady(now, dependants...)
+	case JobStatusFailed:
+		m.skipDependants(now, id, fmt.Sprintf("dependency job %d failed", id))
+	}
+
+	return nil
+}
+
+// HandleJobFailure schedules the next attempt of a failed job according to
+// policy, or dead-letters it once policy.MaxAttempts is exhausted. A zero
+// MaxAttempts allows three attempts. Like UpdateJobStatus, it is fenced on
+// lease.
+func (m *MemoryStore) HandleJobFailure(ctx context.Context, jobId int64, errMsg string, policy RetryPolicy, lease Lease) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[jobId]
+	if !ok {
+		return fmt.Errorf("job %d: %w", jobId, ErrLeaseLost)
+	}
+
+	if j.Status == JobStatusCancelled {
+		logger.Info("Not retrying cancelled job", "job_id", jobId)
+		return nil
+	}
+
+	if !holds(j, lease) {
+		return fmt.Errorf("job %d: %w", jobId, ErrLeaseLost)
+	}
+
+	m.failJob(m.now(), j, errMsg, AttemptFailed, policy)
+	return nil
+}
+
+// failJob ends the open attempt of j with outcome, then schedules its next
+// attempt according to policy or moves it to the DLQ once policy.MaxAttempts
+// (or defaultMaxRetries, if zero) is exhausted.
+func (m *MemoryStore) failJob(now time.Time, j *Job, errMsg string, outcome AttemptOutcome, policy RetryPolicy) {
+	jobId := j.ID
+	m.finishAttempt(now, jobId, outcome, errMsg)
+
+	newRetryCount := j.RetryCount + 1
+	maxRetries := defaultMaxRetries
+	if policy.MaxAttempts > 0 {
+		maxRetries = policy.MaxAttempts
+	}
+
+	if newRetryCount >= maxRetries {
+		m.dead[jobId] = &deadJob{
+			job: Job{
+				ID:           j.ID,
+				Type:         j.Type,
+				Payload:      j.Payload,
+				ErrorMessage: sql.NullString{String: errMsg, Valid: true},
+				RetryCount:   newRetryCount,
+				Queue:        j.Queue,
+				Priority:     j.Priority,
+				ReplayCount:  j.ReplayCount,
+				RetryPolicy:  j.RetryPolicy,
+				TimeoutMs:    j.TimeoutMs,
+				UniqueKey:    j.UniqueKey,
+			},
+			failedAt: now,
+		}
+		delete(m.jobs, jobId)
+
+		// a dead job no longer holds its idempotency key
+		m.releaseKeysOf(jobId)
+
+		m.skipDependants(now, jobId, fmt.Sprintf("dependency job %d was dead-lettered", jobId))
+
+		logger.Info("Job moved to DLQ", "job_id", jobId)
+		return
+	}
+
+	j.Status = JobStatusPending
+	j.RetryCount = newRetryCount
+	j.ErrorMessage = sql.NullString{String: errMsg, Valid: true}
+	j.NextRunAt = now.Add(policy.Delay(newRetryCount))
+	j.UpdatedAt = now
+	j.LockedBy = sql.NullString{}
+	j.LeaseExpiresAt = nil
+}
+
+func (m *MemoryStore) GetArchivedJobs(ctx context.Context, duration time.Duration, limit int) ([]Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := m.now().Add(-duration)
+	var jobs []Job
+	for _, j := range m.jobs {
+		if j.Status == JobStatusCompleted && j.CompletedAt != nil && j.CompletedAt.Before(cutoff) {
+			jobs = append(jobs, Job{
+				ID:          j.ID,
+				Type:        j.Type,
+				Payload:     j.Payload,
+				Status:      j.Status,
+				CreatedAt:   j.CreatedAt,
+				CompletedAt: j.CompletedAt,
+				Result:      j.Result,
+			})
+		}
+	}
+	slices.SortFunc(jobs, func(a, b Job) int { return cmp.Compare(a.ID, b.ID) })
+
+	return jobs[:min(len(jobs), max(limit, 0))], nil
+}
+
+// BatchDeleteJobs deletes jobs together with their attempt history and
+// idempotency keys. The status of a deleted workflow job is kept with the
+// workflow.
+func (m *MemoryStore) BatchDeleteJobs(ctx context.Context, ids []int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted []int64
+	for _, id := range ids {
+		if j, ok := m.jobs[id]; ok {
+			m.recordStepStatus(j)
+			delete(m.jobs, id)
+			delete(m.attempts, id)
+			deleted = append(deleted, id)
+		}
+	}
+	m.releaseKeysOf(deleted...)
+
+	return nil
+}
+
+func (m *MemoryStore) ListJobs(ctx context.Context, filter JobFilter, sort JobSort, page PageParams) (*PaginatedJobs, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var jobs []Job
+	for _, j := range m.jobs {
+		if filter.matches(j) {
+			jobs = append(jobs, *j)
+		}
+	}
+
+	return paginate(jobs, sort, jobSortKey(sort.Field), page)
+}
+
+// matches reports whether j passes the filter; it is the in-memory
+// counterpart of where.
+func (f JobFilter) matches(j *Job) bool {
+	switch {
+	case f.MinPriority != nil && j.Priority < *f.MinPriority,
+		f.Queue != "" && j.Queue != f.Queue,
+		len(f.Statuses) > 0 && !slices.Contains(f.Statuses, j.Status),
+		f.Type != "" && j.Type != f.Type,
+		f.CreatedAfter != nil && j.CreatedAt.Before(*f.CreatedAfter),
+		f.CreatedBefore != nil && !j.CreatedAt.Before(*f.CreatedBefore),
+		f.CompletedAfter != nil && (j.CompletedAt == nil || j.CompletedAt.Before(*f.CompletedAfter)),
+		f.CompletedBefore != nil && (j.CompletedAt == nil || !j.CompletedAt.Before(*f.CompletedBefore)),
+		f.MinRetries != nil && j.RetryCount < *f.MinRetries,
+		f.MaxRetries != nil && j.RetryCount > *f.MaxRetries,
+		f.ErrorContains != "" && !strings.Contains(j.ErrorMessage.String, f.ErrorContains):
+		return false
+	}
+	return true
+}
+
+func (m *MemoryStore) ListDeadJobs(ctx context.Context, filter DeadJobFilter, sort JobSort, page PageParams) (*PaginatedJobs, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var jobs []Job
+	for _, d := range m.dead {
+		if filter.matches(d) {
+			jobs = append(jobs, Job{
+				ID:           d.job.ID,
+				Type:         d.job.Type,
+				Payload:      d.job.Payload,
+				Status:       JobStatusFailed,
+				CreatedAt:    d.failedAt,
+				ErrorMessage: d.job.ErrorMessage,
+				RetryCount:   d.job.RetryCount,
+				Queue:        d.job.Queue,
+				Priority:     d.job.Priority,
+			})
+		}
+	}
+
+	// dead jobs do not keep their creation time, so both time fields sort by failed_at
+	field := sort.Field
+	if field == SortByCompletedAt {
+		field = SortByCreatedAt
+	}
+	return paginate(jobs, sort, jobSortKey(field), page)
+}
+
+// jobSortKey returns the value of field for a job as a number, or false when
+// it is NULL.
+func jobSortKey(field JobSortField) func(Job) (int64, bool) {
+	switch field {
+	case SortByCompletedAt:
+		return func(j Job) (int64, bool) {
+			if j.CompletedAt == nil {
+				return 0, false
+			}
+			return j.CompletedAt.UnixNano(), true
+		}
+	case SortByPriority:
+		return func(j Job) (int64, bool) { return int64(j.Priority), true }
+	case SortByRetryCount:
+		return func(j Job) (int64, bool) { return int64(j.RetryCount), true }
+	default:
+		return func(j Job) (int64, bool) { return j.CreatedAt.UnixNano(), true }
+	}
+}
+
+// paginate is listJobs for jobs filtered in memory: it orders them by
+// sortKey, NULLs last, then by id, and cuts out the requested page. Totals
+// are always exact, since counting is cheap here.
+func paginate(jobs []Job, sort JobSort, sortKey func(Job) (int64, bool), page PageParams) (*PaginatedJobs, error) {
+	if page.PageToken != "" && page.Offset > 0 {
+		return nil, fmt.Errorf("%w: offset cannot be combined with a page token", ErrInvalidPageToken)
+	}
+
+	meta := PaginationMetadata{Limit: page.Limit, TotalCount: page.total()}
+	if meta.TotalCount != TotalNone {
+		meta.TotalRecords = int64(len(jobs))
+		meta.TotalPages = int(math.Ceil(float64(meta.TotalRecords) / float64(page.Limit)))
+	}
+
+	direction := -1
+	if sort.Ascending {
+		direction = 1
+	}
+	slices.SortFunc(jobs, func(a, b Job) int {
+		av, aok := sortKey(a)
+		bv, bok := sortKey(b)
+		if aok != bok {
+			if aok {
+				return -1
+			}
+			return 1
+		}
+		return direction * cmp.Or(cmp.Compare(av, bv), cmp.Compare(a.ID, b.ID))
+	})
+
+	if page.PageToken != "" {
+		cursor, err := decodePageToken(page.PageToken, sort)
+		if err != nil {
+			return nil, err
+		}
+		var cursorKey int64
+		if cursor.Time != nil {
+			cursorKey = cursor.Time.UnixNano()
+		} else {
+			cursorKey = *cursor.Number
+		}
+
+		jobs = slices.DeleteFunc(jobs, func(j Job) bool {
+			v, ok := sortKey(j)
+			return !ok || direction*cmp.Or(cmp.Compare(v, cursorKey), cmp.Compare(j.ID, cursor.ID)) <= 0
+		})
+	} else {
+		meta.CurrentPage = (page.Offset / page.Limit) + 1
+	}
+
+	jobs = jobs[min(len(jobs), page.Offset):]
+	if jobs == nil {
+		jobs = []Job{}
+	}
+
+	result := &PaginatedJobs{Jobs: jobs, Meta: meta}
+	if len(jobs) > page.Limit {
+		result.Jobs = jobs[:page.Limit]
+		if cursor, ok := cursorAfter(result.Jobs[page.Limit-1], sort); ok {
+			result.NextPageToken = cursor.encode()
+		}
+	}
+
+	return result, nil
+}
+
+func (m *MemoryStore) GetStats(ctx context.Context) (*JobStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := &JobStats{Dead: int64(len(m.dead))}
+	for _, j := range m.jobs {
+		switch j.Status {
+		case JobStatusPending:
+			stats.Pending++
+		case JobStatusRunning:
+			stats.Running++
+		case JobStatusCompleted:
+			stats.Completed++
+		case JobStatusFailed:
+			stats.Failed++
+		case JobStatusCancelled:
+			stats.Cancelled++
+		case JobStatusSkipped:
+			stats.Skipped++
+		}
+	}
+
+	return stats, nil
+}
+
+// UpdateJobProgress records the progress reported by the handler of a running
+// job. Like UpdateJobStatus it is fenced on lease and returns ErrLeaseLost when
+// the lease is no longer held.
+func (m *MemoryStore) UpdateJobProgress(ctx context.Context, id int64, lease Lease, percent int, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok || !holds(j, lease) {
+		return fmt.Errorf("job %d: %w", id, ErrLeaseLost)
+	}
+
+	now := m.now()
+	j.Progress = sql.NullInt32{Int32: int32(percent), Valid: true}
+	j.ProgressMessage = sql.NullString{String: message, Valid: message != ""}
+	j.ProgressUpdatedAt = &now
+
+	return nil
+}
+
+// ExtendLeases heartbeats the leases of running jobs, pushing their expiry
+// duration into the future. It returns the ids whose lease is no longer held.
+func (m *MemoryStore) ExtendLeases(ctx context.Context, leases map[int64]Lease, duration time.Duration) ([]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := m.now().Add(duration)
+	var lost []int64
+	for id, lease := range leases {
+		j, ok := m.jobs[id]
+		if !ok || !j.LockedBy.Valid || j.LockedBy.String != lease.Owner || j.LeaseToken != lease.Token {
+			lost = append(lost, id)
+			continue
+		}
+		j.LeaseExpiresAt = &expiresAt
+	}
+
+	return lost, nil
+}
+
+// ReclaimExpiredJobs takes back running jobs whose lease expired. Each one
+// counts as a failed attempt, closed as lease_expired, and is retried or
+// dead-lettered under policy(job).
+func (m *MemoryStore) ReclaimExpiredJobs(ctx context.Context, policy func(Job) RetryPolicy) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	const reason = "job lease expired (worker lost)"
+
+	now := m.now()
+	var reclaimed int64
+	for _, j := range m.jobs {
+		if j.Status != JobStatusRunning || (j.LeaseExpiresAt != nil && !j.LeaseExpiresAt.Before(now)) {
+			continue
+		}
+		m.failJob(now, j, reason, AttemptLeaseExpired, policy(*j))
+		reclaimed++
+	}
+
+	return reclaimed, nil
+}
+
+// CancelJob marks a pending or running job as cancelled. Pending jobs are never
+// claimed afterwards; workers running the job notice the status through
+// GetCancelledJobIDs.
+func (m *MemoryStore) CancelJob(ctx context.Context, id int64) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("job %d: %w", id, ErrNotFound)
+	}
+
+	current := j.Status
+	if current != JobStatusPending && current != JobStatusRunning {
+		return nil, fmt.Errorf("job %d is %s: %w", id, current, ErrJobFinished)
+	}
+
+	now := m.now()
+	j.Status = JobStatusCancelled
+	j.CompletedAt = &now
+	j.UpdatedAt = now
+
+	if current == JobStatusRunning {
+		m.finishAttempt(now, id, AttemptCancelled, "")
+	}
+
+	m.skipDependants(now, id, fmt.Sprintf("dependency job %d was cancelled", id))
+
+	job := *j
+	return &job, nil
+}
+
+// GetCancelledJobIDs returns the subset of ids whose jobs have been cancelled.
+func (m *MemoryStore) GetCancelledJobIDs(ctx context.Context, ids []int64) ([]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var cancelled []int64
+	for _, id := range ids {
+		if j, ok := m.jobs[id]; ok && j.Status == JobStatusCancelled {
+			cancelled = append(cancelled, id)
+		}
+	}
+	return cancelled, nil
+}
+
+// startAttempt opens the next attempt of a job claimed by worker.
+func (m *MemoryStore) startAttempt(now time.Time, jobID int64, worker string) {
+	m.attempts[jobID] = append(m.attempts[jobID], JobAttempt{
+		JobID:     jobID,
+		Attempt:   len(m.attempts[jobID]) + 1,
+		Worker:    worker,
+		StartedAt: now,
+		Outcome:   AttemptRunning,
+	})
+}
+
+// finishAttempt closes the open attempt of a job. An empty errMsg leaves the
+// error NULL.
+func (m *MemoryStore) finishAttempt(now time.Time, jobID int64, outcome AttemptOutcome, errMsg string) {
+	attempts := m.attempts[jobID]
+	for i := range attempts {
+		if attempts[i].FinishedAt == nil {
+			attempts[i].FinishedAt = &now
+			attempts[i].Outcome = outcome
+			attempts[i].Error = sql.NullString{String: errMsg, Valid: errMsg != ""}
+		}
+	}
+}
+
+// ListJobAttempts returns every attempt of a job, oldest first. It works for
+// pending, running, finished and dead-lettered jobs alike.
+func (m *MemoryStore) ListJobAttempts(ctx context.Context, jobID int64) ([]JobAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.attempts[jobID]) == 0 {
+		_, live := m.jobs[jobID]
+		_, dead := m.dead[jobID]
+		if !live && !dead {
+			return nil, fmt.Errorf("job %d: %w", jobID, ErrNotFound)
+		}
+	}
+	return slices.Clone(m.attempts[jobID]), nil
+}
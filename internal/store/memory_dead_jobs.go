@@ -0,0 +1,134 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+)
+
+// RetryDeadJob moves a dead-lettered job back into jobs under its original id
+// with a reset retry count. A non-nil payload replaces the stored one, and the
+// unique key is then recomputed with the spec unique returns for the job's
+// type; a job blocking the new key fails the replay with ErrAlreadyExists.
+func (m *MemoryStore) RetryDeadJob(ctx context.Context, id int64, payload *string, unique func(jobType string) *UniqueSpec) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d, ok := m.dead[id]
+	if !ok {
+		return nil, fmt.Errorf("dead job %d: %w", id, ErrNotFound)
+	}
+	if payload != nil && !json.Valid([]byte(*payload)) {
+		return nil, fmt.Errorf("requeue dead job: payload is not valid JSON")
+	}
+
+	now := m.now()
+	if payload != nil {
+		var uniqueKey sql.NullString
+		if spec := uniqueSpec(unique, d.job.Type); spec != nil {
+			key, err := CreateJobParams{Type: d.job.Type, Payload: *payload, Unique: spec}.UniqueKey()
+			if err != nil {
+				return nil, fmt.Errorf("requeue dead job: %w", err)
+			}
+			if existing := m.uniqueDuplicate(now, key, spec); existing != nil {
+				return nil, fmt.Errorf("dead job %d: duplicate of job %d: %w", id, existing.ID, ErrAlreadyExists)
+			}
+			uniqueKey = sql.NullString{String: key, Valid: true}
+		}
+		d.job.UniqueKey = uniqueKey
+	}
+
+	job := m.requeueDead(now, d, payload)
+
+	m.restoreDependants(now, []int64{id})
+	m.notifyJobsReady(now, id)
+
+	logger.Info("Dead job requeued", "job_id", id, "replay_count", job.ReplayCount)
+
+	return &job, nil
+}
+
+// RetryDeadJobs requeues every dead-lettered job matching filter and returns
+// their ids.
+func (m *MemoryStore) RetryDeadJobs(ctx context.Context, filter DeadJobFilter) ([]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var matched []*deadJob
+	for _, d := range m.dead {
+		if filter.matches(d) {
+			matched = append(matched, d)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *deadJob) int { return cmp.Compare(a.job.ID, b.job.ID) })
+
+	now := m.now()
+	var ids []int64
+	for _, d := range matched {
+		job := m.requeueDead(now, d, nil)
+		ids = append(ids, job.ID)
+	}
+
+	m.restoreDependants(now, ids)
+	m.notifyJobsReady(now, ids...)
+
+	if len(ids) > 0 {
+		logger.Info("Dead jobs requeued", "count", len(ids))
+	}
+
+	return ids, nil
+}
+
+// requeueDead moves d back into jobs as a fresh pending job.
+func (m *MemoryStore) requeueDead(now time.Time, d *deadJob, payload *string) Job {
+	job := &Job{
+		ID:           d.job.ID,
+		Type:         d.job.Type,
+		Payload:      d.job.Payload,
+		Status:       JobStatusPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		ErrorMessage: d.job.ErrorMessage,
+		NextRunAt:    now,
+		Priority:     d.job.Priority,
+		Queue:        d.job.Queue,
+		ReplayCount:  d.job.ReplayCount + 1,
+		ReplayedAt:   &now,
+		RetryPolicy:  d.job.RetryPolicy,
+		TimeoutMs:    d.job.TimeoutMs,
+		UniqueKey:    d.job.UniqueKey,
+	}
+	if payload != nil {
+		job.Payload = *payload
+	}
+
+	delete(m.dead, d.job.ID)
+	m.jobs[job.ID] = job
+	return *job
+}
+
+// matches reports whether d passes the filter; it is the in-memory
+// counterpart of where.
+func (f DeadJobFilter) matches(d *deadJob) bool {
+	j := d.job
+	switch {
+	case len(f.IDs) > 0 && !slices.Contains(f.IDs, j.ID),
+		f.Type != "" && j.Type != f.Type,
+		f.ErrorContains != "" && !strings.Contains(j.ErrorMessage.String, f.ErrorContains),
+		f.FailedAfter != nil && d.failedAt.Before(*f.FailedAfter),
+		f.FailedBefore != nil && !d.failedAt.Before(*f.FailedBefore),
+		f.MinPriority != nil && j.Priority < *f.MinPriority,
+		f.Queue != "" && j.Queue != f.Queue,
+		f.MinRetries != nil && j.RetryCount < *f.MinRetries,
+		f.MaxRetries != nil && j.RetryCount > *f.MaxRetries:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (m *MemoryStore) CreateSchedule(ctx context.Context, params CreateScheduleParams) (*Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, sc := range m.schedules {
+		if sc.Name == params.Name {
+			return nil, fmt.Errorf("schedule %q: %w", params.Name, ErrAlreadyExists)
+		}
+	}
+
+	timezone := params.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	catchup := params.CatchupPolicy
+	if catchup == "" {
+		catchup = CatchupSkip
+	}
+
+	now := m.now()
+	m.nextScheduleID++
+	sc := &Schedule{
+		ID:              m.nextScheduleID,
+		Name:            params.Name,
+		CronExpr:        params.CronExpr,
+		Timezone:        timezone,
+		JobType:         params.JobType,
+		PayloadTemplate: params.PayloadTemplate,
+		CatchupPolicy:   catchup,
+		NextRunAt:       params.NextRunAt.UTC().Truncate(time.Microsecond),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	m.schedules[sc.ID] = sc
+
+	out := *sc
+	return &out, nil
+}
+
+func (m *MemoryStore) GetSchedule(ctx context.Context, id int64) (*Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sc, ok := m.schedules[id]
+	if !ok {
+		return nil, fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+	}
+	out := *sc
+	return &out, nil
+}
+
+func (m *MemoryStore) ListSchedules(ctx context.Context) ([]Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	schedules := []Schedule{}
+	for _, sc := range m.schedules {
+		schedules = append(schedules, *sc)
+	}
+	slices.SortFunc(schedules, func(a, b Schedule) int { return strings.Compare(a.Name, b.Name) })
+
+	return schedules, nil
+}
+
+func (m *MemoryStore) SetSchedulePaused(ctx context.Context, id int64, paused bool, nextRunAt time.Time) (*Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sc, ok := m.schedules[id]
+	if !ok {
+		return nil, fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+	}
+	sc.Paused = paused
+	sc.NextRunAt = nextRunAt.UTC().Truncate(time.Microsecond)
+	sc.UpdatedAt = m.now()
+
+	out := *sc
+	return &out, nil
+}
+
+func (m *MemoryStore) DeleteSchedule(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.schedules[id]; !ok {
+		return fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+	}
+	delete(m.schedules, id)
+	return nil
+}
+
+func (m *MemoryStore) GetDueSchedules(ctx context.Context, now time.Time, limit int) ([]Schedule, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var schedules []Schedule
+	for _, sc := range m.schedules {
+		if !sc.Paused && !sc.NextRunAt.After(now) {
+			schedules = append(schedules, *sc)
+		}
+	}
+	slices.SortFunc(schedules, func(a, b Schedule) int { return a.NextRunAt.Compare(b.NextRunAt) })
+
+	return schedules[:min(len(schedules), max(limit, 0))], nil
+}
+
+// FireSchedule advances the schedule to nextRunAt and enqueues runs
+// atomically. Like the Postgres store it only does so while the schedule still
+// has the next_run_at that was read, so when several schedulers race on the
+// same tick only one of them enqueues jobs; the others get false.
+func (m *MemoryStore) FireSchedule(ctx context.Context, schedule Schedule, runs []CreateJobParams, nextRunAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sc, ok := m.schedules[schedule.ID]
+	if !ok || sc.Paused || !sc.NextRunAt.Equal(schedule.NextRunAt.UTC().Truncate(time.Microsecond)) {
+		return false, nil
+	}
+
+	for _, run := range runs {
+		if !json.Valid([]byte(run.Payload)) {
+			return false, fmt.Errorf("insert job: payload is not valid JSON")
+		}
+	}
+
+	now := m.now()
+	sc.NextRunAt = nextRunAt.UTC().Truncate(time.Microsecond)
+	if len(runs) > 0 {
+		sc.LastRunAt = &now
+	}
+	sc.UpdatedAt = now
+
+	for _, run := range runs {
+		if _, err := m.createJob(now, run); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
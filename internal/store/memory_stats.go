@@ -0,0 +1,38 @@
+package store
+
+import "context"
+
+// GetJobTypeStats reports, per job type, how many jobs finished in the last
+// params.Window and how long they waited and ran, overall and per bucket.
+// Types without activity in the window are left out.
+func (m *MemoryStore) GetJobTypeStats(ctx context.Context, params TypeStatsParams) ([]JobTypeStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	end := m.now()
+	start := end.Add(-params.Window)
+
+	var samples []typeStatsSample
+	for _, j := range m.jobs {
+		if (j.Status == JobStatusCompleted || j.Status == JobStatusFailed) && j.CompletedAt != nil {
+			s := typeStatsSample{jobType: j.Type, at: *j.CompletedAt, kind: sampleFailed}
+			if j.Status == JobStatusCompleted {
+				s.kind = sampleCompleted
+			}
+			if j.StartedAt != nil {
+				d := j.CompletedAt.Sub(*j.StartedAt)
+				s.value = &d
+			}
+			samples = append(samples, s)
+		}
+		if j.StartedAt != nil {
+			wait := max(j.StartedAt.Sub(j.NextRunAt), 0)
+			samples = append(samples, typeStatsSample{jobType: j.Type, at: *j.StartedAt, kind: sampleStarted, value: &wait})
+		}
+	}
+	for _, d := range m.dead {
+		samples = append(samples, typeStatsSample{jobType: d.job.Type, at: d.failedAt, kind: sampleFailed})
+	}
+
+	return aggregateTypeStats(params, start, end, samples), nil
+}
@@ -0,0 +1,14 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+)
+
+func TestMemoryStore_Conformance(t *testing.T) {
+	logger.Init()
+	testStorerConformance(t, func(t *testing.T) Storer {
+		return NewMemoryStore()
+	})
+}
@@ -0,0 +1,224 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+)
+
+// memoryWorkflow is a row of workflows with its rows of workflow_jobs.
+type memoryWorkflow struct {
+	name      string
+	createdAt time.Time
+	steps     []workflowJob
+}
+
+// status is the final status of the job once it has been archived.
+type workflowJob struct {
+	key    string
+	jobID  int64
+	status JobStatus
+}
+
+// CreateWorkflow stores the workflow, its jobs and their dependency edges
+// under one lock, so no job of the workflow can be claimed before the whole
+// graph is stored. The graph is validated before anything is written. A
+// unique step with a duplicate fails the workflow with ErrAlreadyExists.
+func (m *MemoryStore) CreateWorkflow(ctx context.Context, params CreateWorkflowParams) (*Workflow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	declared := make(map[string]bool, len(params.Steps))
+	uniqueSteps := make(map[string]string)
+	for _, step := range params.Steps {
+		if declared[step.Key] {
+			return nil, fmt.Errorf("duplicate workflow step %q", step.Key)
+		}
+		for i, dep := range step.DependsOn {
+			if !declared[dep] {
+				return nil, fmt.Errorf("step %q depends on %q, which is not declared before it", step.Key, dep)
+			}
+			if slices.Contains(step.DependsOn[:i], dep) {
+				return nil, fmt.Errorf("insert dependency: step %q depends on %q twice", step.Key, dep)
+			}
+		}
+		if !json.Valid([]byte(step.Job.Payload)) {
+			return nil, fmt.Errorf("create step %q: insert job: payload is not valid JSON", step.Key)
+		}
+		if step.Job.Unique != nil {
+			key, err := step.Job.UniqueKey()
+			if err != nil {
+				return nil, fmt.Errorf("create step %q: insert job: %w", step.Key, err)
+			}
+			if existing := m.uniqueDuplicate(now, key, step.Job.Unique); existing != nil {
+				return nil, fmt.Errorf("create step %q: duplicate of job %d: %w", step.Key, existing.ID, ErrAlreadyExists)
+			}
+			// an earlier step of the workflow is pending by the time this one is created
+			if other, ok := uniqueSteps[key]; ok && step.Job.Unique.Blocks(JobStatusPending, now, now) {
+				return nil, fmt.Errorf("create step %q: duplicate of step %q: %w", step.Key, other, ErrAlreadyExists)
+			}
+			uniqueSteps[key] = step.Key
+		}
+		declared[step.Key] = true
+	}
+
+	m.nextWorkflowID++
+	wf := &Workflow{ID: m.nextWorkflowID, Name: params.Name, CreatedAt: now}
+	stored := &memoryWorkflow{name: params.Name, createdAt: now}
+
+	ids := make(map[string]int64, len(params.Steps))
+	for _, step := range params.Steps {
+		job, err := m.createJob(now, step.Job)
+		if err != nil {
+			return nil, fmt.Errorf("create step %q: %w", step.Key, err)
+		}
+		ids[step.Key] = job.ID
+		for _, dep := range step.DependsOn {
+			m.deps[job.ID] = append(m.deps[job.ID], dependency{parent: ids[dep]})
+		}
+		stored.steps = append(stored.steps, workflowJob{key: step.Key, jobID: job.ID})
+
+		wf.Steps = append(wf.Steps, WorkflowStep{
+			Key:       step.Key,
+			JobID:     job.ID,
+			Type:      job.Type,
+			Status:    job.Status,
+			DependsOn: step.DependsOn,
+		})
+	}
+	m.workflows[wf.ID] = stored
+
+	return wf, nil
+}
+
+// GetWorkflow returns the workflow with the current status of each job. A job
+// that is no longer stored was either dead-lettered or archived, in which case
+// its final status was recorded with the workflow.
+func (m *MemoryStore) GetWorkflow(ctx context.Context, id int64) (*Workflow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.workflows[id]
+	if !ok {
+		return nil, fmt.Errorf("workflow %d: %w", id, ErrNotFound)
+	}
+
+	wf := &Workflow{ID: id, Name: stored.name, CreatedAt: stored.createdAt}
+	keys := make(map[int64]string, len(stored.steps))
+	for _, s := range stored.steps {
+		keys[s.jobID] = s.key
+	}
+
+	for _, s := range stored.steps {
+		step := WorkflowStep{Key: s.key, JobID: s.jobID, Status: s.status}
+		if j, ok := m.jobs[s.jobID]; ok {
+			step.Type, step.Status, step.ErrorMessage = j.Type, j.Status, j.ErrorMessage
+		} else if d, ok := m.dead[s.jobID]; ok {
+			step.Type, step.Status, step.ErrorMessage = d.job.Type, JobStatusDead, d.job.ErrorMessage
+		}
+
+		deps := slices.Clone(m.deps[s.jobID])
+		slices.SortFunc(deps, func(a, b dependency) int { return cmp.Compare(a.parent, b.parent) })
+		for _, dep := range deps {
+			step.DependsOn = append(step.DependsOn, keys[dep.parent])
+		}
+		wf.Steps = append(wf.Steps, step)
+	}
+	slices.SortFunc(wf.Steps, func(a, b WorkflowStep) int { return cmp.Compare(a.JobID, b.JobID) })
+
+	return wf, nil
+}
+
+// recordStepStatus keeps the status of a workflow job that is about to be
+// deleted, like the status column of workflow_jobs.
+func (m *MemoryStore) recordStepStatus(j *Job) {
+	for _, wf := range m.workflows {
+		for i := range wf.steps {
+			if wf.steps[i].jobID == j.ID {
+				wf.steps[i].status = j.Status
+				return
+			}
+		}
+	}
+}
+
+// skipDependants marks every pending job that transitively depends on jobID as
+// skipped, recording reason as its error.
+func (m *MemoryStore) skipDependants(now time.Time, jobID int64, reason string) {
+	dependants := map[int64]bool{}
+	parents := []int64{jobID}
+	for len(parents) > 0 {
+		var next []int64
+		for id, deps := range m.deps {
+			if dependants[id] || !slices.ContainsFunc(deps, func(d dependency) bool { return slices.Contains(parents, d.parent) }) {
+				continue
+			}
+			dependants[id] = true
+			next = append(next, id)
+		}
+		parents = next
+	}
+
+	var skipped int
+	for id := range dependants {
+		j, ok := m.jobs[id]
+		if !ok || j.Status != JobStatusPending {
+			continue
+		}
+		j.Status = JobStatusSkipped
+		j.ErrorMessage = sql.NullString{String: reason, Valid: true}
+		j.CompletedAt = &now
+		j.UpdatedAt = now
+		skipped++
+	}
+
+	if skipped > 0 {
+		logger.Info("Skipped dependant jobs", "job_id", jobID, "count", skipped)
+	}
+}
+
+// restoreDependants returns the jobs skipped because of the requeued jobs ids to
+// pending, level by level, as long as none of their other dependencies is
+// dead, failed, cancelled or still skipped.
+func (m *MemoryStore) restoreDependants(now time.Time, ids []int64) {
+	blocked := func(d dependency) bool {
+		if d.resolved {
+			return false
+		}
+		p, ok := m.jobs[d.parent]
+		return !ok || p.Status == JobStatusSkipped || p.Status == JobStatusFailed || p.Status == JobStatusCancelled
+	}
+
+	for len(ids) > 0 {
+		var restored []int64
+		for id, deps := range m.deps {
+			j, ok := m.jobs[id]
+			if !ok || j.Status != JobStatusSkipped {
+				continue
+			}
+			if !slices.ContainsFunc(deps, func(d dependency) bool { return slices.Contains(ids, d.parent) }) {
+				continue
+			}
+			if slices.ContainsFunc(deps, blocked) {
+				continue
+			}
+			restored = append(restored, id)
+		}
+
+		for _, id := range restored {
+			j := m.jobs[id]
+			j.Status = JobStatusPending
+			j.ErrorMessage = sql.NullString{}
+			j.CompletedAt = nil
+			j.UpdatedAt = now
+		}
+		ids = restored
+	}
+}
@@ -0,0 +1,294 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/migrations"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationLockID is the advisory lock that serializes migrations across
+// replicas starting at the same time.
+const migrationLockID int64 = 4_211_993_170
+
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState is a migration and, once applied, when it was applied.
+type MigrationState struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// loadMigrations reads the <version>_<name>.up.sql and .down.sql pairs in
+// fsys, sorted by version.
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		m := migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		sql, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(sql)
+		} else {
+			mig.Down = string(sql)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", mig.Version, mig.Name)
+		}
+		list = append(list, *mig)
+	}
+	slices.SortFunc(list, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+	return list, nil
+}
+
+// baselineColumns are the tables and columns created by migration 1, the
+// original schema.sql. A database that predates schema_migrations is only
+// baselined at version 1 if it has all of them.
+var baselineColumns = map[string][]string{
+	"jobs": {
+		"id", "type", "payload", "status", "created_at", "updated_at", "started_at", "completed_at",
+		"retry_count", "max_retries", "last_err", "next_run_at",
+	},
+	"dead_jobs": {"id", "type", "payload", "last_err", "failed_at", "retry_count"},
+}
+
+// missingBaselineColumns returns the table.column names of baselineColumns
+// that are not in live, sorted.
+func missingBaselineColumns(live map[string][]string) []string {
+	var missing []string
+	for table, columns := range baselineColumns {
+		for _, column := range columns {
+			if !slices.Contains(live[table], column) {
+				missing = append(missing, table+"."+column)
+			}
+		}
+	}
+	slices.Sort(missing)
+	return missing
+}
+
+// Migrate applies every pending migration in order, each in its own
+// transaction, and returns the ones it applied. Replicas migrating at the same
+// time wait for each other on an advisory lock.
+//
+// A database created by hand from schema.sql before migrations existed
+// already has the jobs table but no schema_migrations. It is baselined at
+// version 1, which is that schema, and upgraded in place by the later
+// migrations. Migrate refuses to touch a jobs table that lacks a column of
+// migration 1, since the later migrations would break on it.
+func (s *Store) Migrate(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := s.withMigrationLock(ctx, func(conn *pgxpool.Conn, all []Migration, done map[int64]time.Time) error {
+		if len(done) == 0 && len(all) > 0 {
+			var exists bool
+			if err := conn.QueryRow(ctx, `SELECT to_regclass('jobs') IS NOT NULL`).Scan(&exists); err != nil {
+				return fmt.Errorf("check for existing schema: %w", err)
+			}
+			if exists {
+				if err := checkBaseline(ctx, conn); err != nil {
+					return err
+				}
+				if _, err := conn.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, all[0].Version, all[0].Name); err != nil {
+					return fmt.Errorf("baseline existing schema: %w", err)
+				}
+				logger.Info("Existing schema baselined", "version", all[0].Version)
+				done[all[0].Version] = time.Now()
+			}
+		}
+
+		for _, mig := range all {
+			if _, ok := done[mig.Version]; ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Up); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			logger.Info("Migration applied", "version", mig.Version, "name", mig.Name)
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// checkBaseline fails unless the live schema has every table and column of
+// migration 1.
+func checkBaseline(ctx context.Context, conn *pgxpool.Conn) error {
+	tables := make([]string, 0, len(baselineColumns))
+	for table := range baselineColumns {
+		tables = append(tables, table)
+	}
+
+	rows, err := conn.Query(ctx, `
+		SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ANY($1)
+	`, tables)
+	if err != nil {
+		return fmt.Errorf("read existing schema: %w", err)
+	}
+	defer rows.Close()
+
+	live := map[string][]string{}
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return fmt.Errorf("scan existing schema: %w", err)
+		}
+		live[table] = append(live[table], column)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("read existing schema: %w", err)
+	}
+
+	if missing := missingBaselineColumns(live); len(missing) > 0 {
+		return fmt.Errorf("existing schema predates migrations and does not match migration 1 (missing %s); "+
+			"bring it up to migrations/postgres/0001_initial_schema.up.sql by hand or start from an empty database",
+			strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// MigrateDown reverts the last steps applied migrations, newest first, and
+// returns the ones it reverted.
+func (s *Store) MigrateDown(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := s.withMigrationLock(ctx, func(conn *pgxpool.Conn, all []Migration, done map[int64]time.Time) error {
+		for i := len(all) - 1; i >= 0 && len(reverted) < steps; i-- {
+			mig := all[i]
+			if _, ok := done[mig.Version]; !ok {
+				continue
+			}
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, mig.Down); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("revert migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+			logger.Info("Migration reverted", "version", mig.Version, "name", mig.Name)
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// MigrationStatus lists every known migration and when it was applied.
+func (s *Store) MigrationStatus(ctx context.Context) ([]MigrationState, error) {
+	var states []MigrationState
+	err := s.withMigrationLock(ctx, func(conn *pgxpool.Conn, all []Migration, done map[int64]time.Time) error {
+		for _, mig := range all {
+			state := MigrationState{Migration: mig}
+			if at, ok := done[mig.Version]; ok {
+				state.AppliedAt = &at
+			}
+			states = append(states, state)
+		}
+		return nil
+	})
+	return states, err
+}
+
+// withMigrationLock runs fn on a dedicated connection holding the migration
+// lock, with the embedded migrations and the versions already applied.
+func (s *Store) withMigrationLock(ctx context.Context, fn func(conn *pgxpool.Conn, all []Migration, done map[int64]time.Time) error) error {
+	all, err := loadMigrations(migrations.Postgres)
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	_, err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("read schema_migrations: %w", err)
+	}
+	done := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		done[version] = at
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	return fn(conn, all, done)
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bhanuprakaash/job-scheduler/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_add_index.up.sql":      {Data: []byte("CREATE INDEX i ON t (c);")},
+		"0010_add_index.down.sql":    {Data: []byte("DROP INDEX i;")},
+		"0002_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c INT);")},
+		"0002_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"README.md":                  {Data: []byte("ignored")},
+		"migrations.go":              {Data: []byte("ignored")},
+	}
+
+	// 1. Pairs are matched by version and sorted numerically
+	list, err := loadMigrations(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Version != 2 || list[1].Version != 10 {
+		t.Fatalf("Expected versions 2 and 10, got %+v", list)
+	}
+	if list[0].Name != "create_table" || list[0].Down != "DROP TABLE t;" {
+		t.Errorf("Expected migration 2 to be create_table, got %+v", list[0])
+	}
+
+	// 2. A migration without a down file is rejected
+	delete(fsys, "0010_add_index.down.sql")
+	if _, err := loadMigrations(fsys); err == nil || !strings.Contains(err.Error(), "10_add_index") {
+		t.Errorf("Expected an error for the missing down file, got %v", err)
+	}
+
+	// 3. The embedded migrations of both backends are well-formed
+	if _, err := loadMigrations(migrations.Postgres); err != nil {
+		t.Errorf("Expected the embedded Postgres migrations to load, got %v", err)
+	}
+	if _, err := loadMigrations(migrations.SQLite); err != nil {
+		t.Errorf("Expected the embedded SQLite migrations to load, got %v", err)
+	}
+}
+
+func TestMissingBaselineColumns(t *testing.T) {
+	// 1. A schema with every table and column of migration 1 can be baselined
+	live := map[string][]string{}
+	for table, columns := range baselineColumns {
+		live[table] = append([]string{"extra"}, columns...)
+	}
+	if missing := missingBaselineColumns(live); len(missing) != 0 {
+		t.Errorf("Expected nothing missing, got %v", missing)
+	}
+
+	// 2. The original schema.sql is migration 1, so it is baselined as is
+	live = map[string][]string{
+		"jobs":      {"id", "type", "payload", "status", "created_at", "updated_at", "started_at", "completed_at", "retry_count", "max_retries", "last_err", "next_run_at"},
+		"dead_jobs": {"id", "type", "payload", "last_err", "failed_at", "retry_count"},
+	}
+	if missing := missingBaselineColumns(live); len(missing) != 0 {
+		t.Errorf("Expected the original schema to be complete, got %v missing", missing)
+	}
+
+	// 3. A jobs table without the retry columns or dead_jobs is refused
+	live = map[string][]string{
+		"jobs": {"id", "type", "payload", "status", "created_at", "updated_at", "started_at", "completed_at"},
+	}
+	missing := missingBaselineColumns(live)
+	for _, want := range []string{"jobs.retry_count", "jobs.next_run_at", "dead_jobs.id"} {
+		if !slices.Contains(missing, want) {
+			t.Errorf("Expected %s to be missing, got %v", want, missing)
+		}
+	}
+}
@@ -2,46 +2,349 @@ package store
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/jobdb"
+)
+
+// The job model lives in jobdb, which the enqueue client shares.
+type (
+	JobStatus       = jobdb.JobStatus
+	Job             = jobdb.Job
+	CreateJobParams = jobdb.CreateJobParams
+	Lease           = jobdb.Lease
+	RetryPolicy     = jobdb.RetryPolicy
+	BackoffStrategy = jobdb.BackoffStrategy
+	UniqueSpec      = jobdb.UniqueSpec
 )
 
-type JobStatus string
+const (
+	JobStatusPending   = jobdb.JobStatusPending
+	JobStatusRunning   = jobdb.JobStatusRunning
+	JobStatusCompleted = jobdb.JobStatusCompleted
+	JobStatusFailed    = jobdb.JobStatusFailed
+	JobStatusCancelled = jobdb.JobStatusCancelled
+	JobStatusSkipped   = jobdb.JobStatusSkipped
+	JobStatusDead      = jobdb.JobStatusDead
+
+	DefaultQueue = jobdb.DefaultQueue
+
+	BackoffExponential = jobdb.BackoffExponential
+	BackoffLinear      = jobdb.BackoffLinear
+	BackoffFixed       = jobdb.BackoffFixed
+)
+
+var DefaultRetryPolicy = jobdb.DefaultRetryPolicy
+
+type AttemptOutcome string
+
+const (
+	// AttemptRunning marks the attempt a worker is currently running.
+	AttemptRunning   AttemptOutcome = "running"
+	AttemptCompleted AttemptOutcome = "completed"
+	AttemptFailed    AttemptOutcome = "failed"
+	AttemptCancelled AttemptOutcome = "cancelled"
+	// AttemptLeaseExpired marks an attempt whose worker stopped heartbeating;
+	// the job was reclaimed and its result, if any, was discarded.
+	AttemptLeaseExpired AttemptOutcome = "lease_expired"
+)
+
+// JobAttempt records one claim of a job by a worker. Attempts are kept by job
+// id, so the history of a dead-lettered job stays available under the same id.
+type JobAttempt struct {
+	JobID      int64          `db:"job_id"`
+	Attempt    int            `db:"attempt"`
+	Worker     string         `db:"worker"`
+	StartedAt  time.Time      `db:"started_at"`
+	FinishedAt *time.Time     `db:"finished_at"`
+	Outcome    AttemptOutcome `db:"outcome"`
+	Error      sql.NullString `db:"error"`
+}
+
+// Duration returns how long the attempt ran, or 0 while it is still running.
+func (a JobAttempt) Duration() time.Duration {
+	if a.FinishedAt == nil {
+		return 0
+	}
+	return a.FinishedAt.Sub(a.StartedAt)
+}
+
+// ClaimParams controls which pending jobs GetPendingJobs claims.
+// Only jobs in Queue are considered, and jobs with a higher priority are
+// claimed first. When PriorityAging is set, a job gains one priority point for
+// every PriorityAging it has been waiting, so low-priority jobs are not starved forever.
+// Claimed jobs are leased to Owner for LeaseDuration.
+type ClaimParams struct {
+	Queue         string
+	Limit         int
+	PriorityAging time.Duration
+	Owner         string
+	LeaseDuration time.Duration
+}
+
+// JobFilter narrows down ListJobs. Zero-valued fields are not filtered on;
+// time bounds are inclusive below and exclusive above.
+type JobFilter struct {
+	MinPriority *int
+	Queue       string
+	Statuses    []JobStatus
+	Type        string
+
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	CompletedAfter  *time.Time
+	CompletedBefore *time.Time
+
+	MinRetries *int
+	MaxRetries *int
+	// ErrorContains matches jobs whose last error contains this text.
+	ErrorContains string
+}
+
+// DeadJobFilter selects dead-lettered jobs. Zero-valued fields are not filtered on.
+type DeadJobFilter struct {
+	IDs           []int64
+	Type          string
+	ErrorContains string
+	FailedAfter   *time.Time
+	FailedBefore  *time.Time
+
+	MinPriority *int
+	Queue       string
+	MinRetries  *int
+	MaxRetries  *int
+}
+
+// JobSortField is a column ListJobs and ListDeadJobs can order by.
+type JobSortField string
 
 const (
-	JobStatusPending   JobStatus = "pending"
-	JobStatusRunning   JobStatus = "running"
-	JobStatusCompleted JobStatus = "completed"
-	JobStatusFailed    JobStatus = "failed"
+	SortByCreatedAt JobSortField = "created_at"
+	// SortByCompletedAt orders dead jobs by the time they were dead-lettered.
+	SortByCompletedAt JobSortField = "completed_at"
+	SortByPriority    JobSortField = "priority"
+	SortByRetryCount  JobSortField = "retry_count"
 )
 
-type Job struct {
-	ID           int64          `db:"id"`
-	Type         string         `db:"type"`
-	Payload      string         `db:"payload"`
-	Status       JobStatus      `db:"status"`
-	CreatedAt    time.Time      `db:"created_at"`
-	UpdatedAt    time.Time      `db:"updated_at"`
-	StartedAt    *time.Time     `db:"started_at"`
-	CompletedAt  *time.Time     `db:"completed_at"`
-	ErrorMessage sql.NullString `db:"last_err"`
-	RetryCount   int            `db:"retry_count"`
+// JobSort orders a job listing. The zero value sorts by creation time, newest
+// first; ties are broken by id in the same direction.
+type JobSort struct {
+	Field     JobSortField
+	Ascending bool
 }
 
+// Valid reports whether the sort field is known.
+func (s JobSort) Valid() bool {
+	switch s.Field {
+	case "", SortByCreatedAt, SortByCompletedAt, SortByPriority, SortByRetryCount:
+		return true
+	}
+	return false
+}
+
+// orderBy renders the sort as a SQL ORDER BY clause. column maps the sort
+// field to the column of the listed table.
+func (s JobSort) orderBy(column map[JobSortField]string) string {
+	field := s.Field
+	if field == "" {
+		field = SortByCreatedAt
+	}
+	direction := "DESC"
+	if s.Ascending {
+		direction = "ASC"
+	}
+	return fmt.Sprintf(" ORDER BY %s %s NULLS LAST, id %s", column[field], direction, direction)
+}
+
+// PaginationMetadata describes a page of a listing. CurrentPage is 0 for pages
+// selected by a page token; TotalRecords and TotalPages are 0 when TotalCount
+// is TotalNone.
 type PaginationMetadata struct {
-	CurrentPage  int   `json:"current_page"`
-	TotalPages   int   `json:"total_pages"`
-	TotalRecords int64 `json:"total_records"`
-	Limit        int   `json:"limit"`
+	CurrentPage  int        `json:"current_page"`
+	TotalPages   int        `json:"total_pages"`
+	TotalRecords int64      `json:"total_records"`
+	Limit        int        `json:"limit"`
+	TotalCount   TotalCount `json:"total_count"`
 }
 
 type PaginatedJobs struct {
 	Jobs []Job              `json:"jobs"`
 	Meta PaginationMetadata `json:"meta"`
+	// NextPageToken continues the listing after the last job of this page.
+	// It is empty on the last page and for sorts that do not support tokens.
+	NextPageToken string `json:"next_page_token,omitempty"`
 }
 
 type JobStats struct {
 	Pending   int64
 	Running   int64
 	Completed int64
+	// Failed counts jobs that failed without being retried; dead-lettered
+	// jobs are counted in Dead.
+	Failed    int64
+	Cancelled int64
+	Skipped   int64
+	Dead      int64
+}
+
+// Total returns the number of jobs in any status.
+func (s JobStats) Total() int64 {
+	return s.Pending + s.Running + s.Completed + s.Failed + s.Cancelled + s.Skipped + s.Dead
+}
+
+// TypeStatsParams selects the window GetJobTypeStats reports on: the last
+// Window, split into buckets of Bucket (no buckets when Bucket is 0).
+// An empty Type reports every job type.
+type TypeStatsParams struct {
+	Type   string
+	Window time.Duration
+	Bucket time.Duration
+}
+
+// JobTypeStats summarizes the jobs of one type over a window and each of its
+// buckets.
+type JobTypeStats struct {
+	Type    string
+	Total   WindowStats
+	Buckets []WindowStats
+}
+
+// WindowStats describes the jobs of a type that finished, or were claimed,
+// between Start and End. Failed includes dead-lettered jobs. Wait is the time
+// from a job being due to being claimed; Duration is the execution time of
+// completed and failed attempts.
+type WindowStats struct {
+	Start     time.Time
+	End       time.Time
+	Completed int64
 	Failed    int64
+	Started   int64
+
+	AvgWait     time.Duration
+	P95Wait     time.Duration
+	AvgDuration time.Duration
+	P95Duration time.Duration
+}
+
+// ThroughputPerMinute returns the number of jobs completed per minute.
+func (w WindowStats) ThroughputPerMinute() float64 {
+	minutes := w.End.Sub(w.Start).Minutes()
+	if minutes <= 0 {
+		return 0
+	}
+	return float64(w.Completed) / minutes
+}
+
+// SuccessRatio returns the share of finished jobs that completed, or 0 if
+// none finished.
+func (w WindowStats) SuccessRatio() float64 {
+	if w.Completed+w.Failed == 0 {
+		return 0
+	}
+	return float64(w.Completed) / float64(w.Completed+w.Failed)
+}
+
+type CatchupPolicy string
+
+const (
+	// CatchupSkip drops ticks missed while no scheduler was running.
+	CatchupSkip CatchupPolicy = "skip"
+	// CatchupOnce collapses all missed ticks into a single run.
+	CatchupOnce CatchupPolicy = "once"
+	// CatchupAll runs every missed tick.
+	CatchupAll CatchupPolicy = "all"
+)
+
+type Schedule struct {
+	ID              int64         `db:"id"`
+	Name            string        `db:"name"`
+	CronExpr        string        `db:"cron_expr"`
+	Timezone        string        `db:"timezone"`
+	JobType         string        `db:"job_type"`
+	PayloadTemplate string        `db:"payload_template"`
+	CatchupPolicy   CatchupPolicy `db:"catchup_policy"`
+	Paused          bool          `db:"paused"`
+	NextRunAt       time.Time     `db:"next_run_at"`
+	LastRunAt       *time.Time    `db:"last_run_at"`
+	CreatedAt       time.Time     `db:"created_at"`
+	UpdatedAt       time.Time     `db:"updated_at"`
+}
+
+type CreateScheduleParams struct {
+	Name            string
+	CronExpr        string
+	Timezone        string
+	JobType         string
+	PayloadTemplate string
+	CatchupPolicy   CatchupPolicy
+	NextRunAt       time.Time
+}
+
+type WorkflowStatus string
+
+const (
+	WorkflowStatusPending   WorkflowStatus = "pending"
+	WorkflowStatusRunning   WorkflowStatus = "running"
+	WorkflowStatusCompleted WorkflowStatus = "completed"
+	WorkflowStatusFailed    WorkflowStatus = "failed"
+)
+
+type Workflow struct {
+	ID        int64          `db:"id"`
+	Name      string         `db:"name"`
+	CreatedAt time.Time      `db:"created_at"`
+	Steps     []WorkflowStep `db:"-"`
+}
+
+// WorkflowStep is a job of a workflow. DependsOn holds the keys of the steps
+// that must complete before this one is claimed.
+type WorkflowStep struct {
+	Key          string
+	JobID        int64
+	Type         string
+	Status       JobStatus
+	ErrorMessage sql.NullString
+	DependsOn    []string
+}
+
+// Status aggregates the statuses of the workflow's jobs: completed once every
+// job completed, failed once nothing can run anymore and some job did not
+// complete, pending while no job has started and running otherwise.
+func (w *Workflow) Status() WorkflowStatus {
+	var pending, active, completed int
+	for _, step := range w.Steps {
+		switch step.Status {
+		case JobStatusPending:
+			pending++
+		case JobStatusRunning:
+			active++
+		case JobStatusCompleted:
+			completed++
+		}
+	}
+
+	switch {
+	case completed == len(w.Steps):
+		return WorkflowStatusCompleted
+	case pending == len(w.Steps):
+		return WorkflowStatusPending
+	case pending+active > 0:
+		return WorkflowStatusRunning
+	default:
+		return WorkflowStatusFailed
+	}
+}
+
+// CreateWorkflowParams describes a workflow to submit. Steps must be ordered so
+// that every step comes after the steps it depends on.
+type CreateWorkflowParams struct {
+	Name  string
+	Steps []CreateWorkflowStepParams
+}
+
+type CreateWorkflowStepParams struct {
+	Key       string
+	DependsOn []string
+	Job       CreateJobParams
 }
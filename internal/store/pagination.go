@@ -0,0 +1,119 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPageToken is returned for a page token that is malformed or was
+// issued for a different sort order.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// TotalCount selects how the total number of matching jobs is reported.
+type TotalCount string
+
+const (
+	// TotalExact counts the matching jobs, which is slow on large tables.
+	TotalExact TotalCount = "exact"
+	// TotalEstimated uses the query planner's row estimate.
+	TotalEstimated TotalCount = "estimated"
+	// TotalNone skips counting.
+	TotalNone TotalCount = "none"
+)
+
+// PageParams selects a page of a job listing. A non-empty PageToken continues
+// after the last job of the page that returned it and cannot be combined with
+// Offset. An empty Total counts exactly for offset pages and not at all for
+// token pages.
+type PageParams struct {
+	Limit     int
+	Offset    int
+	PageToken string
+	Total     TotalCount
+}
+
+// total resolves the default of Total.
+func (p PageParams) total() TotalCount {
+	if p.Total != "" {
+		return p.Total
+	}
+	if p.PageToken != "" {
+		return TotalNone
+	}
+	return TotalExact
+}
+
+// pageCursor is the position after which a token page starts: the sort key
+// and id of the last job of the previous page. Only non-nullable sort fields
+// can be seeked, so completed_at listings have no cursor.
+type pageCursor struct {
+	Field     JobSortField `json:"f"`
+	Ascending bool         `json:"a,omitempty"`
+	Time      *time.Time   `json:"t,omitempty"`
+	Number    *int64       `json:"n,omitempty"`
+	ID        int64        `json:"i"`
+}
+
+// cursorAfter returns the cursor following job in a listing sorted by sort,
+// or false if the sort cannot be seeked.
+func cursorAfter(job Job, sort JobSort) (pageCursor, bool) {
+	c := pageCursor{Field: sort.Field, Ascending: sort.Ascending, ID: job.ID}
+	if c.Field == "" {
+		c.Field = SortByCreatedAt
+	}
+
+	switch c.Field {
+	case SortByCreatedAt:
+		t := job.CreatedAt
+		c.Time = &t
+	case SortByPriority:
+		n := int64(job.Priority)
+		c.Number = &n
+	case SortByRetryCount:
+		n := int64(job.RetryCount)
+		c.Number = &n
+	default:
+		return c, false
+	}
+	return c, true
+}
+
+// value returns the sort key of the cursor.
+func (c pageCursor) value() any {
+	if c.Time != nil {
+		return *c.Time
+	}
+	return *c.Number
+}
+
+func (c pageCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePageToken parses a token issued by encode for a listing sorted by sort.
+func decodePageToken(token string, sort JobSort) (pageCursor, error) {
+	var c pageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, ErrInvalidPageToken
+	}
+
+	field := sort.Field
+	if field == "" {
+		field = SortByCreatedAt
+	}
+	if c.Field != field || c.Ascending != sort.Ascending {
+		return c, fmt.Errorf("%w: it was issued for a different sort order", ErrInvalidPageToken)
+	}
+	if (c.Field == SortByCreatedAt) != (c.Time != nil) || (c.Time == nil && c.Number == nil) {
+		return c, ErrInvalidPageToken
+	}
+	return c, nil
+}
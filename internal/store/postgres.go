@@ -2,17 +2,30 @@ package store
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
+	"github.com/bhanuprakaash/job-scheduler/internal/jobdb"
 	"github.com/bhanuprakaash/job-scheduler/internal/logger"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Store struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	listener jobListener
+}
+
+// dbtx is satisfied by both *pgxpool.Pool and pgx.Tx.
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
 func NewStore(ctx context.Context, databaseUrl string) (*Store, error) {
@@ -40,53 +53,174 @@ func NewStore(ctx context.Context, databaseUrl string) (*Store, error) {
 }
 
 func (s *Store) Close() {
+	s.listener.close()
 	s.db.Close()
 	logger.Info("db disconnected")
 }
 
-func (s *Store) CreateJob(ctx context.Context, jobType, payload string) (*Job, error) {
-	var job = &Job{}
-	query :=
-		`
-		INSERT INTO jobs (type, payload)
-		VALUES ($1, $2)
-		RETURNING id, type, payload, status, created_at, updated_at
-		`
+const jobColumns = jobdb.Columns
+
+// scanJob scans a row selected with jobColumns, followed by the columns
+// scanned into extra.
+func scanJob(row pgx.Row, extra ...any) (*Job, error) {
+	return jobdb.ScanJob(row, extra...)
+}
+
+func (s *Store) CreateJob(ctx context.Context, params CreateJobParams) (*Job, error) {
+	if params.Unique == nil {
+		return createJob(ctx, s.db, params)
+	}
 
-	err := s.db.QueryRow(ctx, query, jobType, payload).
-		Scan(&job.ID, &job.Type, &job.Payload, &job.Status, &job.CreatedAt, &job.UpdatedAt)
+	// the lock serializing duplicates is held until the transaction ends
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
 
+	job, err := createJob(ctx, tx, params)
 	if err != nil {
-		return nil, fmt.Errorf("insert job: %w", err)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
 	}
 	return job, nil
 }
 
-func (s *Store) GetJobByID(ctx context.Context, id int64) (*Job, error) {
-	var job = &Job{}
+// CreateJobTx inserts a job like CreateJob inside the caller's transaction, so
+// the job is only enqueued if tx commits. Pools are notified on commit.
+func CreateJobTx(ctx context.Context, tx pgx.Tx, params CreateJobParams) (*Job, error) {
+	return createJob(ctx, tx, params)
+}
 
-	query :=
-		`
-		SELECT id, type, payload, status, created_at, updated_at, started_at, completed_at, last_err, retry_count
-		FROM jobs
-		WHERE id = $1
-		`
-	err := s.db.QueryRow(ctx, query, id).
-		Scan(
-			&job.ID,
-			&job.Type,
-			&job.Payload,
-			&job.Status,
-			&job.CreatedAt,
-			&job.UpdatedAt,
-			&job.StartedAt,
-			&job.CompletedAt,
-			&job.ErrorMessage,
-			&job.RetryCount,
+// createJob inserts a job using db, which may be the pool or an open
+// transaction; see jobdb.Insert.
+func createJob(ctx context.Context, db dbtx, params CreateJobParams) (*Job, error) {
+	return jobdb.Insert(ctx, db, params)
+}
+
+// CreateJobs inserts many jobs in one transaction and returns them in the order
+// of params. Jobs without an idempotency key or unique spec are written with a
+// single multi-row INSERT; the others go through createJob, so duplicates (also
+// within the batch) come back with Duplicate set.
+func (s *Store) CreateJobs(ctx context.Context, params []CreateJobParams) ([]Job, error) {
+	for _, p := range params {
+		if !json.Valid([]byte(p.Payload)) {
+			return nil, fmt.Errorf("insert jobs: payload is not valid JSON")
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	jobs := make([]Job, len(params))
+
+	var bulk []int
+	for i, p := range params {
+		if p.IdempotencyKey == "" && p.Unique == nil {
+			bulk = append(bulk, i)
+			continue
+		}
+		job, err := createJob(ctx, tx, p)
+		if err != nil {
+			return nil, err
+		}
+		jobs[i] = *job
+	}
+
+	if len(bulk) > 0 {
+		var (
+			types, payloads, queues []string
+			runAts                  []*time.Time
+			priorities              []int
+			retryPolicies           []*string
+			timeouts                []*int64
 		)
+		for _, i := range bulk {
+			p := params[i]
+			queue := p.Queue
+			if queue == "" {
+				queue = DefaultQueue
+			}
+
+			var retryPolicy *string
+			if p.RetryPolicy != nil {
+				encoded, err := json.Marshal(p.RetryPolicy)
+				if err != nil {
+					return nil, fmt.Errorf("encode retry policy: %w", err)
+				}
+				rp := string(encoded)
+				retryPolicy = &rp
+			}
+
+			var timeoutMs *int64
+			if p.Timeout > 0 {
+				ms := p.Timeout.Milliseconds()
+				timeoutMs = &ms
+			}
+
+			types = append(types, p.Type)
+			payloads = append(payloads, p.Payload)
+			runAts = append(runAts, p.RunAt)
+			priorities = append(priorities, p.Priority)
+			queues = append(queues, queue)
+			retryPolicies = append(retryPolicies, retryPolicy)
+			timeouts = append(timeouts, timeoutMs)
+		}
+
+		// ids are drawn in ordinality order, so ordering by id restores the input order
+		query :=
+			`
+			WITH inserted AS (
+				INSERT INTO jobs (type, payload, next_run_at, priority, queue, retry_policy, timeout_ms)
+				SELECT t.type, t.payload::JSONB, COALESCE(t.run_at, NOW()), t.priority, t.queue, t.retry_policy::JSONB, t.timeout_ms
+				FROM unnest($1::TEXT[], $2::TEXT[], $3::TIMESTAMPTZ[], $4::INT[], $5::TEXT[], $6::TEXT[], $7::BIGINT[])
+					WITH ORDINALITY AS t(type, payload, run_at, priority, queue, retry_policy, timeout_ms, ord)
+				ORDER BY t.ord
+				RETURNING ` + jobColumns + `
+			)
+			SELECT ` + jobColumns + ` FROM inserted ORDER BY id
+			`
+
+		rows, err := tx.Query(ctx, query, types, payloads, runAts, priorities, queues, retryPolicies, timeouts)
+		if err != nil {
+			return nil, fmt.Errorf("insert jobs: %w", err)
+		}
+		defer rows.Close()
+
+		n := 0
+		for rows.Next() {
+			job, err := scanJob(rows)
+			if err != nil {
+				return nil, fmt.Errorf("scan job: %w", err)
+			}
+			jobs[bulk[n]] = *job
+			n++
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("insert jobs: %w", err)
+		}
+		rows.Close()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (s *Store) GetJobByID(ctx context.Context, id int64) (*Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	job, err := scanJob(s.db.QueryRow(ctx, query, id))
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("no jobs found with the id: %d", id)
+			return nil, fmt.Errorf("job %d: %w", id, ErrNotFound)
 		}
 		return nil, fmt.Errorf("get job: %w", err)
 	}
@@ -94,7 +228,7 @@ func (s *Store) GetJobByID(ctx context.Context, id int64) (*Job, error) {
 	return job, nil
 }
 
-func (s *Store) GetPendingJobs(ctx context.Context, limit int) ([]Job, error) {
+func (s *Store) GetPendingJobs(ctx context.Context, params ClaimParams) ([]Job, error) {
 
 	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
@@ -102,16 +236,36 @@ func (s *Store) GetPendingJobs(ctx context.Context, limit int) ([]Job, error) {
 	}
 	defer tx.Rollback(ctx)
 
+	// Without aging the ordering matches idx_jobs_pending_queue_priority. With aging,
+	// every PriorityAging a job has been waiting counts as one extra priority point.
+	orderBy := `priority DESC, next_run_at ASC`
+	if params.PriorityAging > 0 {
+		orderBy = fmt.Sprintf(
+			`priority + FLOOR(EXTRACT(EPOCH FROM (NOW() - next_run_at)) / %f) DESC, next_run_at ASC`,
+			params.PriorityAging.Seconds(),
+		)
+	}
+
 	query :=
 		`
-		SELECT id, type, payload, status, created_at, updated_at, started_at, completed_at
+		SELECT ` + jobColumns + `
 		FROM jobs
-		WHERE status = $1 AND next_run_at <= NOW()
-		ORDER BY next_run_at ASC
-		LIMIT $2
+		WHERE status = $1 AND queue = $2 AND next_run_at <= NOW()
+			AND NOT EXISTS (
+				SELECT 1 FROM job_dependencies d
+				WHERE d.job_id = jobs.id AND NOT d.resolved
+			)
+		ORDER BY ` + orderBy + `
+		LIMIT $3
 		FOR UPDATE SKIP LOCKED
 		`
-	rows, err := tx.Query(ctx, query, JobStatusPending, limit)
+
+	queue := params.Queue
+	if queue == "" {
+		queue = DefaultQueue
+	}
+
+	rows, err := tx.Query(ctx, query, JobStatusPending, queue, params.Limit)
 	if err != nil {
 		return nil, fmt.Errorf("get pending jobs: %w", err)
 	}
@@ -119,28 +273,39 @@ func (s *Store) GetPendingJobs(ctx context.Context, limit int) ([]Job, error) {
 
 	var jobs []Job
 	for rows.Next() {
-		var job Job
-		err := rows.Scan(
-			&job.ID, &job.Type, &job.Payload, &job.Status,
-			&job.CreatedAt, &job.UpdatedAt, &job.StartedAt, &job.CompletedAt,
-		)
+		job, err := scanJob(rows)
 		if err != nil {
 			return nil, fmt.Errorf("scan job: %w", err)
 		}
-		jobs = append(jobs, job)
+		jobs = append(jobs, *job)
 	}
 
-	now := time.Now()
+	leaseStr := fmt.Sprintf("%f seconds", params.LeaseDuration.Seconds())
 	for i := range jobs {
-		_, err := tx.Exec(ctx,
-			`UPDATE jobs SET status = $1, started_at = NOW() WHERE id = $2`,
-			JobStatusRunning, jobs[i].ID,
-		)
+		err := tx.QueryRow(ctx,
+			`
+			UPDATE jobs
+			SET status = $1,
+				started_at = NOW(),
+				locked_by = $3,
+				lease_expires_at = NOW() + $4::INTERVAL,
+				lease_token = lease_token + 1,
+				progress = NULL,
+				progress_message = NULL,
+				progress_updated_at = NULL
+			WHERE id = $2
+			RETURNING started_at, locked_by, lease_expires_at, lease_token
+			`,
+			JobStatusRunning, jobs[i].ID, params.Owner, leaseStr,
+		).Scan(&jobs[i].StartedAt, &jobs[i].LockedBy, &jobs[i].LeaseExpiresAt, &jobs[i].LeaseToken)
 		if err != nil {
 			return nil, fmt.Errorf("mark job running: %w", err)
 		}
 		jobs[i].Status = JobStatusRunning
-		jobs[i].StartedAt = &now
+
+		if err := startAttempt(ctx, tx, jobs[i].ID, params.Owner); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -150,24 +315,88 @@ func (s *Store) GetPendingJobs(ctx context.Context, limit int) ([]Job, error) {
 	return jobs, nil
 }
 
-func (s *Store) UpdateJobStatus(ctx context.Context, status JobStatus, id int64) error {
+// UpdateJobStatus records the outcome of an attempt and releases its lease.
+// The update only applies while lease is still the job's current lease;
+// otherwise ErrLeaseLost is returned. A job cancelled while running keeps
+// its cancellation.
+func (s *Store) UpdateJobStatus(ctx context.Context, status JobStatus, id int64, lease Lease) error {
+	return s.finishJob(ctx, status, id, lease, nil)
+}
+
+// CompleteJob marks a job completed like UpdateJobStatus and stores the JSON
+// result its handler produced. A nil result leaves the column NULL.
+func (s *Store) CompleteJob(ctx context.Context, id int64, lease Lease, result json.RawMessage) error {
+	return s.finishJob(ctx, JobStatusCompleted, id, lease, result)
+}
+
+func (s *Store) finishJob(ctx context.Context, status JobStatus, id int64, lease Lease, result json.RawMessage) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query :=
 		`
 			UPDATE jobs
 			SET status = $1,
 				started_at = CASE WHEN $1 = 'running' THEN NOW() ELSE started_at END,
-				completed_at = CASE WHEN $1 IN ('completed', 'failed') THEN NOW() ELSE completed_at END
-			WHERE id = $2
+				completed_at = CASE WHEN $1 IN ('completed', 'failed') THEN NOW() ELSE completed_at END,
+				locked_by = NULL,
+				lease_expires_at = NULL,
+				result = $5::JSONB
+			WHERE id = $2 AND status = 'running' AND locked_by = $3 AND lease_token = $4
 		`
-	_, err := s.db.Exec(ctx, query, status, id)
+	var resultArg *string
+	if result != nil {
+		r := string(result)
+		resultArg = &r
+	}
+	tag, err := tx.Exec(ctx, query, status, id, lease.Owner, lease.Token, resultArg)
 	if err != nil {
 		return fmt.Errorf("update job: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		var current JobStatus
+		err := tx.QueryRow(ctx, `SELECT status FROM jobs WHERE id = $1`, id).Scan(&current)
+		if err == nil && current == JobStatusCancelled {
+			// the job was cancelled while running; keep the cancellation
+			return nil
+		}
+		return fmt.Errorf("job %d: %w", id, ErrLeaseLost)
+	}
 
-	return nil
+	if err := finishAttempt(ctx, tx, id, AttemptOutcome(status), ""); err != nil {
+		return err
+	}
+
+	switch status {
+	case JobStatusCompleted:
+		rows, err := tx.Query(ctx, `UPDATE job_dependencies SET resolved = TRUE WHERE depends_on = $1 RETURNING job_id`, id)
+		if err != nil {
+			return fmt.Errorf("resolve dependencies: %w", err)
+		}
+		dependants, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return fmt.Errorf("resolve dependencies: %w", err)
+		}
+		if err := notifyJobsReady(ctx, tx, dependants...); err != nil {
+			return err
+		}
+	case JobStatusFailed:
+		if err := skipDependants(ctx, tx, id, fmt.Sprintf("dependency job %d failed", id)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
-func (s *Store) HandleJobFailure(ctx context.Context, jobId int64, errMsg string) error {
+// HandleJobFailure schedules the next attempt of a failed job according to
+// policy, or moves it to dead_jobs once policy.MaxAttempts is exhausted. A zero
+// MaxAttempts falls back to the job's max_retries column. Like UpdateJobStatus,
+// it is fenced on lease.
+func (s *Store) HandleJobFailure(ctx context.Context, jobId int64, errMsg string, policy RetryPolicy, lease Lease) error {
 
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
@@ -176,61 +405,97 @@ func (s *Store) HandleJobFailure(ctx context.Context, jobId int64, errMsg string
 	defer tx.Rollback(ctx)
 
 	var retryCount, maxRetries int
-	var jobType, payload string
+	var jobStatus JobStatus
+	var lockedBy sql.NullString
+	var leaseToken int64
 
-	err = tx.QueryRow(ctx, `SELECT type, payload, retry_count, max_retries FROM jobs WHERE id = $1 FOR UPDATE`, jobId).
-		Scan(&jobType, &payload, &retryCount, &maxRetries)
+	err = tx.QueryRow(ctx, `SELECT status, retry_count, max_retries, locked_by, lease_token FROM jobs WHERE id = $1 FOR UPDATE`, jobId).
+		Scan(&jobStatus, &retryCount, &maxRetries, &lockedBy, &leaseToken)
 
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("job %d: %w", jobId, ErrLeaseLost)
+	}
 	if err != nil {
 		return fmt.Errorf("fetch job: %w", err)
 	}
 
-	newRetryCount := retryCount + 1
-
-	if newRetryCount >= maxRetries {
-		_, err = tx.Exec(ctx, `INSERT into dead_jobs (id, type, payload, last_err, retry_count) VALUES($1, $2, $3, $4, $5)`,
-			jobId, jobType, payload, errMsg, newRetryCount)
+	if jobStatus == JobStatusCancelled {
+		logger.Info("Not retrying cancelled job", "job_id", jobId)
+		return nil
+	}
 
-		if err != nil {
-			return fmt.Errorf("move to dlq: %w", err)
-		}
+	if jobStatus != JobStatusRunning || lockedBy.String != lease.Owner || leaseToken != lease.Token {
+		return fmt.Errorf("job %d: %w", jobId, ErrLeaseLost)
+	}
 
-		_, err = tx.Exec(ctx, `DELETE FROM jobs WHERE id = $1`, jobId)
-		if err != nil {
-			return fmt.Errorf("delete from jobs: %w", err)
-		}
+	if err := failJob(ctx, tx, jobId, retryCount, maxRetries, errMsg, AttemptFailed, policy); err != nil {
+		return err
+	}
 
-		logger.Info("Job moved to DLQ", "job_id", jobId)
+	return tx.Commit(ctx)
+}
 
-	} else {
+// failJob ends the open attempt of a job with outcome, then schedules its next
+// attempt according to policy or moves it to dead_jobs once policy.MaxAttempts
+// (or maxRetries, if zero) is exhausted. The caller holds the job's row lock.
+func failJob(ctx context.Context, tx pgx.Tx, jobId int64, retryCount, maxRetries int, errMsg string, outcome AttemptOutcome, policy RetryPolicy) error {
+	if err := finishAttempt(ctx, tx, jobId, outcome, errMsg); err != nil {
+		return err
+	}
 
-		backoff := math.Pow(2, float64(newRetryCount))
+	newRetryCount := retryCount + 1
+	if policy.MaxAttempts > 0 {
+		maxRetries = policy.MaxAttempts
+	}
 
-		_, err = tx.Exec(ctx, `
+	if newRetryCount < maxRetries {
+		_, err := tx.Exec(ctx, `
 			UPDATE jobs
 			SET status = 'pending',
 				retry_count = $1,
 				last_err = $2,
 				next_run_at = NOW() + ($3 * INTERVAL '1 second'),
-				updated_at = NOW()
+				updated_at = NOW(),
+				locked_by = NULL,
+				lease_expires_at = NULL
 			WHERE id = $4
-		`, newRetryCount, errMsg, backoff, jobId)
-
+		`, newRetryCount, errMsg, policy.Delay(newRetryCount).Seconds(), jobId)
 		if err != nil {
 			return fmt.Errorf("update retry: %w", err)
 		}
+		return nil
+	}
 
+	_, err := tx.Exec(ctx, `
+		INSERT into dead_jobs (id, type, payload, last_err, retry_count, queue, priority, replay_count, retry_policy, timeout_ms, unique_key)
+		SELECT id, type, payload, $2, $3, queue, priority, replay_count, retry_policy, timeout_ms, unique_key FROM jobs WHERE id = $1
+	`, jobId, errMsg, newRetryCount)
+	if err != nil {
+		return fmt.Errorf("move to dlq: %w", err)
 	}
 
-	return tx.Commit(ctx)
+	if _, err := tx.Exec(ctx, `DELETE FROM jobs WHERE id = $1`, jobId); err != nil {
+		return fmt.Errorf("delete from jobs: %w", err)
+	}
 
+	// a dead job no longer holds its idempotency key
+	if _, err := tx.Exec(ctx, `DELETE FROM job_idempotency_keys WHERE job_id = $1`, jobId); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+
+	if err := skipDependants(ctx, tx, jobId, fmt.Sprintf("dependency job %d was dead-lettered", jobId)); err != nil {
+		return err
+	}
+
+	logger.Info("Job moved to DLQ", "job_id", jobId)
+	return nil
 }
 
 func (s *Store) GetArchivedJobs(ctx context.Context, duration time.Duration, limit int) ([]Job, error) {
 
 	query :=
 		`
-			SELECT id, type, payload, status, created_at, completed_at
+			SELECT id, type, payload, status, created_at, completed_at, result
 			FROM jobs
 			WHERE status = 'completed' AND completed_at < NOW() - $1::INTERVAL
 			LIMIT $2
@@ -246,7 +511,7 @@ func (s *Store) GetArchivedJobs(ctx context.Context, duration time.Duration, lim
 	var jobs []Job
 	for rows.Next() {
 		var j Job
-		if err := rows.Scan(&j.ID, &j.Type, &j.Payload, &j.Status, &j.CreatedAt, &j.CompletedAt); err != nil {
+		if err := rows.Scan(&j.ID, &j.Type, &j.Payload, &j.Status, &j.CreatedAt, &j.CompletedAt, &j.Result); err != nil {
 			return nil, err
 		}
 		jobs = append(jobs, j)
@@ -255,12 +520,26 @@ func (s *Store) GetArchivedJobs(ctx context.Context, duration time.Duration, lim
 	return jobs, nil
 }
 
+// BatchDeleteJobs deletes jobs together with their attempt history and
+// idempotency keys. The status of a deleted workflow job is kept in
+// workflow_jobs.
 func (s *Store) BatchDeleteJobs(ctx context.Context, ids []int64) error {
 	if len(ids) == 0 {
 		return nil
 	}
 
-	query := `DELETE FROM jobs WHERE id = ANY($1)`
+	query :=
+		`
+			WITH deleted AS (
+				DELETE FROM jobs WHERE id = ANY($1) RETURNING id, status
+			), steps AS (
+				UPDATE workflow_jobs wj SET status = deleted.status
+				FROM deleted WHERE wj.job_id = deleted.id
+			), attempts AS (
+				DELETE FROM job_attempts WHERE job_id IN (SELECT id FROM deleted)
+			)
+			DELETE FROM job_idempotency_keys WHERE job_id IN (SELECT id FROM deleted)
+		`
 	_, err := s.db.Exec(ctx, query, ids)
 
 	if err != nil {
@@ -270,132 +549,212 @@ func (s *Store) BatchDeleteJobs(ctx context.Context, ids []int64) error {
 	return nil
 }
 
-func (s *Store) ListJobs(ctx context.Context, limit, offset int) (*PaginatedJobs, error) {
+// jobSortColumns maps sort fields to columns of jobs.
+var jobSortColumns = map[JobSortField]string{
+	SortByCreatedAt:   "created_at",
+	SortByCompletedAt: "completed_at",
+	SortByPriority:    "priority",
+	SortByRetryCount:  "retry_count",
+}
 
-	var total int64
-	if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM jobs").Scan(&total); err != nil {
-		return nil, err
+func (s *Store) ListJobs(ctx context.Context, filter JobFilter, sort JobSort, page PageParams) (*PaginatedJobs, error) {
+	where, args := filter.where()
+	return s.listJobs(ctx, "jobs", jobColumns, where, args, sort, jobSortColumns, page, func(row pgx.Row) (*Job, error) { return scanJob(row) })
+}
+
+// where renders the filter as a SQL WHERE clause with positional arguments.
+func (f JobFilter) where() (string, []any) {
+	var conditions []string
+	var args []any
+
+	if f.MinPriority != nil {
+		args = append(args, *f.MinPriority)
+		conditions = append(conditions, fmt.Sprintf("priority >= $%d", len(args)))
 	}
 
-	if total == 0 {
-		return &PaginatedJobs{
-			Jobs: []Job{},
-			Meta: PaginationMetadata{
-				CurrentPage:  1,
-				TotalPages:   0,
-				TotalRecords: 0,
-				Limit:        limit,
-			},
-		}, nil
+	if f.Queue != "" {
+		args = append(args, f.Queue)
+		conditions = append(conditions, fmt.Sprintf("queue = $%d", len(args)))
 	}
 
-	query := `
-		SELECT id, type, payload, status, created_at, updated_at, started_at, completed_at, last_err, retry_count
-		FROM jobs
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
-	rows, err := s.db.Query(ctx, query, limit, offset)
+	if len(f.Statuses) > 0 {
+		statuses := make([]string, len(f.Statuses))
+		for i, status := range f.Statuses {
+			statuses[i] = string(status)
+		}
+		args = append(args, statuses)
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+
+	if f.Type != "" {
+		args = append(args, f.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+
+	if f.CreatedAfter != nil {
+		args = append(args, f.CreatedAfter.UTC())
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	if f.CreatedBefore != nil {
+		args = append(args, f.CreatedBefore.UTC())
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	if f.CompletedAfter != nil {
+		args = append(args, f.CompletedAfter.UTC())
+		conditions = append(conditions, fmt.Sprintf("completed_at >= $%d", len(args)))
+	}
+
+	if f.CompletedBefore != nil {
+		args = append(args, f.CompletedBefore.UTC())
+		conditions = append(conditions, fmt.Sprintf("completed_at < $%d", len(args)))
+	}
+
+	if f.MinRetries != nil {
+		args = append(args, *f.MinRetries)
+		conditions = append(conditions, fmt.Sprintf("retry_count >= $%d", len(args)))
+	}
+
+	if f.MaxRetries != nil {
+		args = append(args, *f.MaxRetries)
+		conditions = append(conditions, fmt.Sprintf("retry_count <= $%d", len(args)))
+	}
+
+	if f.ErrorContains != "" {
+		args = append(args, f.ErrorContains)
+		conditions = append(conditions, fmt.Sprintf("strpos(last_err, $%d) > 0", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// deadJobSortColumns maps sort fields to columns of dead_jobs. Dead jobs do
+// not keep their creation time, so both time fields sort by failed_at.
+var deadJobSortColumns = map[JobSortField]string{
+	SortByCreatedAt:   "failed_at",
+	SortByCompletedAt: "failed_at",
+	SortByPriority:    "priority",
+	SortByRetryCount:  "retry_count",
+}
+
+func (s *Store) ListDeadJobs(ctx context.Context, filter DeadJobFilter, sort JobSort, page PageParams) (*PaginatedJobs, error) {
+	where, args := filter.where()
+	columns := `id, type, payload, 'failed' AS status, failed_at AS created_at, last_err, retry_count, queue, priority`
+	return s.listJobs(ctx, "dead_jobs", columns, where, args, sort, deadJobSortColumns, page, func(row pgx.Row) (*Job, error) {
+		var j Job
+		err := row.Scan(&j.ID, &j.Type, &j.Payload, &j.Status, &j.CreatedAt, &j.ErrorMessage, &j.RetryCount, &j.Queue, &j.Priority)
+		return &j, err
+	})
+}
+
+// listJobs returns a page of the rows of table matching where, ordered by
+// sort. Token pages seek past the cursor on (sort column, id) instead of
+// skipping rows, so they stay fast however deep the listing goes.
+func (s *Store) listJobs(
+	ctx context.Context,
+	table, columns, where string, args []any,
+	sort JobSort, sortColumns map[JobSortField]string,
+	page PageParams,
+	scan func(pgx.Row) (*Job, error),
+) (*PaginatedJobs, error) {
+	if page.PageToken != "" && page.Offset > 0 {
+		return nil, fmt.Errorf("%w: offset cannot be combined with a page token", ErrInvalidPageToken)
+	}
+
+	meta := PaginationMetadata{Limit: page.Limit, TotalCount: page.total()}
+
+	switch meta.TotalCount {
+	case TotalExact:
+		if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM "+table+where, args...).Scan(&meta.TotalRecords); err != nil {
+			return nil, err
+		}
+	case TotalEstimated:
+		estimate, err := s.estimateRows(ctx, "SELECT 1 FROM "+table+where, args)
+		if err != nil {
+			return nil, err
+		}
+		meta.TotalRecords = estimate
+	}
+	if meta.TotalCount != TotalNone {
+		meta.TotalPages = int(math.Ceil(float64(meta.TotalRecords) / float64(page.Limit)))
+	}
+
+	if page.PageToken != "" {
+		cursor, err := decodePageToken(page.PageToken, sort)
+		if err != nil {
+			return nil, err
+		}
+		field := cursor.Field
+		comparison := "<"
+		if cursor.Ascending {
+			comparison = ">"
+		}
+		args = append(args, cursor.value(), cursor.ID)
+		seek := fmt.Sprintf("(%s, id) %s ($%d, $%d)", sortColumns[field], comparison, len(args)-1, len(args))
+		if where == "" {
+			where = " WHERE " + seek
+		} else {
+			where += " AND " + seek
+		}
+	} else {
+		meta.CurrentPage = (page.Offset / page.Limit) + 1
+	}
+
+	// one extra row tells whether there is a next page
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s%s%s
+		LIMIT $%d OFFSET $%d
+	`, columns, table, where, sort.orderBy(sortColumns), len(args)+1, len(args)+2)
+	rows, err := s.db.Query(ctx, query, append(args, page.Limit+1, page.Offset)...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	jobs := []Job{}
-
 	for rows.Next() {
-		var j Job
-		if err := rows.Scan(
-			&j.ID,
-			&j.Type,
-			&j.Payload,
-			&j.Status,
-			&j.CreatedAt,
-			&j.UpdatedAt,
-			&j.StartedAt,
-			&j.CompletedAt,
-			&j.ErrorMessage,
-			&j.RetryCount,
-		); err != nil {
+		j, err := scan(rows)
+		if err != nil {
 			return nil, err
 		}
-		jobs = append(jobs, j)
+		jobs = append(jobs, *j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	currentPage := (offset / limit) + 1
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	result := &PaginatedJobs{Jobs: jobs, Meta: meta}
+	if len(jobs) > page.Limit {
+		result.Jobs = jobs[:page.Limit]
+		if cursor, ok := cursorAfter(result.Jobs[page.Limit-1], sort); ok {
+			result.NextPageToken = cursor.encode()
+		}
+	}
 
-	return &PaginatedJobs{
-		Jobs: jobs,
-		Meta: PaginationMetadata{
-			CurrentPage:  currentPage,
-			TotalPages:   totalPages,
-			TotalRecords: total,
-			Limit:        limit,
-		},
-	}, nil
+	return result, nil
 }
 
-func (s *Store) ListDeadJobs(ctx context.Context, limit, offset int) (*PaginatedJobs, error) {
-    var total int64
-    if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM dead_jobs").Scan(&total); err != nil {
-        return nil, err
-    }
-
-    if total == 0 {
-        return &PaginatedJobs{
-            Jobs: []Job{},
-            Meta: PaginationMetadata{
-                CurrentPage:  1,
-                TotalPages:   0,
-                TotalRecords: 0,
-                Limit:        limit,
-            },
-        }, nil
-    }
-
-    query := `
-        SELECT id, type, payload, 'failed' as status, failed_at as created_at, last_err, retry_count
-        FROM dead_jobs
-        ORDER BY failed_at DESC
-        LIMIT $1 OFFSET $2
-    `
-    rows, err := s.db.Query(ctx, query, limit, offset)
-    if err != nil {
-        return nil, err
-    }
-    defer rows.Close()
-
-    jobs := []Job{}
-
-    for rows.Next() {
-        var j Job
-        if err := rows.Scan(
-            &j.ID, 
-            &j.Type, 
-            &j.Payload, 
-            &j.Status, 
-            &j.CreatedAt, 
-            &j.ErrorMessage, 
-            &j.RetryCount,
-        ); err != nil {
-            return nil, err
-        }
-        jobs = append(jobs, j)
-    }
-
-    currentPage := (offset / limit) + 1
-    totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-    return &PaginatedJobs{
-        Jobs: jobs,
-        Meta: PaginationMetadata{
-            CurrentPage:  currentPage,
-            TotalPages:   totalPages,
-            TotalRecords: total,
-            Limit:        limit,
-        },
-    }, nil
+// estimateRows returns the planner's estimate of the number of rows query
+// returns, which is cheap but can be off, especially for selective filters.
+func (s *Store) estimateRows(ctx context.Context, query string, args []any) (int64, error) {
+	var plan []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	var raw []byte
+	if err := s.db.QueryRow(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&raw); err != nil {
+		return 0, fmt.Errorf("estimate rows: %w", err)
+	}
+	if err := json.Unmarshal(raw, &plan); err != nil || len(plan) == 0 {
+		return 0, fmt.Errorf("estimate rows: unexpected plan %s", raw)
+	}
+	return int64(plan[0].Plan.Rows), nil
 }
 
 func (s *Store) GetStats(ctx context.Context) (*JobStats, error) {
@@ -419,45 +778,227 @@ func (s *Store) GetStats(ctx context.Context) (*JobStats, error) {
 			return nil, err
 		}
 
-		switch status {
-		case "pending":
+		switch JobStatus(status) {
+		case JobStatusPending:
 			stats.Pending = count
-		case "running":
+		case JobStatusRunning:
 			stats.Running = count
-		case "completed":
+		case JobStatusCompleted:
 			stats.Completed = count
+		case JobStatusFailed:
+			stats.Failed = count
+		case JobStatusCancelled:
+			stats.Cancelled = count
+		case JobStatusSkipped:
+			stats.Skipped = count
 		}
 	}
 
 	queryDead := `SELECT COUNT(*) FROM dead_jobs`
-	var deadCount int64
-	if err := s.db.QueryRow(ctx, queryDead).Scan(&deadCount); err != nil {
+	if err := s.db.QueryRow(ctx, queryDead).Scan(&stats.Dead); err != nil {
 		return nil, err
 	}
 
-	stats.Failed = deadCount
-
 	return stats, nil
 }
 
-func (s *Store) RepeatStuckJobs(ctx context.Context, interval time.Duration) (int64, error) {
+// UpdateJobProgress records the progress reported by the handler of a running
+// job. Like UpdateJobStatus it is fenced on lease and returns ErrLeaseLost when
+// the lease is no longer held.
+func (s *Store) UpdateJobProgress(ctx context.Context, id int64, lease Lease, percent int, message string) error {
+	query :=
+		`
+			UPDATE jobs
+			SET progress = $4,
+				progress_message = NULLIF($5, ''),
+				progress_updated_at = NOW()
+			WHERE id = $1 AND status = 'running' AND locked_by = $2 AND lease_token = $3
+		`
+
+	tag, err := s.db.Exec(ctx, query, id, lease.Owner, lease.Token, percent, message)
+	if err != nil {
+		return fmt.Errorf("update progress: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("job %d: %w", id, ErrLeaseLost)
+	}
+	return nil
+}
+
+// ExtendLeases heartbeats the leases of running jobs, pushing their expiry
+// duration into the future. It returns the ids whose lease is no longer held,
+// so the caller can stop working on them.
+func (s *Store) ExtendLeases(ctx context.Context, leases map[int64]Lease, duration time.Duration) ([]int64, error) {
+	if len(leases) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(leases))
+	owners := make([]string, 0, len(leases))
+	tokens := make([]int64, 0, len(leases))
+	for id, lease := range leases {
+		ids = append(ids, id)
+		owners = append(owners, lease.Owner)
+		tokens = append(tokens, lease.Token)
+	}
+
 	query :=
-		`	
+		`
 			UPDATE jobs
-			SET status = 'pending',
-				updated_at = NOW(),
-				last_err = 'job execution timed out (stuck)'
-			WHERE 
-				status = 'running'
-				AND started_at < NOW() - $1::INTERVAL
+			SET lease_expires_at = NOW() + $4::INTERVAL
+			FROM unnest($1::BIGINT[], $2::TEXT[], $3::BIGINT[]) AS l(id, owner, token)
+			WHERE jobs.id = l.id AND jobs.locked_by = l.owner AND jobs.lease_token = l.token
+			RETURNING jobs.id
 		`
 
-	intervalStr := fmt.Sprintf("%f seconds", interval.Seconds())
-	result, err := s.db.Exec(ctx, query, intervalStr)
+	durationStr := fmt.Sprintf("%f seconds", duration.Seconds())
+	rows, err := s.db.Query(ctx, query, ids, owners, tokens, durationStr)
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("extend leases: %w", err)
 	}
+	defer rows.Close()
 
-	return result.RowsAffected(), nil
+	held := make(map[int64]bool, len(leases))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		held[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var lost []int64
+	for _, id := range ids {
+		if !held[id] {
+			lost = append(lost, id)
+		}
+	}
+	return lost, nil
+}
+
+// ReclaimExpiredJobs takes back running jobs whose lease expired, i.e. whose
+// worker stopped heartbeating. Each one counts as a failed attempt, closed as
+// lease_expired: the job is retried under policy(job), or dead-lettered once
+// its attempts are exhausted, so a job that keeps crashing its worker ends up
+// in the DLQ. It returns the number of jobs reclaimed.
+func (s *Store) ReclaimExpiredJobs(ctx context.Context, policy func(Job) RetryPolicy) (int64, error) {
+	const reason = "job lease expired (worker lost)"
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT `+jobColumns+`, max_retries FROM jobs
+		WHERE status = 'running' AND (lease_expires_at IS NULL OR lease_expires_at < NOW())
+		FOR UPDATE SKIP LOCKED
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("select expired jobs: %w", err)
+	}
+	var expired []Job
+	var maxRetries []int
+	for rows.Next() {
+		var max int
+		job, err := scanJob(rows, &max)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan expired job: %w", err)
+		}
+		expired = append(expired, *job)
+		maxRetries = append(maxRetries, max)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("select expired jobs: %w", err)
+	}
+
+	for i, job := range expired {
+		if err := failJob(ctx, tx, job.ID, job.RetryCount, maxRetries[i], reason, AttemptLeaseExpired, policy(job)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit tx: %w", err)
+	}
+	return int64(len(expired)), nil
+}
+
+// CancelJob marks a pending or running job as cancelled. Pending jobs are never
+// claimed afterwards; workers running the job notice the status through
+// GetCancelledJobIDs and cancel the handler's context.
+func (s *Store) CancelJob(ctx context.Context, id int64) (*Job, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var current JobStatus
+	err = tx.QueryRow(ctx, `SELECT status FROM jobs WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("job %d: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetch job: %w", err)
+	}
+
+	if current != JobStatusPending && current != JobStatusRunning {
+		return nil, fmt.Errorf("job %d is %s: %w", id, current, ErrJobFinished)
+	}
+
+	job, err := scanJob(tx.QueryRow(ctx, `
+		UPDATE jobs
+		SET status = $1, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $2
+		RETURNING `+jobColumns, JobStatusCancelled, id))
+	if err != nil {
+		return nil, fmt.Errorf("cancel job: %w", err)
+	}
+
+	if current == JobStatusRunning {
+		if err := finishAttempt(ctx, tx, id, AttemptCancelled, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := skipDependants(ctx, tx, id, fmt.Sprintf("dependency job %d was cancelled", id)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit cancel: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetCancelledJobIDs returns the subset of ids whose jobs have been cancelled.
+func (s *Store) GetCancelledJobIDs(ctx context.Context, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT id FROM jobs WHERE id = ANY($1) AND status = $2`, ids, JobStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("get cancelled jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var cancelled []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		cancelled = append(cancelled, id)
+	}
 
+	return cancelled, rows.Err()
 }
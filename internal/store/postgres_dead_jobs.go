@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/jobdb"
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// RetryDeadJob moves a dead-lettered job back into jobs under its original id
+// with a reset retry count. A non-nil payload replaces the stored one, and the
+// unique key is then recomputed with the spec unique returns for the job's
+// type; a job blocking the new key fails the replay with ErrAlreadyExists.
+func (s *Store) RetryDeadJob(ctx context.Context, id int64, payload *string, unique func(jobType string) *UniqueSpec) (*Job, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var jobType string
+	var uniqueKey *string
+	err = tx.QueryRow(ctx, `SELECT type, unique_key FROM dead_jobs WHERE id = $1 FOR UPDATE`, id).Scan(&jobType, &uniqueKey)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("dead job %d: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get dead job: %w", err)
+	}
+
+	if payload != nil {
+		uniqueKey = nil
+		if spec := uniqueSpec(unique, jobType); spec != nil {
+			key, err := CreateJobParams{Type: jobType, Payload: *payload, Unique: spec}.UniqueKey()
+			if err != nil {
+				return nil, fmt.Errorf("requeue dead job: %w", err)
+			}
+			existing, err := jobdb.UniqueDuplicate(ctx, tx, key, spec)
+			if err != nil {
+				return nil, err
+			}
+			if existing != nil {
+				return nil, fmt.Errorf("dead job %d: duplicate of job %d: %w", id, existing.ID, ErrAlreadyExists)
+			}
+			uniqueKey = &key
+		}
+	}
+
+	job, err := scanJob(tx.QueryRow(ctx, `
+		WITH moved AS (
+			DELETE FROM dead_jobs WHERE id = $1
+			RETURNING id, type, payload, last_err, queue, priority, replay_count, retry_policy, timeout_ms
+		)
+		INSERT INTO jobs (id, type, payload, last_err, queue, priority, replay_count, replayed_at, retry_policy, timeout_ms, unique_key)
+		SELECT id, type, COALESCE($2::JSONB, payload), last_err, queue, priority, replay_count + 1, NOW(), retry_policy, timeout_ms, $3
+		FROM moved
+		RETURNING `+jobColumns, id, payload, uniqueKey))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("dead job %d: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("requeue dead job: %w", err)
+	}
+
+	if err := restoreDependants(ctx, tx, []int64{id}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit requeue: %w", err)
+	}
+
+	logger.Info("Dead job requeued", "job_id", id, "replay_count", job.ReplayCount)
+
+	return job, nil
+}
+
+// uniqueSpec returns the unique spec of jobType, or nil without a lookup.
+func uniqueSpec(unique func(jobType string) *UniqueSpec, jobType string) *UniqueSpec {
+	if unique == nil {
+		return nil
+	}
+	return unique(jobType)
+}
+
+// RetryDeadJobs requeues every dead-lettered job matching filter and returns
+// their ids.
+func (s *Store) RetryDeadJobs(ctx context.Context, filter DeadJobFilter) ([]int64, error) {
+	where, args := filter.where()
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		WITH moved AS (
+			DELETE FROM dead_jobs`+where+`
+			RETURNING id, type, payload, last_err, queue, priority, replay_count, retry_policy, timeout_ms, unique_key
+		)
+		INSERT INTO jobs (id, type, payload, last_err, queue, priority, replay_count, replayed_at, retry_policy, timeout_ms, unique_key)
+		SELECT id, type, payload, last_err, queue, priority, replay_count + 1, NOW(), retry_policy, timeout_ms, unique_key
+		FROM moved
+		RETURNING id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("requeue dead jobs: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, fmt.Errorf("requeue dead jobs: %w", err)
+	}
+
+	if err := restoreDependants(ctx, tx, ids); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit requeue: %w", err)
+	}
+
+	if len(ids) > 0 {
+		logger.Info("Dead jobs requeued", "count", len(ids))
+	}
+
+	return ids, nil
+}
+
+func (f DeadJobFilter) where() (string, []any) {
+	var conditions []string
+	var args []any
+
+	if len(f.IDs) > 0 {
+		args = append(args, f.IDs)
+		conditions = append(conditions, fmt.Sprintf("id = ANY($%d)", len(args)))
+	}
+
+	if f.Type != "" {
+		args = append(args, f.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+
+	if f.ErrorContains != "" {
+		args = append(args, f.ErrorContains)
+		conditions = append(conditions, fmt.Sprintf("strpos(last_err, $%d) > 0", len(args)))
+	}
+
+	if f.FailedAfter != nil {
+		args = append(args, f.FailedAfter.UTC())
+		conditions = append(conditions, fmt.Sprintf("failed_at >= $%d", len(args)))
+	}
+
+	if f.FailedBefore != nil {
+		args = append(args, f.FailedBefore.UTC())
+		conditions = append(conditions, fmt.Sprintf("failed_at < $%d", len(args)))
+	}
+
+	if f.MinPriority != nil {
+		args = append(args, *f.MinPriority)
+		conditions = append(conditions, fmt.Sprintf("priority >= $%d", len(args)))
+	}
+
+	if f.Queue != "" {
+		args = append(args, f.Queue)
+		conditions = append(conditions, fmt.Sprintf("queue = $%d", len(args)))
+	}
+
+	if f.MinRetries != nil {
+		args = append(args, *f.MinRetries)
+		conditions = append(conditions, fmt.Sprintf("retry_count >= $%d", len(args)))
+	}
+
+	if f.MaxRetries != nil {
+		args = append(args, *f.MaxRetries)
+		conditions = append(conditions, fmt.Sprintf("retry_count <= $%d", len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// jobsReadyChannel is the notification channel announcing jobs that can be
+// claimed now. The payload is the job's queue. Inserted jobs are announced by
+// the jobs_notify_ready trigger (migration 0019), which uses the same name.
+const jobsReadyChannel = "jobs_ready"
+
+// listenRetryDelay is how long the listener waits before reconnecting after
+// losing its connection.
+const listenRetryDelay = 5 * time.Second
+
+// notifyJobsReady notifies listeners of the queues of the given jobs that an
+// UPDATE made due. Inside a transaction the notification is delivered on
+// commit, so listeners never wake before the jobs are visible.
+func notifyJobsReady(ctx context.Context, db dbtx, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := db.Exec(ctx, `
+		SELECT pg_notify($1, queue)
+		FROM (
+			SELECT DISTINCT queue FROM jobs
+			WHERE id = ANY($2) AND status = 'pending' AND next_run_at <= NOW()
+		) ready
+	`, jobsReadyChannel, ids)
+	if err != nil {
+		return fmt.Errorf("notify jobs ready: %w", err)
+	}
+	return nil
+}
+
+// jobListener fans the notifications received on one dedicated connection
+// out to the subscribers of each queue.
+type jobListener struct {
+	once sync.Once
+	stop context.CancelFunc
+
+	mu   sync.Mutex
+	subs map[string][]chan struct{}
+}
+
+// ListenJobs returns a channel that receives a value whenever jobs in queue
+// may have become ready. Notifications arriving faster than they are consumed
+// are coalesced. The first call opens a dedicated LISTEN connection, which is
+// re-established if it drops; the channel is closed when ctx is done.
+func (s *Store) ListenJobs(ctx context.Context, queue string) <-chan struct{} {
+	l := &s.listener
+	ch := l.subscribe(ctx, queue)
+
+	l.once.Do(func() {
+		listenCtx, stop := context.WithCancel(context.Background())
+		l.mu.Lock()
+		l.stop = stop
+		l.mu.Unlock()
+		go s.listen(listenCtx)
+	})
+
+	return ch
+}
+
+func (s *Store) listen(ctx context.Context) {
+	for {
+		err := s.listenConn(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error("Job listener disconnected, reconnecting", "error", err, "retry_in", listenRetryDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func (s *Store) listenConn(ctx context.Context) error {
+	conn, err := pgx.ConnectConfig(ctx, s.db.Config().ConnConfig.Copy())
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+jobsReadyChannel); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	logger.Info("Listening for new jobs", "channel", jobsReadyChannel)
+
+	// notifications sent while disconnected are lost
+	s.listener.wake("")
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		s.listener.wake(n.Payload)
+	}
+}
+
+// subscribe returns a channel that receives the wake-ups of queue until ctx is
+// done, when it is closed.
+func (l *jobListener) subscribe(ctx context.Context, queue string) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	if l.subs == nil {
+		l.subs = make(map[string][]chan struct{})
+	}
+	l.subs[queue] = append(l.subs[queue], ch)
+	l.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.subs[queue] = slices.DeleteFunc(l.subs[queue], func(c chan struct{}) bool { return c == ch })
+		close(ch)
+	}()
+
+	return ch
+}
+
+// wake signals the subscribers of queue, or of every queue if queue is empty.
+func (l *jobListener) wake(queue string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for q, subs := range l.subs {
+		if queue != "" && q != queue {
+			continue
+		}
+		for _, ch := range subs {
+			select {
+			case ch <- struct{}{}:
+			default:
+				// a wake-up is already pending
+			}
+		}
+	}
+}
+
+func (l *jobListener) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop != nil {
+		l.stop()
+	}
+}
@@ -0,0 +1,317 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrPartitionActive is returned when a partition cannot be removed because it
+// still holds pending or running jobs.
+var ErrPartitionActive = errors.New("partition has active jobs")
+
+// jobPartitionName matches the partitions of jobs, attached or detached.
+var jobPartitionName = regexp.MustCompile(`^jobs_(p\d{8}|legacy)$`)
+
+var partitionBound = regexp.MustCompile(`FROM \((?:MINVALUE|'([^']+)')\) TO \('([^']+)'\)`)
+
+const partitionBoundLayout = "2006-01-02 15:04:05"
+
+// JobPartition is one daily partition of the jobs table. From is nil for the
+// partition holding the jobs created before partitioning. A partition that is
+// no longer attached has no bounds.
+type JobPartition struct {
+	Name     string
+	From     *time.Time
+	To       *time.Time
+	Attached bool
+}
+
+// partitionLockID serializes replicas creating partitions at the same time.
+const partitionLockID int64 = 4_211_993_171
+
+// defaultPartition receives the jobs created on a day that has no partition
+// yet. It is not a JobPartition: it is never listed, detached or dropped.
+const defaultPartition = "jobs_default"
+
+// EnsureJobPartitions creates the daily partitions of jobs up to ahead from
+// now, filling any gap after the last partition, and returns the names of the
+// partitions it created. Jobs that landed in jobs_default because their day
+// had no partition are moved into it.
+//
+// Lookups by id alone (GetJobByID, UpdateJobStatus, ExtendLeases, CancelJob,
+// ...) cannot be pruned to one partition, so each probes the index of every
+// attached partition. Their cost grows with the number of partitions, which
+// is bounded by JOB_PARTITIONS_AHEAD_DAYS plus the retention of
+// maintenance:archive.
+func (s *Store) EnsureJobPartitions(ctx context.Context, ahead time.Duration) ([]string, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, partitionLockID); err != nil {
+		return nil, fmt.Errorf("acquire partition lock: %w", err)
+	}
+
+	var now time.Time
+	if err := tx.QueryRow(ctx, `SELECT NOW()::TIMESTAMP`).Scan(&now); err != nil {
+		return nil, fmt.Errorf("get time: %w", err)
+	}
+
+	partitions, err := listJobPartitions(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// start where the last partition ends, so the days the server missed get
+	// a partition too and their jobs leave jobs_default
+	var day time.Time
+	for _, p := range partitions {
+		if p.To != nil && p.To.After(day) {
+			day = *p.To
+		}
+	}
+	if day.IsZero() {
+		day = now.Truncate(24 * time.Hour)
+	}
+
+	var created []string
+	for end := now.Add(ahead); !day.After(end); day = day.Add(24 * time.Hour) {
+		name := "jobs_p" + day.Format("20060102")
+		if err := createJobPartition(ctx, tx, name, day, day.Add(24*time.Hour)); err != nil {
+			return nil, fmt.Errorf("create partition %s: %w", name, err)
+		}
+		created = append(created, name)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return created, nil
+}
+
+// createJobPartition creates the partition of jobs holding [from, to). A new
+// range partition cannot be attached while jobs_default has rows in its range,
+// so in that case jobs_default is detached, its rows moved through jobs into
+// the new partition and then reattached, locking jobs until the transaction
+// commits.
+func createJobPartition(ctx context.Context, tx pgx.Tx, name string, from, to time.Time) error {
+	create := fmt.Sprintf(
+		`CREATE TABLE %s PARTITION OF jobs FOR VALUES FROM ('%s') TO ('%s')`,
+		pgx.Identifier{name}.Sanitize(), from.Format(partitionBoundLayout), to.Format(partitionBoundLayout),
+	)
+
+	var stray bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM `+defaultPartition+` WHERE created_at >= $1 AND created_at < $2)
+	`, from, to).Scan(&stray)
+	if err != nil {
+		return fmt.Errorf("check %s: %w", defaultPartition, err)
+	}
+	if !stray {
+		_, err := tx.Exec(ctx, create)
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `ALTER TABLE jobs DETACH PARTITION `+defaultPartition); err != nil {
+		return fmt.Errorf("detach %s: %w", defaultPartition, err)
+	}
+	if _, err := tx.Exec(ctx, create); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		WITH moved AS (
+			DELETE FROM `+defaultPartition+` WHERE created_at >= $1 AND created_at < $2
+			RETURNING *
+		)
+		INSERT INTO jobs SELECT * FROM moved
+	`, from, to)
+	if err != nil {
+		return fmt.Errorf("move jobs from %s: %w", defaultPartition, err)
+	}
+	if _, err := tx.Exec(ctx, `ALTER TABLE jobs ATTACH PARTITION `+defaultPartition+` DEFAULT`); err != nil {
+		return fmt.Errorf("attach %s: %w", defaultPartition, err)
+	}
+	return nil
+}
+
+// ListJobPartitions returns the partitions of jobs ordered by name, including
+// detached ones that were not dropped yet.
+func (s *Store) ListJobPartitions(ctx context.Context) ([]JobPartition, error) {
+	return listJobPartitions(ctx, s.db)
+}
+
+func listJobPartitions(ctx context.Context, db dbtx) ([]JobPartition, error) {
+	rows, err := db.Query(ctx, `
+		SELECT c.relname, c.relispartition, COALESCE(pg_get_expr(c.relpartbound, c.oid), '')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'r' AND n.nspname = current_schema() AND c.relname LIKE 'jobs\_%'
+			AND (NOT c.relispartition OR EXISTS (
+				SELECT 1 FROM pg_inherits i WHERE i.inhrelid = c.oid AND i.inhparent = 'jobs'::regclass
+			))
+		ORDER BY c.relname
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list partitions: %w", err)
+	}
+	defer rows.Close()
+
+	var partitions []JobPartition
+	for rows.Next() {
+		var p JobPartition
+		var bound string
+		if err := rows.Scan(&p.Name, &p.Attached, &bound); err != nil {
+			return nil, fmt.Errorf("scan partition: %w", err)
+		}
+		if !jobPartitionName.MatchString(p.Name) {
+			continue
+		}
+		if m := partitionBound.FindStringSubmatch(bound); m != nil {
+			if m[1] != "" {
+				from, err := time.Parse(partitionBoundLayout, m[1])
+				if err != nil {
+					return nil, fmt.Errorf("parse bound of %s: %w", p.Name, err)
+				}
+				p.From = &from
+			}
+			to, err := time.Parse(partitionBoundLayout, m[2])
+			if err != nil {
+				return nil, fmt.Errorf("parse bound of %s: %w", p.Name, err)
+			}
+			p.To = &to
+		}
+		partitions = append(partitions, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list partitions: %w", err)
+	}
+
+	return partitions, nil
+}
+
+// DetachJobPartition detaches a partition from jobs. Jobs in a detached
+// partition are no longer visible to the store, except for the status of
+// workflow steps, which is copied to workflow_jobs. Partitions with pending or
+// running jobs are not detached.
+//
+// DETACH PARTITION CONCURRENTLY is refused while jobs has a default partition,
+// so the detach takes a short ACCESS EXCLUSIVE lock on jobs instead, held
+// until the check for active jobs commits with it.
+func (s *Store) DetachJobPartition(ctx context.Context, name string) error {
+	if !jobPartitionName.MatchString(name) {
+		return fmt.Errorf("partition %s: %w", name, ErrNotFound)
+	}
+	table := pgx.Identifier{name}.Sanitize()
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// createJobPartition detaches jobs_default while it moves rows
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, partitionLockID); err != nil {
+		return fmt.Errorf("acquire partition lock: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `ALTER TABLE jobs DETACH PARTITION `+table); err != nil {
+		return fmt.Errorf("detach partition %s: %w", name, err)
+	}
+
+	// checked once detached, so no job can be claimed or retried in between
+	var active bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM `+table+` WHERE status IN ('pending', 'running'))
+	`).Scan(&active)
+	if err != nil {
+		return fmt.Errorf("check partition %s: %w", name, err)
+	}
+	if active {
+		return fmt.Errorf("partition %s: %w", name, ErrPartitionActive)
+	}
+
+	// the workflows of its jobs keep their final status
+	_, err = tx.Exec(ctx, `UPDATE workflow_jobs wj SET status = j.status FROM `+table+` j WHERE wj.job_id = j.id`)
+	if err != nil {
+		return fmt.Errorf("record workflow step status: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ExportJobPartition writes the jobs of a detached partition to w as
+// newline-delimited JSON and returns how many it wrote.
+func (s *Store) ExportJobPartition(ctx context.Context, name string, w io.Writer) (int64, error) {
+	if !jobPartitionName.MatchString(name) {
+		return 0, fmt.Errorf("partition %s: %w", name, ErrNotFound)
+	}
+
+	rows, err := s.db.Query(ctx, `SELECT row_to_json(j)::TEXT FROM `+pgx.Identifier{name}.Sanitize()+` j ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("export partition %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return n, fmt.Errorf("scan job: %w", err)
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return n, fmt.Errorf("write job: %w", err)
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, fmt.Errorf("export partition %s: %w", name, err)
+	}
+
+	return n, nil
+}
+
+// DropJobPartition drops a detached partition together with the attempt
+// history and idempotency keys of its jobs.
+func (s *Store) DropJobPartition(ctx context.Context, name string) error {
+	if !jobPartitionName.MatchString(name) {
+		return fmt.Errorf("partition %s: %w", name, ErrNotFound)
+	}
+	table := pgx.Identifier{name}.Sanitize()
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var attached bool
+	err = tx.QueryRow(ctx, `SELECT relispartition FROM pg_class WHERE oid = $1::regclass`, table).Scan(&attached)
+	if err != nil {
+		return fmt.Errorf("check partition %s: %w", name, err)
+	}
+	if attached {
+		return fmt.Errorf("partition %s is still attached, detach it first", name)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM job_attempts WHERE job_id IN (SELECT id FROM `+table+`)`)
+	if err != nil {
+		return fmt.Errorf("delete attempts: %w", err)
+	}
+	_, err = tx.Exec(ctx, `DELETE FROM job_idempotency_keys WHERE job_id IN (SELECT id FROM `+table+`)`)
+	if err != nil {
+		return fmt.Errorf("delete idempotency keys: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DROP TABLE `+table); err != nil {
+		return fmt.Errorf("drop partition %s: %w", name, err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const scheduleColumns = `id, name, cron_expr, timezone, job_type, payload_template, catchup_policy, paused, next_run_at, last_run_at, created_at, updated_at`
+
+func scanSchedule(row pgx.Row) (*Schedule, error) {
+	var sc Schedule
+	err := row.Scan(
+		&sc.ID,
+		&sc.Name,
+		&sc.CronExpr,
+		&sc.Timezone,
+		&sc.JobType,
+		&sc.PayloadTemplate,
+		&sc.CatchupPolicy,
+		&sc.Paused,
+		&sc.NextRunAt,
+		&sc.LastRunAt,
+		&sc.CreatedAt,
+		&sc.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (s *Store) CreateSchedule(ctx context.Context, params CreateScheduleParams) (*Schedule, error) {
+	query := `
+		INSERT INTO schedules (name, cron_expr, timezone, job_type, payload_template, catchup_policy, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + scheduleColumns
+
+	sc, err := scanSchedule(s.db.QueryRow(ctx, query,
+		params.Name,
+		params.CronExpr,
+		params.Timezone,
+		params.JobType,
+		params.PayloadTemplate,
+		params.CatchupPolicy,
+		params.NextRunAt.UTC(),
+	))
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+		return nil, fmt.Errorf("schedule %q: %w", params.Name, ErrAlreadyExists)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("insert schedule: %w", err)
+	}
+	return sc, nil
+}
+
+func (s *Store) GetSchedule(ctx context.Context, id int64) (*Schedule, error) {
+	sc, err := scanSchedule(s.db.QueryRow(ctx, `SELECT `+scheduleColumns+` FROM schedules WHERE id = $1`, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	return sc, nil
+}
+
+func (s *Store) ListSchedules(ctx context.Context) ([]Schedule, error) {
+	rows, err := s.db.Query(ctx, `SELECT `+scheduleColumns+` FROM schedules ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("list schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []Schedule{}
+	for rows.Next() {
+		sc, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan schedule: %w", err)
+		}
+		schedules = append(schedules, *sc)
+	}
+
+	return schedules, rows.Err()
+}
+
+func (s *Store) SetSchedulePaused(ctx context.Context, id int64, paused bool, nextRunAt time.Time) (*Schedule, error) {
+	query := `
+		UPDATE schedules
+		SET paused = $1,
+			next_run_at = $2,
+			updated_at = NOW()
+		WHERE id = $3
+		RETURNING ` + scheduleColumns
+
+	sc, err := scanSchedule(s.db.QueryRow(ctx, query, paused, nextRunAt.UTC(), id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("update schedule: %w", err)
+	}
+	return sc, nil
+}
+
+func (s *Store) DeleteSchedule(ctx context.Context, id int64) error {
+	result, err := s.db.Exec(ctx, `DELETE FROM schedules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete schedule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func (s *Store) GetDueSchedules(ctx context.Context, now time.Time, limit int) ([]Schedule, error) {
+	query := `
+		SELECT ` + scheduleColumns + `
+		FROM schedules
+		WHERE paused = FALSE AND next_run_at <= $1
+		ORDER BY next_run_at ASC
+		LIMIT $2
+	`
+	rows, err := s.db.Query(ctx, query, now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("get due schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []Schedule
+	for rows.Next() {
+		sc, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan schedule: %w", err)
+		}
+		schedules = append(schedules, *sc)
+	}
+
+	return schedules, rows.Err()
+}
+
+// FireSchedule advances the schedule to nextRunAt and enqueues runs in a single
+// transaction. The update is conditioned on the schedule still having the
+// next_run_at that was read, so when several replicas race on the same tick only
+// one of them enqueues jobs; the others get false.
+func (s *Store) FireSchedule(ctx context.Context, schedule Schedule, runs []CreateJobParams, nextRunAt time.Time) (bool, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE schedules
+		SET next_run_at = $1,
+			last_run_at = CASE WHEN $2 > 0 THEN NOW() ELSE last_run_at END,
+			updated_at = NOW()
+		WHERE id = $3 AND next_run_at = $4 AND paused = FALSE
+	`, nextRunAt.UTC(), len(runs), schedule.ID, schedule.NextRunAt.UTC())
+	if err != nil {
+		return false, fmt.Errorf("advance schedule: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	for _, run := range runs {
+		if _, err := createJob(ctx, tx, run); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return true, nil
+}
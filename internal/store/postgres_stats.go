@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GetJobTypeStats reports, per job type, how many jobs finished in the last
+// params.Window and how long they waited and ran, overall and per bucket.
+// Types without activity in the window are left out.
+func (s *Store) GetJobTypeStats(ctx context.Context, params TypeStatsParams) ([]JobTypeStats, error) {
+	var start, end time.Time
+	window := fmt.Sprintf("%f seconds", params.Window.Seconds())
+	if err := s.db.QueryRow(ctx, `SELECT (NOW() - $1::INTERVAL)::TIMESTAMP, NOW()::TIMESTAMP`, window).Scan(&start, &end); err != nil {
+		return nil, fmt.Errorf("get stats window: %w", err)
+	}
+
+	bucket := params.Bucket
+	if bucket <= 0 {
+		bucket = params.Window
+	}
+	buckets := int(math.Ceil(float64(params.Window) / float64(bucket)))
+
+	byType := map[string]*JobTypeStats{}
+	statsFor := func(jobType string, b *int32) *WindowStats {
+		st, ok := byType[jobType]
+		if !ok {
+			st = &JobTypeStats{Type: jobType, Total: WindowStats{Start: start, End: end}}
+			if params.Bucket > 0 {
+				for i := 0; i < buckets; i++ {
+					bucketEnd := start.Add(time.Duration(i+1) * bucket)
+					if bucketEnd.After(end) {
+						bucketEnd = end
+					}
+					st.Buckets = append(st.Buckets, WindowStats{Start: start.Add(time.Duration(i) * bucket), End: bucketEnd})
+				}
+			}
+			byType[jobType] = st
+		}
+		if b == nil {
+			return &st.Total
+		}
+		if params.Bucket <= 0 || int(*b) >= len(st.Buckets) {
+			return nil
+		}
+		return &st.Buckets[*b]
+	}
+
+	// the grouping set (type) yields the whole window with a NULL bucket
+	finished :=
+		`
+		WITH finished AS (
+			SELECT type, status = 'completed' AS ok,
+				EXTRACT(EPOCH FROM completed_at - started_at)::FLOAT8 AS duration,
+				FLOOR(EXTRACT(EPOCH FROM completed_at - $1::TIMESTAMP) / $3)::INT AS bucket
+			FROM jobs
+			WHERE status IN ('completed', 'failed') AND completed_at >= $1 AND completed_at < $2
+				AND ($4::TEXT = '' OR type = $4)
+			UNION ALL
+			SELECT type, FALSE, NULL, FLOOR(EXTRACT(EPOCH FROM failed_at - $1::TIMESTAMP) / $3)::INT
+			FROM dead_jobs
+			WHERE failed_at >= $1 AND failed_at < $2
+				AND ($4::TEXT = '' OR type = $4)
+		)
+		SELECT type, bucket,
+			COUNT(*) FILTER (WHERE ok),
+			COUNT(*) FILTER (WHERE NOT ok),
+			COALESCE(AVG(duration), 0),
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY duration), 0)
+		FROM finished
+		GROUP BY GROUPING SETS ((type, bucket), (type))
+		`
+	rows, err := s.db.Query(ctx, finished, start, end, bucket.Seconds(), params.Type)
+	if err != nil {
+		return nil, fmt.Errorf("get finished stats: %w", err)
+	}
+	for rows.Next() {
+		var jobType string
+		var b *int32
+		var completed, failed int64
+		var avg, p95 float64
+		if err := rows.Scan(&jobType, &b, &completed, &failed, &avg, &p95); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan finished stats: %w", err)
+		}
+		if w := statsFor(jobType, b); w != nil {
+			w.Completed, w.Failed = completed, failed
+			w.AvgDuration, w.P95Duration = seconds(avg), seconds(p95)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get finished stats: %w", err)
+	}
+
+	started :=
+		`
+		WITH started AS (
+			SELECT type,
+				GREATEST(EXTRACT(EPOCH FROM started_at - next_run_at), 0)::FLOAT8 AS wait,
+				FLOOR(EXTRACT(EPOCH FROM started_at - $1::TIMESTAMP) / $3)::INT AS bucket
+			FROM jobs
+			WHERE started_at >= $1 AND started_at < $2
+				AND ($4::TEXT = '' OR type = $4)
+		)
+		SELECT type, bucket,
+			COUNT(*),
+			COALESCE(AVG(wait), 0),
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY wait), 0)
+		FROM started
+		GROUP BY GROUPING SETS ((type, bucket), (type))
+		`
+	rows, err = s.db.Query(ctx, started, start, end, bucket.Seconds(), params.Type)
+	if err != nil {
+		return nil, fmt.Errorf("get wait stats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var jobType string
+		var b *int32
+		var count int64
+		var avg, p95 float64
+		if err := rows.Scan(&jobType, &b, &count, &avg, &p95); err != nil {
+			return nil, fmt.Errorf("scan wait stats: %w", err)
+		}
+		if w := statsFor(jobType, b); w != nil {
+			w.Started = count
+			w.AvgWait, w.P95Wait = seconds(avg), seconds(p95)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get wait stats: %w", err)
+	}
+
+	stats := make([]JobTypeStats, 0, len(byType))
+	for _, st := range byType {
+		stats = append(stats, *st)
+	}
+	slices.SortFunc(stats, func(a, b JobTypeStats) int { return strings.Compare(a.Type, b.Type) })
+
+	return stats, nil
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -2,12 +2,17 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/migrations"
 )
 
 
@@ -25,9 +30,16 @@ func setupIntegrationTest(t *testing.T) *Store {
 		t.Fatalf("Failed to connect to integration DB: %v", err)
 	}
 
+	if _, err := store.Migrate(ctx); err != nil {
+		t.Fatalf("Failed to migrate integration DB: %v", err)
+	}
+	if _, err := store.EnsureJobPartitions(ctx, 24*time.Hour); err != nil {
+		t.Fatalf("Failed to create jobs partitions: %v", err)
+	}
+
 	// 🧹 Cleanup: Truncate table to ensure a clean state
 	// RESTART IDENTITY resets the ID counter to 1
-	_, err = store.db.Exec(ctx, "TRUNCATE TABLE jobs RESTART IDENTITY")
+	_, err = store.db.Exec(ctx, "TRUNCATE TABLE jobs, dead_jobs, schedules, workflows, workflow_jobs, job_dependencies, job_attempts, job_idempotency_keys RESTART IDENTITY")
 	if err != nil {
 		t.Fatalf("Failed to clean database: %v", err)
 	}
@@ -35,13 +47,31 @@ func setupIntegrationTest(t *testing.T) *Store {
 	return store
 }
 
+// claimJob marks a job running under a fresh lease, simulating a pickup by a
+// worker, and returns the lease.
+func claimJob(t *testing.T, s *Store, id int64) Lease {
+	t.Helper()
+	lease := Lease{Owner: "test-worker"}
+	err := s.db.QueryRow(context.Background(), `
+		UPDATE jobs
+		SET status = 'running', locked_by = $2, lease_token = lease_token + 1,
+			lease_expires_at = NOW() + INTERVAL '1 minute'
+		WHERE id = $1
+		RETURNING lease_token
+	`, id, lease.Owner).Scan(&lease.Token)
+	if err != nil {
+		t.Fatalf("claim job %d: %v", id, err)
+	}
+	return lease
+}
+
 func TestIntegration_CreateJob(t *testing.T) {
 	s := setupIntegrationTest(t)
 	defer s.Close()
 	ctx := context.Background()
 
 	// 1. Create a Job
-	job, err := s.CreateJob(ctx, "test:create", `{"foo": "bar"}`)
+	job, err := s.CreateJob(ctx, CreateJobParams{Type: "test:create", Payload: `{"foo": "bar"}`})
 	if err != nil {
 		t.Fatalf("CreateJob failed: %v", err)
 	}
@@ -58,6 +88,100 @@ func TestIntegration_CreateJob(t *testing.T) {
 	}
 }
 
+func TestIntegration_CreateJob_Delayed(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	runAt := time.Now().Add(1 * time.Hour)
+	job, err := s.CreateJob(ctx, CreateJobParams{Type: "test:delayed", Payload: "{}", RunAt: &runAt})
+	if err != nil {
+		t.Fatalf("CreateJob failed: %v", err)
+	}
+
+	if job.NextRunAt.Before(time.Now()) {
+		t.Errorf("Expected next_run_at in the future, got %v", job.NextRunAt)
+	}
+
+	// A delayed job must not be claimed before its run time
+	jobs, err := s.GetPendingJobs(ctx, ClaimParams{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 0 {
+		t.Errorf("Expected no claimable jobs, got %d", len(jobs))
+	}
+}
+
+func TestIntegration_CreateJob_IdempotencyKey(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	params := CreateJobParams{Type: "test:idempotent", Payload: "{}", IdempotencyKey: "order-42", IdempotencyTTL: time.Hour}
+
+	// 1. Submit the same key concurrently
+	var wg sync.WaitGroup
+	ids := make(chan int64, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job, err := s.CreateJob(ctx, params)
+			if err != nil {
+				t.Errorf("CreateJob failed: %v", err)
+				return
+			}
+			ids <- job.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	// 2. Every submission must resolve to the same job
+	var first int64
+	for id := range ids {
+		if first == 0 {
+			first = id
+		}
+		if id != first {
+			t.Errorf("Expected all submissions to return job %d, got %d", first, id)
+		}
+	}
+
+	var count int
+	s.db.QueryRow(ctx, "SELECT COUNT(*) FROM jobs").Scan(&count)
+	if count != 1 {
+		t.Errorf("Expected 1 job, got %d", count)
+	}
+
+	// 3. A repeated submission is flagged as duplicate
+	job, err := s.CreateJob(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !job.Duplicate || job.ID != first {
+		t.Errorf("Expected duplicate of job %d, got job %d (duplicate=%v)", first, job.ID, job.Duplicate)
+	}
+
+	// 4. Once the key expires it can be reused
+	params.IdempotencyKey = "order-43"
+	params.IdempotencyTTL = time.Millisecond
+	old, err := s.CreateJob(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	fresh, err := s.CreateJob(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.Duplicate || fresh.ID == old.ID {
+		t.Errorf("Expected a new job after the key expired, got job %d (duplicate=%v)", fresh.ID, fresh.Duplicate)
+	}
+}
+
 func TestIntegration_GetPendingJobs_Concurrency(t *testing.T) {
 	// 🧪 THE CRITICAL TEST: Validating "SKIP LOCKED"
 	// This proves that multiple workers won't steal each other's jobs.
@@ -68,7 +192,7 @@ func TestIntegration_GetPendingJobs_Concurrency(t *testing.T) {
 	// 1. Seed 20 jobs
 	totalJobs := 20
 	for i := 0; i < totalJobs; i++ {
-		_, err := s.CreateJob(ctx, "test:concurrent", fmt.Sprintf(`{"index": %d}`, i))
+		_, err := s.CreateJob(ctx, CreateJobParams{Type: "test:concurrent", Payload: fmt.Sprintf(`{"index": %d}`, i)})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -91,7 +215,7 @@ func TestIntegration_GetPendingJobs_Concurrency(t *testing.T) {
 			defer wg.Done()
 
 			// Simulate the Worker Loop logic
-			jobs, err := s.GetPendingJobs(ctx, jobsPerWorker)
+			jobs, err := s.GetPendingJobs(ctx, ClaimParams{Limit: jobsPerWorker})
 			if err != nil {
 				errorsCh <- fmt.Errorf("worker %d failed: %w", workerID, err)
 				return
@@ -144,17 +268,14 @@ func TestIntegration_HandleJobFailure_RetryLogic(t *testing.T) {
 	ctx := context.Background()
 
 	// 1. Create a job
-	job, _ := s.CreateJob(ctx, "test:retry", "{}")
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:retry", Payload: "{}"})
 
 	// 2. Move to 'running' manually (simulating a pickup)
-	_, err := s.db.Exec(ctx, "UPDATE jobs SET status = 'running' WHERE id = $1", job.ID)
-	if err != nil {
-		t.Fatal(err)
-	}
+	lease := claimJob(t, s, job.ID)
 
 	// 3. Fail it (Retry 1)
 	errMsg := "network timeout"
-	err = s.HandleJobFailure(ctx, job.ID, errMsg)
+	err := s.HandleJobFailure(ctx, job.ID, errMsg, DefaultRetryPolicy, lease)
 	if err != nil {
 		t.Fatalf("HandleJobFailure failed: %v", err)
 	}
@@ -194,14 +315,14 @@ func TestIntegration_HandleJobFailure_MaxRetries(t *testing.T) {
 	ctx := context.Background()
 
 	// 1. Create a job and force retry_count to 2 (Assuming Max=3)
-	job, _ := s.CreateJob(ctx, "test:max_retry", "{}")
-	_, err := s.db.Exec(ctx, "UPDATE jobs SET status = 'running', retry_count = 2 WHERE id = $1", job.ID)
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:max_retry", Payload: "{}"})
+	_, err := s.db.Exec(ctx, "UPDATE jobs SET retry_count = 2 WHERE id = $1", job.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// 2. Fail it (This should be the 3rd strike)
-	err = s.HandleJobFailure(ctx, job.ID, "fatal error")
+	err = s.HandleJobFailure(ctx, job.ID, "fatal error", DefaultRetryPolicy, claimJob(t, s, job.ID))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -216,3 +337,1091 @@ func TestIntegration_HandleJobFailure_MaxRetries(t *testing.T) {
 		t.Errorf("Expected retry_count 3, got %d", deadJob.RetryCount)
 	}
 }
+
+func TestIntegration_HandleJobFailure_RetryPolicy(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:policy", Payload: "{}"})
+	policy := RetryPolicy{MaxAttempts: 2, BaseDelay: time.Hour, Backoff: BackoffFixed}
+
+	// 1. The first failure waits the policy's delay
+	before := time.Now()
+	if err := s.HandleJobFailure(ctx, job.ID, "boom", policy, claimJob(t, s, job.ID)); err != nil {
+		t.Fatal(err)
+	}
+	updated, _ := s.GetJobByID(ctx, job.ID)
+	if updated.NextRunAt.Before(before.Add(59 * time.Minute)) {
+		t.Errorf("Expected next_run_at about an hour from now, got %v", updated.NextRunAt)
+	}
+
+	// 2. The policy's MaxAttempts wins over the max_retries column
+	if err := s.HandleJobFailure(ctx, job.ID, "boom", policy, claimJob(t, s, job.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetJobByID(ctx, job.ID); err == nil {
+		t.Error("Expected job to be dead-lettered after 2 attempts")
+	}
+}
+
+func TestIntegration_Leases(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. Claiming records the owner and the lease
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:lease", Payload: "{}"})
+	alive, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:lease", Payload: "{}"})
+	jobs, err := s.GetPendingJobs(ctx, ClaimParams{Limit: 10, Owner: "worker-a", LeaseDuration: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 || jobs[0].LockedBy.String != "worker-a" || jobs[0].LeaseExpiresAt == nil {
+		t.Fatalf("Expected 2 jobs leased to worker-a, got %+v", jobs)
+	}
+	oldLease := jobs[0].Lease()
+	if jobs[0].ID != job.ID {
+		oldLease = jobs[1].Lease()
+	}
+
+	// 2. Only the expired lease is reclaimed, even though both jobs have been running as long
+	s.db.Exec(ctx, `UPDATE jobs SET lease_expires_at = NOW() - INTERVAL '1 second' WHERE id = $1`, job.ID)
+	count, err := s.ReclaimExpiredJobs(ctx, func(Job) RetryPolicy { return RetryPolicy{MaxAttempts: 3} })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 reclaimed job, got %d", count)
+	}
+	got, _ := s.GetJobByID(ctx, alive.ID)
+	if got.Status != JobStatusRunning {
+		t.Errorf("Expected the heartbeated job to keep running, got '%s'", got.Status)
+	}
+
+	// 3. The old owner can neither heartbeat nor finish the reclaimed job
+	jobs, _ = s.GetPendingJobs(ctx, ClaimParams{Limit: 10, Owner: "worker-b", LeaseDuration: time.Minute})
+	if len(jobs) != 1 || jobs[0].ID != job.ID {
+		t.Fatalf("Expected worker-b to claim job %d, got %+v", job.ID, jobs)
+	}
+	lost, err := s.ExtendLeases(ctx, map[int64]Lease{job.ID: oldLease}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lost) != 1 || lost[0] != job.ID {
+		t.Errorf("Expected job %d to be reported lost, got %v", job.ID, lost)
+	}
+	if err := s.UpdateJobStatus(ctx, JobStatusCompleted, job.ID, oldLease); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost, got %v", err)
+	}
+	if err := s.HandleJobFailure(ctx, job.ID, "late", DefaultRetryPolicy, oldLease); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost, got %v", err)
+	}
+
+	// 4. The new owner's result sticks
+	if err := s.UpdateJobStatus(ctx, JobStatusCompleted, job.ID, jobs[0].Lease()); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = s.GetJobByID(ctx, job.ID)
+	if got.Status != JobStatusCompleted || got.LockedBy.Valid {
+		t.Errorf("Expected job completed with its lease released, got '%s' locked by %v", got.Status, got.LockedBy)
+	}
+}
+
+func TestIntegration_JobAttempts(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+	claim := ClaimParams{Limit: 1, Owner: "worker-a", LeaseDuration: time.Minute}
+	policy := RetryPolicy{MaxAttempts: 4}
+
+	// 1. Every claim opens an attempt; failures close it with their error
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:attempts", Payload: "{}"})
+	jobs, _ := s.GetPendingJobs(ctx, claim)
+	if err := s.HandleJobFailure(ctx, job.ID, "resend: 503", policy, jobs[0].Lease()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2. A reclaimed attempt is closed as lease_expired and counts as a failure
+	s.db.Exec(ctx, `UPDATE jobs SET next_run_at = NOW() WHERE id = $1`, job.ID)
+	jobs, _ = s.GetPendingJobs(ctx, claim)
+	s.db.Exec(ctx, `UPDATE jobs SET lease_expires_at = NOW() - INTERVAL '1 second' WHERE id = $1`, job.ID)
+	if _, err := s.ReclaimExpiredJobs(ctx, func(Job) RetryPolicy { return policy }); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.GetJobByID(ctx, job.ID); got.RetryCount != 2 {
+		t.Errorf("Expected the reclaim to count as attempt 2, got retry count %d", got.RetryCount)
+	}
+
+	// 3. The last failure dead-letters the job, which keeps its history
+	claim.Owner = "worker-b"
+	jobs, _ = s.GetPendingJobs(ctx, claim)
+	s.HandleJobFailure(ctx, job.ID, "resend: 429", policy, jobs[0].Lease())
+	s.db.Exec(ctx, `UPDATE jobs SET next_run_at = NOW() WHERE id = $1`, job.ID)
+	jobs, _ = s.GetPendingJobs(ctx, claim)
+	if err := s.HandleJobFailure(ctx, job.ID, "resend: 500", policy, jobs[0].Lease()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetJobByID(ctx, job.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected job %d to be dead-lettered, got %v", job.ID, err)
+	}
+
+	attempts, err := s.ListJobAttempts(ctx, job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		outcome AttemptOutcome
+		worker  string
+		err     string
+	}{
+		{AttemptFailed, "worker-a", "resend: 503"},
+		{AttemptLeaseExpired, "worker-a", "job lease expired (worker lost)"},
+		{AttemptFailed, "worker-b", "resend: 429"},
+		{AttemptFailed, "worker-b", "resend: 500"},
+	}
+	if len(attempts) != len(want) {
+		t.Fatalf("Expected %d attempts, got %+v", len(want), attempts)
+	}
+	for i, w := range want {
+		a := attempts[i]
+		if a.Attempt != i+1 || a.Outcome != w.outcome || a.Worker != w.worker || a.Error.String != w.err || a.FinishedAt == nil {
+			t.Errorf("Expected attempt %d to be %+v, got %+v", i+1, w, a)
+		}
+	}
+
+	// 4. Unknown jobs are reported as not found, unattempted ones have no history
+	if _, err := s.ListJobAttempts(ctx, 9999); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	later := time.Now().Add(time.Hour)
+	fresh, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:attempts", Payload: "{}", RunAt: &later})
+	if attempts, err := s.ListJobAttempts(ctx, fresh.ID); err != nil || len(attempts) != 0 {
+		t.Errorf("Expected no attempts, got %v, %v", attempts, err)
+	}
+}
+
+func TestIntegration_ListenJobs(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	media := s.ListenJobs(ctx, "media")
+	other := s.ListenJobs(ctx, "other")
+
+	// 1. Connecting wakes every queue, since notifications may have been missed
+	for _, ch := range []<-chan struct{}{media, other} {
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected a wake-up once the listener is connected")
+		}
+	}
+
+	// 2. A due job wakes only its queue
+	s.CreateJob(ctx, CreateJobParams{Type: "test:listen", Payload: "{}", Queue: "media"})
+	select {
+	case <-media:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the media queue to be notified")
+	}
+
+	// 3. A delayed job is left to polling
+	later := time.Now().Add(time.Hour)
+	s.CreateJob(ctx, CreateJobParams{Type: "test:listen", Payload: "{}", Queue: "media", RunAt: &later})
+	select {
+	case <-other:
+		t.Error("Expected no notification for an unrelated queue")
+	case <-media:
+		t.Error("Expected the delayed job not to notify")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestIntegration_CompleteJob_Result(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. Completing with a result stores it
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:result", Payload: "{}"})
+	result := json.RawMessage(`{"object_path": "secure/invoices/inv_001.pdf"}`)
+	if err := s.CompleteJob(ctx, job.ID, claimJob(t, s, job.ID), result); err != nil {
+		t.Fatalf("CompleteJob failed: %v", err)
+	}
+
+	got, _ := s.GetJobByID(ctx, job.ID)
+	if got.Status != JobStatusCompleted {
+		t.Errorf("Expected status 'completed', got '%s'", got.Status)
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal(got.Result, &decoded); err != nil || decoded["object_path"] != "secure/invoices/inv_001.pdf" {
+		t.Errorf("Expected the result to round-trip, got %s", got.Result)
+	}
+
+	// 2. Completing without a result leaves it empty
+	other, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:result", Payload: "{}"})
+	s.CompleteJob(ctx, other.ID, claimJob(t, s, other.ID), nil)
+	got, _ = s.GetJobByID(ctx, other.ID)
+	if got.Result != nil {
+		t.Errorf("Expected no result, got %s", got.Result)
+	}
+}
+
+func TestIntegration_UpdateJobProgress(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:progress", Payload: "{}"})
+	lease := claimJob(t, s, job.ID)
+
+	// 1. The lease holder's progress is stored
+	if err := s.UpdateJobProgress(ctx, job.ID, lease, 40, "exporting 4000 jobs"); err != nil {
+		t.Fatalf("UpdateJobProgress failed: %v", err)
+	}
+	got, _ := s.GetJobByID(ctx, job.ID)
+	if got.Progress.Int32 != 40 || got.ProgressMessage.String != "exporting 4000 jobs" || got.ProgressUpdatedAt == nil {
+		t.Errorf("Expected progress 40%% 'exporting 4000 jobs', got %v %v", got.Progress, got.ProgressMessage)
+	}
+
+	// 2. A stale lease cannot report progress
+	stale := lease
+	stale.Token--
+	if err := s.UpdateJobProgress(ctx, job.ID, stale, 90, ""); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost, got %v", err)
+	}
+
+	// 3. A new attempt starts without progress
+	s.HandleJobFailure(ctx, job.ID, "boom", RetryPolicy{MaxAttempts: 5}, lease)
+	s.db.Exec(ctx, `UPDATE jobs SET next_run_at = NOW() WHERE id = $1`, job.ID)
+	jobs, _ := s.GetPendingJobs(ctx, ClaimParams{Limit: 1, Owner: "test-worker", LeaseDuration: time.Minute})
+	if len(jobs) != 1 || jobs[0].Progress.Valid {
+		t.Errorf("Expected the retried job to be claimed without progress, got %+v", jobs)
+	}
+}
+
+func TestIntegration_CreateJobs(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	existing, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:batch", Payload: "{}", IdempotencyKey: "order-1", IdempotencyTTL: time.Hour})
+
+	// 1. A batch mixing plain jobs and idempotency keys
+	jobs, err := s.CreateJobs(ctx, []CreateJobParams{
+		{Type: "test:batch", Payload: `{"n": 1}`},
+		{Type: "test:batch", Payload: `{"n": 2}`, IdempotencyKey: "order-1", IdempotencyTTL: time.Hour},
+		{Type: "test:batch", Payload: `{"n": 3}`, Queue: "media", Priority: 7, Timeout: time.Minute},
+		{Type: "test:batch", Payload: `{"n": 4}`, RetryPolicy: &RetryPolicy{MaxAttempts: 9}},
+	})
+	if err != nil {
+		t.Fatalf("CreateJobs failed: %v", err)
+	}
+	if len(jobs) != 4 {
+		t.Fatalf("Expected 4 jobs, got %d", len(jobs))
+	}
+
+	// 2. Results come back in input order
+	if jobs[0].Payload != `{"n": 1}` || jobs[2].Payload != `{"n": 3}` || jobs[3].Payload != `{"n": 4}` {
+		t.Errorf("Expected jobs in input order, got %s, %s, %s", jobs[0].Payload, jobs[2].Payload, jobs[3].Payload)
+	}
+	if jobs[2].Queue != "media" || jobs[2].Priority != 7 || jobs[2].Timeout() != time.Minute {
+		t.Errorf("Expected queue, priority and timeout to be stored, got %+v", jobs[2])
+	}
+	if jobs[3].RetryPolicy == nil || jobs[3].RetryPolicy.MaxAttempts != 9 {
+		t.Errorf("Expected the retry policy to be stored, got %+v", jobs[3].RetryPolicy)
+	}
+
+	// 3. A repeated idempotency key returns the existing job
+	if !jobs[1].Duplicate || jobs[1].ID != existing.ID {
+		t.Errorf("Expected job %d as a duplicate, got %d (duplicate=%v)", existing.ID, jobs[1].ID, jobs[1].Duplicate)
+	}
+}
+
+func TestIntegration_ListJobs_Filters(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	email1, _ := s.CreateJob(ctx, CreateJobParams{Type: "notification:email", Payload: "{}"})
+	email2, _ := s.CreateJob(ctx, CreateJobParams{Type: "notification:email", Payload: "{}"})
+	invoice, _ := s.CreateJob(ctx, CreateJobParams{Type: "finance:invoice", Payload: "{}"})
+	s.db.Exec(ctx, `UPDATE jobs SET status = 'pending', retry_count = 2, last_err = 'resend: 429 rate limited' WHERE id = $1`, email1.ID)
+	s.db.Exec(ctx, `UPDATE jobs SET status = 'completed', completed_at = NOW() WHERE id = $1`, email2.ID)
+	s.db.Exec(ctx, `UPDATE jobs SET status = 'failed', retry_count = 1, completed_at = NOW() - INTERVAL '2 days', created_at = NOW() - INTERVAL '3 days' WHERE id = $1`, invoice.ID)
+
+	ids := func(page *PaginatedJobs) []int64 {
+		var ids []int64
+		for _, j := range page.Jobs {
+			ids = append(ids, j.ID)
+		}
+		return ids
+	}
+	yesterday := time.Now().UTC().Add(-24 * time.Hour)
+	one := 1
+
+	tests := []struct {
+		name   string
+		filter JobFilter
+		sort   JobSort
+		want   []int64
+	}{
+		{"all, newest first", JobFilter{}, JobSort{}, []int64{email2.ID, email1.ID, invoice.ID}},
+		{"statuses", JobFilter{Statuses: []JobStatus{JobStatusCompleted, JobStatusFailed}}, JobSort{}, []int64{email2.ID, invoice.ID}},
+		{"type", JobFilter{Type: "notification:email"}, JobSort{Ascending: true}, []int64{email1.ID, email2.ID}},
+		{"created range", JobFilter{CreatedBefore: &yesterday}, JobSort{}, []int64{invoice.ID}},
+		{"completed range", JobFilter{CompletedAfter: &yesterday}, JobSort{}, []int64{email2.ID}},
+		{"retry count", JobFilter{MinRetries: &one}, JobSort{Field: SortByRetryCount}, []int64{email1.ID, invoice.ID}},
+		{"error substring", JobFilter{ErrorContains: "rate limited"}, JobSort{}, []int64{email1.ID}},
+		{"sort by completion", JobFilter{}, JobSort{Field: SortByCompletedAt, Ascending: true}, []int64{invoice.ID, email2.ID, email1.ID}},
+	}
+	for _, tt := range tests {
+		page, err := s.ListJobs(ctx, tt.filter, tt.sort, PageParams{Limit: 10})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := ids(page); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Expected jobs %v, got %v", tt.name, tt.want, got)
+		}
+		if page.Meta.TotalRecords != int64(len(tt.want)) {
+			t.Errorf("%s: Expected %d total records, got %d", tt.name, len(tt.want), page.Meta.TotalRecords)
+		}
+	}
+
+	// Dead jobs take the same filters
+	s.db.Exec(ctx, `INSERT INTO dead_jobs (id, type, payload, last_err, retry_count) VALUES (100, 'notification:email', '{}', 'resend: 500', 3), (101, 'finance:invoice', '{}', 'minio: timeout', 5)`)
+	three := 3
+	page, err := s.ListDeadJobs(ctx, DeadJobFilter{Type: "finance:invoice", MinRetries: &three}, JobSort{Field: SortByRetryCount}, PageParams{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(page); !slices.Equal(got, []int64{101}) {
+		t.Errorf("Expected dead job 101, got %v", got)
+	}
+}
+
+func TestIntegration_ListJobs_PageToken(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// five jobs sharing one creation time, so only the id breaks ties
+	var want []int64
+	for i := 0; i < 5; i++ {
+		job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:page", Payload: "{}"})
+		want = append([]int64{job.ID}, want...)
+	}
+	s.db.Exec(ctx, `UPDATE jobs SET created_at = '2026-01-30 09:00:00'`)
+
+	// 1. Walking the tokens returns every job once, newest first
+	var got []int64
+	page, err := s.ListJobs(ctx, JobFilter{}, JobSort{}, PageParams{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Meta.TotalRecords != 5 || page.Meta.TotalCount != TotalExact {
+		t.Errorf("Expected an exact total of 5 on the first page, got %+v", page.Meta)
+	}
+	for {
+		for _, j := range page.Jobs {
+			got = append(got, j.ID)
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		page, err = s.ListJobs(ctx, JobFilter{}, JobSort{}, PageParams{Limit: 2, PageToken: page.NextPageToken})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.Meta.TotalCount != TotalNone || page.Meta.CurrentPage != 0 {
+			t.Errorf("Expected token pages not to be counted, got %+v", page.Meta)
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected jobs %v, got %v", want, got)
+	}
+
+	// 2. A token only continues the sort it was issued for
+	page, _ = s.ListJobs(ctx, JobFilter{}, JobSort{}, PageParams{Limit: 2})
+	_, err = s.ListJobs(ctx, JobFilter{}, JobSort{Ascending: true}, PageParams{Limit: 2, PageToken: page.NextPageToken})
+	if !errors.Is(err, ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken, got %v", err)
+	}
+	if _, err := s.ListJobs(ctx, JobFilter{}, JobSort{}, PageParams{Limit: 2, PageToken: "garbage"}); !errors.Is(err, ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken, got %v", err)
+	}
+
+	// 3. The total can be estimated instead of counted
+	page, err = s.ListJobs(ctx, JobFilter{}, JobSort{}, PageParams{Limit: 2, Total: TotalEstimated})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Meta.TotalCount != TotalEstimated {
+		t.Errorf("Expected an estimated total, got %+v", page.Meta)
+	}
+}
+
+func TestIntegration_FireSchedule_OnlyOnce(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	due := time.Now().Add(-1 * time.Minute).Truncate(time.Minute)
+	sc, err := s.CreateSchedule(ctx, CreateScheduleParams{
+		Name:            "test-nightly",
+		CronExpr:        "* * * * *",
+		Timezone:        "UTC",
+		JobType:         "test:scheduled",
+		PayloadTemplate: "{}",
+		CatchupPolicy:   CatchupSkip,
+		NextRunAt:       due,
+	})
+	if err != nil {
+		t.Fatalf("CreateSchedule failed: %v", err)
+	}
+
+	runs := []CreateJobParams{{Type: "test:scheduled", Payload: "{}"}}
+	next := due.Add(1 * time.Minute)
+
+	// Two replicas read the same schedule and try to fire the same tick
+	fired, err := s.FireSchedule(ctx, *sc, runs, next)
+	if err != nil || !fired {
+		t.Fatalf("Expected first fire to succeed, got fired=%v err=%v", fired, err)
+	}
+	fired, err = s.FireSchedule(ctx, *sc, runs, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fired {
+		t.Error("Expected second fire of the same tick to be rejected")
+	}
+
+	var count int
+	if err := s.db.QueryRow(ctx, "SELECT COUNT(*) FROM jobs WHERE type = 'test:scheduled'").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 materialized job, got %d", count)
+	}
+}
+
+func TestIntegration_GetPendingJobs_Priority(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// A burst of low-priority jobs followed by one urgent job
+	for i := 0; i < 5; i++ {
+		if _, err := s.CreateJob(ctx, CreateJobParams{Type: "test:bulk", Payload: "{}"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	urgent, err := s.CreateJob(ctx, CreateJobParams{Type: "test:urgent", Payload: "{}", Priority: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := s.GetPendingJobs(ctx, ClaimParams{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != urgent.ID {
+		t.Errorf("Expected the high-priority job %d to be claimed first, got %+v", urgent.ID, jobs)
+	}
+}
+
+func TestIntegration_GetPendingJobs_PriorityAging(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	old, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:old", Payload: "{}"})
+	_, err := s.db.Exec(ctx, "UPDATE jobs SET next_run_at = NOW() - INTERVAL '1 hour' WHERE id = $1", old.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CreateJob(ctx, CreateJobParams{Type: "test:new", Payload: "{}", Priority: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Waiting an hour with one point per minute outranks a priority of 5
+	jobs, err := s.GetPendingJobs(ctx, ClaimParams{Limit: 1, PriorityAging: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != old.ID {
+		t.Errorf("Expected the aged job %d to be claimed first, got %+v", old.ID, jobs)
+	}
+}
+
+func TestIntegration_GetPendingJobs_Queue(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	media, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:media", Payload: "{}", Queue: "media"})
+	def, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:default", Payload: "{}"})
+
+	if def.Queue != DefaultQueue {
+		t.Errorf("Expected queue '%s', got '%s'", DefaultQueue, def.Queue)
+	}
+
+	jobs, err := s.GetPendingJobs(ctx, ClaimParams{Queue: "media", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != media.ID {
+		t.Errorf("Expected only the media job to be claimed, got %+v", jobs)
+	}
+}
+
+func TestIntegration_Workflow_Dependencies(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. invoice -> email, both claimable by time
+	wf, err := s.CreateWorkflow(ctx, CreateWorkflowParams{
+		Name: "invoice-and-email",
+		Steps: []CreateWorkflowStepParams{
+			{Key: "invoice", Job: CreateJobParams{Type: "test:invoice", Payload: "{}"}},
+			{Key: "email", DependsOn: []string{"invoice"}, Job: CreateJobParams{Type: "test:email", Payload: "{}"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateWorkflow failed: %v", err)
+	}
+
+	// 2. Only the parent is claimable
+	jobs, err := s.GetPendingJobs(ctx, ClaimParams{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Type != "test:invoice" {
+		t.Fatalf("Expected only the invoice job to be claimed, got %v", jobs)
+	}
+
+	// 3. Completing the parent releases the child
+	if err := s.UpdateJobStatus(ctx, JobStatusCompleted, jobs[0].ID, jobs[0].Lease()); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err = s.GetPendingJobs(ctx, ClaimParams{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].Type != "test:email" {
+		t.Fatalf("Expected the email job to be claimed, got %v", jobs)
+	}
+
+	got, err := s.GetWorkflow(ctx, wf.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status() != WorkflowStatusRunning {
+		t.Errorf("Expected workflow status 'running', got '%s'", got.Status())
+	}
+	if len(got.Steps) != 2 || len(got.Steps[1].DependsOn) != 1 || got.Steps[1].DependsOn[0] != "invoice" {
+		t.Errorf("Expected email to depend on invoice, got %+v", got.Steps)
+	}
+}
+
+func TestIntegration_Workflow_SkipOnDeadParent(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. resize -> notify -> audit
+	wf, err := s.CreateWorkflow(ctx, CreateWorkflowParams{
+		Name: "resize-and-notify",
+		Steps: []CreateWorkflowStepParams{
+			{Key: "resize", Job: CreateJobParams{Type: "test:resize", Payload: "{}"}},
+			{Key: "notify", DependsOn: []string{"resize"}, Job: CreateJobParams{Type: "test:notify", Payload: "{}"}},
+			{Key: "audit", DependsOn: []string{"notify"}, Job: CreateJobParams{Type: "test:audit", Payload: "{}"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateWorkflow failed: %v", err)
+	}
+	parentID := wf.Steps[0].JobID
+
+	// 2. Fail the parent until it is dead-lettered
+	for i := 0; i < 3; i++ {
+		if err := s.HandleJobFailure(ctx, parentID, "boom", DefaultRetryPolicy, claimJob(t, s, parentID)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 3. Every dependant is skipped with a reason
+	got, err := s.GetWorkflow(ctx, wf.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Steps[0].Status != JobStatusDead {
+		t.Errorf("Expected parent status 'dead', got '%s'", got.Steps[0].Status)
+	}
+	for _, step := range got.Steps[1:] {
+		if step.Status != JobStatusSkipped {
+			t.Errorf("Expected %s to be skipped, got '%s'", step.Key, step.Status)
+		}
+		if !step.ErrorMessage.Valid {
+			t.Errorf("Expected %s to record a skip reason", step.Key)
+		}
+	}
+	if got.Status() != WorkflowStatusFailed {
+		t.Errorf("Expected workflow status 'failed', got '%s'", got.Status())
+	}
+}
+
+func TestIntegration_CancelJob(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	pending, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:cancel", Payload: "{}"})
+
+	// 1. A cancelled pending job is never claimed
+	job, err := s.CancelJob(ctx, pending.ID)
+	if err != nil {
+		t.Fatalf("CancelJob failed: %v", err)
+	}
+	if job.Status != JobStatusCancelled {
+		t.Errorf("Expected status 'cancelled', got '%s'", job.Status)
+	}
+	jobs, _ := s.GetPendingJobs(ctx, ClaimParams{Limit: 10})
+	if len(jobs) != 0 {
+		t.Errorf("Expected no claimable jobs, got %d", len(jobs))
+	}
+
+	// 2. A running job is reported as cancelled and neither retried nor completed
+	running, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:cancel", Payload: "{}"})
+	lease := claimJob(t, s, running.ID)
+	if _, err := s.CancelJob(ctx, running.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := s.GetCancelledJobIDs(ctx, []int64{running.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != running.ID {
+		t.Errorf("Expected job %d to be reported as cancelled, got %v", running.ID, ids)
+	}
+
+	s.HandleJobFailure(ctx, running.ID, "context canceled", DefaultRetryPolicy, lease)
+	s.UpdateJobStatus(ctx, JobStatusCompleted, running.ID, lease)
+	got, _ := s.GetJobByID(ctx, running.ID)
+	if got.Status != JobStatusCancelled || got.RetryCount != 0 {
+		t.Errorf("Expected job to stay cancelled without retries, got status '%s' retries %d", got.Status, got.RetryCount)
+	}
+
+	// 3. A finished job cannot be cancelled
+	if _, err := s.CancelJob(ctx, running.ID); !errors.Is(err, ErrJobFinished) {
+		t.Errorf("Expected ErrJobFinished, got %v", err)
+	}
+}
+
+func TestIntegration_RetryDeadJob(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. Dead-letter two jobs
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:dlq", Payload: `{"v": 1}`, Queue: "media", Priority: 5})
+	other, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:dlq-other", Payload: "{}"})
+	for i := 0; i < 3; i++ {
+		s.HandleJobFailure(ctx, job.ID, "upstream timeout", DefaultRetryPolicy, claimJob(t, s, job.ID))
+		s.HandleJobFailure(ctx, other.ID, "bad input", DefaultRetryPolicy, claimJob(t, s, other.ID))
+	}
+
+	// 2. Replay one with an edited payload
+	payload := `{"v": 2}`
+	replayed, err := s.RetryDeadJob(ctx, job.ID, &payload, nil)
+	if err != nil {
+		t.Fatalf("RetryDeadJob failed: %v", err)
+	}
+	if replayed.ID != job.ID || replayed.Status != JobStatusPending || replayed.RetryCount != 0 {
+		t.Errorf("Expected job %d pending with 0 retries, got %+v", job.ID, replayed)
+	}
+	if replayed.ReplayCount != 1 || replayed.ReplayedAt == nil {
+		t.Errorf("Expected the replay to be recorded, got count %d", replayed.ReplayCount)
+	}
+	if replayed.Queue != "media" || replayed.Priority != 5 {
+		t.Errorf("Expected queue and priority to be restored, got %s/%d", replayed.Queue, replayed.Priority)
+	}
+	if replayed.Payload != `{"v": 2}` {
+		t.Errorf("Expected edited payload, got %s", replayed.Payload)
+	}
+
+	// 3. Replay by filter only matches the other job
+	ids, err := s.RetryDeadJobs(ctx, DeadJobFilter{ErrorContains: "bad input"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != other.ID {
+		t.Errorf("Expected job %d to be requeued, got %v", other.ID, ids)
+	}
+
+	if _, err := s.RetryDeadJob(ctx, job.ID, nil, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for a job that is no longer dead, got %v", err)
+	}
+}
+
+func TestIntegration_GetStats(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. One job in each terminal state, plus a dead-lettered one
+	for _, status := range []JobStatus{JobStatusPending, JobStatusCompleted, JobStatusFailed, JobStatusCancelled, JobStatusSkipped} {
+		job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:stats", Payload: "{}"})
+		s.db.Exec(ctx, `UPDATE jobs SET status = $1 WHERE id = $2`, status, job.ID)
+	}
+	dead, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:stats", Payload: "{}"})
+	lease := claimJob(t, s, dead.ID)
+	s.HandleJobFailure(ctx, dead.ID, "boom", RetryPolicy{MaxAttempts: 1}, lease)
+
+	// 2. Every status is counted once
+	stats, err := s.GetStats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := JobStats{Pending: 1, Completed: 1, Failed: 1, Cancelled: 1, Skipped: 1, Dead: 1}
+	if *stats != want {
+		t.Errorf("Expected %+v, got %+v", want, *stats)
+	}
+	if stats.Total() != 6 {
+		t.Errorf("Expected 6 jobs in total, got %d", stats.Total())
+	}
+}
+
+func TestIntegration_GetJobTypeStats(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. Three finished email jobs: two completed, one failed, waiting 1s and running 2s
+	for _, status := range []JobStatus{JobStatusCompleted, JobStatusCompleted, JobStatusFailed} {
+		job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:email", Payload: "{}"})
+		s.db.Exec(ctx, `
+			UPDATE jobs SET status = $1,
+				next_run_at = NOW() - INTERVAL '3 seconds',
+				started_at = NOW() - INTERVAL '2 seconds',
+				completed_at = NOW()
+			WHERE id = $2
+		`, status, job.ID)
+	}
+
+	// 2. An old job and a job of another type
+	old, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:email", Payload: "{}"})
+	s.db.Exec(ctx, `UPDATE jobs SET status = 'completed', started_at = NOW() - INTERVAL '2 hours', completed_at = NOW() - INTERVAL '2 hours' WHERE id = $1`, old.ID)
+	other, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:sms", Payload: "{}"})
+	s.db.Exec(ctx, `UPDATE jobs SET status = 'completed', started_at = NOW(), completed_at = NOW() WHERE id = $1`, other.ID)
+
+	stats, err := s.GetJobTypeStats(ctx, TypeStatsParams{Type: "test:email", Window: time.Hour, Bucket: 10 * time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 || stats[0].Type != "test:email" {
+		t.Fatalf("Expected stats for test:email only, got %+v", stats)
+	}
+
+	// 3. The window only counts recent jobs
+	total := stats[0].Total
+	if total.Completed != 2 || total.Failed != 1 || total.Started != 3 {
+		t.Errorf("Expected 2 completed, 1 failed, 3 started, got %+v", total)
+	}
+	if total.AvgDuration.Round(time.Second) != 2*time.Second || total.AvgWait.Round(time.Second) != time.Second {
+		t.Errorf("Expected 2s runs after 1s waits, got %s and %s", total.AvgDuration, total.AvgWait)
+	}
+	if ratio := total.SuccessRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("Expected a success ratio of 2/3, got %f", ratio)
+	}
+
+	// 4. The window is split into buckets, and only the last one saw jobs
+	buckets := stats[0].Buckets
+	if len(buckets) != 6 {
+		t.Fatalf("Expected 6 buckets, got %d", len(buckets))
+	}
+	if last := buckets[5]; last.Completed != 2 || last.Failed != 1 {
+		t.Errorf("Expected the last bucket to hold every job, got %+v", last)
+	}
+	if first := buckets[0]; first.Completed != 0 || first.Started != 0 {
+		t.Errorf("Expected the first bucket to be empty, got %+v", first)
+	}
+}
+
+func TestIntegration_Migrate(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. A migrated database has nothing left to apply
+	applied, err := s.Migrate(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Fatalf("Expected no pending migrations, got %v, %v", applied, err)
+	}
+	states, err := s.MigrationStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, st := range states {
+		if st.AppliedAt == nil {
+			t.Errorf("Expected migration %d to be applied", st.Version)
+		}
+	}
+
+	// 2. Replicas migrating concurrently wait for each other instead of failing
+	s.MigrateDown(ctx, 1)
+	var wg sync.WaitGroup
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Migrate(ctx)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected concurrent migrations to succeed, got %v", err)
+		}
+	}
+	states, _ = s.MigrationStatus(ctx)
+	if last := states[len(states)-1]; last.AppliedAt == nil {
+		t.Errorf("Expected migration %d to be applied again", last.Version)
+	}
+}
+
+func TestIntegration_MigrateSchemaSQL(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	all, err := loadMigrations(migrations.Postgres)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1. A database created from the original schema.sql has no schema_migrations
+	_, err = s.db.Exec(ctx, `DROP SCHEMA public CASCADE; CREATE SCHEMA public`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.Exec(ctx, all[0].Up); err != nil {
+		t.Fatal(err)
+	}
+	var id int64
+	err = s.db.QueryRow(ctx, `INSERT INTO jobs (type, payload) VALUES ('test:legacy', '{}') RETURNING id`).Scan(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2. It is baselined at version 1 and upgraded in place, keeping its jobs
+	applied, err := s.Migrate(ctx)
+	if err != nil {
+		t.Fatalf("Expected the original schema to migrate, got %v", err)
+	}
+	if len(applied) != len(all)-1 || applied[0].Version != all[1].Version {
+		t.Errorf("Expected every migration after 1 to be applied, got %d", len(applied))
+	}
+	job, err := s.GetJobByID(ctx, id)
+	if err != nil || job.Type != "test:legacy" || job.Queue != DefaultQueue {
+		t.Errorf("Expected the legacy job to survive with defaults, got %+v, %v", job, err)
+	}
+}
+
+func TestIntegration_JobPartitions(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. Partitions are created ahead once; new jobs land in today's
+	s.EnsureJobPartitions(ctx, 72*time.Hour)
+	if created, err := s.EnsureJobPartitions(ctx, 72*time.Hour); err != nil || len(created) != 0 {
+		t.Errorf("Expected partitions to exist already, got %v, %v", created, err)
+	}
+	job, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:partition", Payload: "{}"})
+	var partition string
+	s.db.QueryRow(ctx, `SELECT tableoid::regclass::TEXT FROM jobs WHERE id = $1`, job.ID).Scan(&partition)
+	if want := "jobs_p" + time.Now().UTC().Format("20060102"); partition != want {
+		t.Errorf("Expected job in %s, got %s", want, partition)
+	}
+
+	// 2. Old jobs move to the legacy partition, which is kept while a job is pending
+	old, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:partition", Payload: "{}", IdempotencyKey: "old", IdempotencyTTL: time.Hour})
+	pending, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:partition", Payload: "{}"})
+	s.db.Exec(ctx, `UPDATE jobs SET created_at = '2000-01-01', status = 'completed' WHERE id = $1`, old.ID)
+	s.db.Exec(ctx, `UPDATE jobs SET created_at = '2000-01-02' WHERE id = $1`, pending.ID)
+	if err := s.DetachJobPartition(ctx, "jobs_legacy"); !errors.Is(err, ErrPartitionActive) {
+		t.Fatalf("Expected ErrPartitionActive, got %v", err)
+	}
+
+	// 3. Once finished, the partition is detached while jobs_default exists, exported and dropped
+	s.db.Exec(ctx, `UPDATE jobs SET status = 'completed' WHERE id = $1`, pending.ID)
+	var hasDefault bool
+	s.db.QueryRow(ctx, `SELECT to_regclass('jobs_default') IS NOT NULL`).Scan(&hasDefault)
+	if !hasDefault {
+		t.Fatal("Expected jobs to have a default partition")
+	}
+	if err := s.DetachJobPartition(ctx, "jobs_legacy"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetJobByID(ctx, old.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected detached job to be gone, got %v", err)
+	}
+	var buf strings.Builder
+	if n, err := s.ExportJobPartition(ctx, "jobs_legacy", &buf); err != nil || n != 2 {
+		t.Errorf("Expected 2 exported jobs, got %d, %v", n, err)
+	}
+	if !strings.Contains(buf.String(), `"type":"test:partition"`) {
+		t.Errorf("Expected NDJSON rows, got %s", buf.String())
+	}
+	if err := s.DropJobPartition(ctx, "jobs_legacy"); err != nil {
+		t.Fatal(err)
+	}
+
+	partitions, _ := s.ListJobPartitions(ctx)
+	for _, p := range partitions {
+		if p.Name == "jobs_legacy" {
+			t.Error("Expected jobs_legacy to be dropped")
+		}
+	}
+
+	// 4. The idempotency key of a dropped job is released
+	again, _ := s.CreateJob(ctx, CreateJobParams{Type: "test:partition", Payload: "{}", IdempotencyKey: "old", IdempotencyTTL: time.Hour})
+	if again.Duplicate {
+		t.Error("Expected the key of the dropped job to be free")
+	}
+
+	// 5. A job past the last partition lands in jobs_default and moves into its partition once created
+	wf, err := s.CreateWorkflow(ctx, CreateWorkflowParams{Name: "late", Steps: []CreateWorkflowStepParams{
+		{Key: "late", Job: CreateJobParams{Type: "test:partition", Payload: "{}"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	late, _ := s.GetJobByID(ctx, wf.Steps[0].JobID)
+	s.db.Exec(ctx, `UPDATE jobs SET created_at = NOW() + INTERVAL '10 days' WHERE id = $1`, late.ID)
+	s.db.QueryRow(ctx, `SELECT tableoid::regclass::TEXT FROM jobs WHERE id = $1`, late.ID).Scan(&partition)
+	if partition != "jobs_default" {
+		t.Fatalf("Expected job in jobs_default, got %s", partition)
+	}
+	if _, err := s.EnsureJobPartitions(ctx, 11*24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	s.db.QueryRow(ctx, `SELECT tableoid::regclass::TEXT FROM jobs WHERE id = $1`, late.ID).Scan(&partition)
+	if want := "jobs_p" + time.Now().UTC().Add(10*24*time.Hour).Format("20060102"); partition != want {
+		t.Errorf("Expected job moved to %s, got %s", want, partition)
+	}
+	if got, err := s.GetJobByID(ctx, late.ID); err != nil || got.Status != JobStatusPending {
+		t.Errorf("Expected the moved job to stay pending, got %+v, %v", got, err)
+	}
+
+	// 6. A daily partition is detached next to jobs_default once its jobs are finished
+	if err := s.DetachJobPartition(ctx, partition); !errors.Is(err, ErrPartitionActive) {
+		t.Errorf("Expected ErrPartitionActive, got %v", err)
+	}
+	s.db.Exec(ctx, `UPDATE jobs SET status = 'completed' WHERE id = $1`, late.ID)
+	if err := s.DetachJobPartition(ctx, partition); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetJobByID(ctx, late.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected detached job to be gone, got %v", err)
+	}
+	if got, err := s.GetWorkflow(ctx, wf.ID); err != nil || got.Steps[0].Status != JobStatusCompleted {
+		t.Errorf("Expected the detached step to keep its status, got %+v, %v", got, err)
+	}
+	if err := s.DropJobPartition(ctx, partition); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIntegration_Conformance(t *testing.T) {
+	testStorerConformance(t, func(t *testing.T) Storer {
+		return setupIntegrationTest(t)
+	})
+}
+
+func TestIntegration_CreateJobTx(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// 1. A rolled back transaction leaves no job behind
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	job, err := CreateJobTx(ctx, tx, CreateJobParams{Type: "test:tx", Payload: "{}"})
+	if err != nil {
+		t.Fatalf("CreateJobTx failed: %v", err)
+	}
+	tx.Rollback(ctx)
+	if _, err := s.GetJobByID(ctx, job.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected job %d to be rolled back, got %v", job.ID, err)
+	}
+
+	// 2. A committed transaction enqueues the job and wakes listeners
+	listenCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ready := s.ListenJobs(listenCtx, DefaultQueue)
+	time.Sleep(200 * time.Millisecond)
+
+	tx, err = s.db.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	job, err = CreateJobTx(ctx, tx, CreateJobParams{Type: "test:tx", Payload: "{}"})
+	if err != nil {
+		t.Fatalf("CreateJobTx failed: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if _, err := s.GetJobByID(ctx, job.ID); err != nil {
+		t.Errorf("Expected job %d to be committed, got %v", job.ID, err)
+	}
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		t.Error("Expected a notification once the transaction committed")
+	}
+}
+
+func TestIntegration_CreateJob_UniqueConcurrency(t *testing.T) {
+	s := setupIntegrationTest(t)
+	defer s.Close()
+	ctx := context.Background()
+
+	// concurrent submissions of the same unique job create it once
+	spec := &UniqueSpec{Fields: []string{"invoice_id"}}
+	var wg sync.WaitGroup
+	ids := make(chan int64, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job, err := s.CreateJob(ctx, CreateJobParams{Type: "test:unique", Payload: `{"invoice_id": "inv-1"}`, Unique: spec})
+			if err != nil {
+				t.Errorf("CreateJob failed: %v", err)
+				return
+			}
+			ids <- job.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	distinct := make(map[int64]bool)
+	for id := range ids {
+		distinct[id] = true
+	}
+	if len(distinct) != 1 {
+		t.Errorf("Expected every submission to return the same job, got %d jobs", len(distinct))
+	}
+}
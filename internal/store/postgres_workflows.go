@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateWorkflow inserts the workflow, its jobs and their dependency edges in a
+// single transaction, so no job of the workflow can be claimed before the whole
+// graph is stored. A unique step with a duplicate fails the workflow with
+// ErrAlreadyExists.
+func (s *Store) CreateWorkflow(ctx context.Context, params CreateWorkflowParams) (*Workflow, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	wf := &Workflow{Name: params.Name}
+	err = tx.QueryRow(ctx, `INSERT INTO workflows (name) VALUES ($1) RETURNING id, created_at`, params.Name).
+		Scan(&wf.ID, &wf.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("insert workflow: %w", err)
+	}
+
+	ids := make(map[string]int64, len(params.Steps))
+	for _, step := range params.Steps {
+		if _, ok := ids[step.Key]; ok {
+			return nil, fmt.Errorf("duplicate workflow step %q", step.Key)
+		}
+
+		job, err := createJob(ctx, tx, step.Job)
+		if err != nil {
+			return nil, fmt.Errorf("create step %q: %w", step.Key, err)
+		}
+		if job.Duplicate {
+			return nil, fmt.Errorf("create step %q: duplicate of job %d: %w", step.Key, job.ID, ErrAlreadyExists)
+		}
+		ids[step.Key] = job.ID
+
+		_, err = tx.Exec(ctx, `INSERT INTO workflow_jobs (workflow_id, job_id, step) VALUES ($1, $2, $3)`,
+			wf.ID, job.ID, step.Key)
+		if err != nil {
+			return nil, fmt.Errorf("insert workflow job: %w", err)
+		}
+
+		for _, dep := range step.DependsOn {
+			parentID, ok := ids[dep]
+			if !ok {
+				return nil, fmt.Errorf("step %q depends on %q, which is not declared before it", step.Key, dep)
+			}
+			_, err = tx.Exec(ctx, `INSERT INTO job_dependencies (job_id, depends_on) VALUES ($1, $2)`, job.ID, parentID)
+			if err != nil {
+				return nil, fmt.Errorf("insert dependency: %w", err)
+			}
+		}
+
+		wf.Steps = append(wf.Steps, WorkflowStep{
+			Key:       step.Key,
+			JobID:     job.ID,
+			Type:      job.Type,
+			Status:    job.Status,
+			DependsOn: step.DependsOn,
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit workflow: %w", err)
+	}
+
+	return wf, nil
+}
+
+// GetWorkflow returns the workflow with the current status of each job. A job
+// that is no longer in the jobs table was either dead-lettered or archived, in
+// which case its final status was recorded in workflow_jobs.
+func (s *Store) GetWorkflow(ctx context.Context, id int64) (*Workflow, error) {
+	wf := &Workflow{ID: id}
+	err := s.db.QueryRow(ctx, `SELECT name, created_at FROM workflows WHERE id = $1`, id).
+		Scan(&wf.Name, &wf.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("workflow %d: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get workflow: %w", err)
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT wj.step, wj.job_id,
+			COALESCE(j.type, d.type, ''),
+			COALESCE(j.status, CASE WHEN d.id IS NOT NULL THEN $2 END, wj.status),
+			COALESCE(j.last_err, d.last_err)
+		FROM workflow_jobs wj
+		LEFT JOIN jobs j ON j.id = wj.job_id
+		LEFT JOIN dead_jobs d ON d.id = wj.job_id
+		WHERE wj.workflow_id = $1
+		ORDER BY wj.job_id
+	`, id, JobStatusDead)
+	if err != nil {
+		return nil, fmt.Errorf("get workflow jobs: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make(map[int64]string)
+	index := make(map[int64]int)
+	for rows.Next() {
+		var step WorkflowStep
+		if err := rows.Scan(&step.Key, &step.JobID, &step.Type, &step.Status, &step.ErrorMessage); err != nil {
+			return nil, fmt.Errorf("scan workflow job: %w", err)
+		}
+		keys[step.JobID] = step.Key
+		index[step.JobID] = len(wf.Steps)
+		wf.Steps = append(wf.Steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get workflow jobs: %w", err)
+	}
+
+	depRows, err := s.db.Query(ctx, `
+		SELECT d.job_id, d.depends_on
+		FROM job_dependencies d
+		JOIN workflow_jobs wj ON wj.job_id = d.job_id
+		WHERE wj.workflow_id = $1
+		ORDER BY d.job_id, d.depends_on
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("get workflow dependencies: %w", err)
+	}
+	defer depRows.Close()
+
+	for depRows.Next() {
+		var jobID, parentID int64
+		if err := depRows.Scan(&jobID, &parentID); err != nil {
+			return nil, fmt.Errorf("scan workflow dependency: %w", err)
+		}
+		i := index[jobID]
+		wf.Steps[i].DependsOn = append(wf.Steps[i].DependsOn, keys[parentID])
+	}
+
+	return wf, depRows.Err()
+}
+
+// skipDependants marks every pending job that transitively depends on jobID as
+// skipped, recording reason as its error.
+func skipDependants(ctx context.Context, db dbtx, jobID int64, reason string) error {
+	tag, err := db.Exec(ctx, `
+		WITH RECURSIVE dependants AS (
+			SELECT job_id FROM job_dependencies WHERE depends_on = $1
+			UNION
+			SELECT d.job_id FROM job_dependencies d JOIN dependants ON d.depends_on = dependants.job_id
+		)
+		UPDATE jobs
+		SET status = $2, last_err = $3, completed_at = NOW(), updated_at = NOW()
+		WHERE id IN (SELECT job_id FROM dependants) AND status = $4
+	`, jobID, JobStatusSkipped, reason, JobStatusPending)
+	if err != nil {
+		return fmt.Errorf("skip dependants: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		logger.Info("Skipped dependant jobs", "job_id", jobID, "count", tag.RowsAffected())
+	}
+	return nil
+}
+
+// restoreDependants returns the jobs skipped because of the requeued jobs ids to
+// pending, level by level, as long as none of their other dependencies is
+// dead, failed, cancelled or still skipped.
+func restoreDependants(ctx context.Context, db dbtx, ids []int64) error {
+	for len(ids) > 0 {
+		rows, err := db.Query(ctx, `
+			UPDATE jobs
+			SET status = $2, last_err = NULL, completed_at = NULL, updated_at = NOW()
+			WHERE status = $3
+				AND id IN (SELECT job_id FROM job_dependencies WHERE depends_on = ANY($1))
+				AND NOT EXISTS (
+					SELECT 1 FROM job_dependencies d
+					LEFT JOIN jobs p ON p.id = d.depends_on
+					WHERE d.job_id = jobs.id AND NOT d.resolved
+						AND (p.id IS NULL OR p.status IN ($3, $4, $5))
+				)
+			RETURNING id
+		`, ids, JobStatusPending, JobStatusSkipped, JobStatusFailed, JobStatusCancelled)
+		if err != nil {
+			return fmt.Errorf("restore dependants: %w", err)
+		}
+
+		ids, err = pgx.CollectRows(rows, pgx.RowTo[int64])
+		if err != nil {
+			return fmt.Errorf("restore dependants: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1102 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore is a Storer backed by a single SQLite database file, for
+// single-node deployments that want jobs to survive a restart without running
+// Postgres. It implements the claim, lease, retry and dead-letter semantics of
+// the Postgres store. All access goes through one connection, so transactions
+// are serialized and claims are exclusive without row locks; the file must
+// not be shared by several servers.
+type SQLiteStore struct {
+	db       *sql.DB
+	listener jobListener
+}
+
+var (
+	_ Storer   = (*SQLiteStore)(nil)
+	_ Listener = (*SQLiteStore)(nil)
+)
+
+// sqliteTimeFormat is the text format of TIMESTAMP columns. Times are stored
+// in UTC with a fixed number of digits so that they compare as text in the
+// same order as in time.
+const sqliteTimeFormat = "2006-01-02 15:04:05.000000"
+
+// NewSQLiteStore opens, creating it if needed, the database file at path. The
+// schema is created by Migrate.
+func NewSQLiteStore(ctx context.Context, path string) (*SQLiteStore, error) {
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxIdleTime(0)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Close() {
+	s.listener.close()
+	s.db.Close()
+	logger.Info("db disconnected")
+}
+
+// ListenJobs returns a channel that receives a value whenever jobs in queue
+// may have become ready. Only this process writes to the database, so the
+// wake-ups are sent on commit instead of through the database. The channel is
+// closed when ctx is done.
+func (s *SQLiteStore) ListenJobs(ctx context.Context, queue string) <-chan struct{} {
+	return s.listener.subscribe(ctx, queue)
+}
+
+// sqliteTx is a transaction with the time it started, which stands in for
+// NOW() in every statement, and the jobs to announce once it commits.
+type sqliteTx struct {
+	*sql.Tx
+	now   time.Time
+	ready []int64
+}
+
+// withTx runs fn in a transaction and commits it if fn succeeds. The store
+// has a single connection, so fn must only use tx.
+func (s *SQLiteStore) withTx(ctx context.Context, fn func(tx *sqliteTx) error) error {
+	sqlTx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer sqlTx.Rollback()
+
+	tx := &sqliteTx{Tx: sqlTx, now: time.Now().UTC().Truncate(time.Microsecond)}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	s.notifyJobsReady(ctx, tx.now, tx.ready)
+	return nil
+}
+
+// notifyJobsReady wakes the listeners of the queues of the given jobs that are
+// due. Failing to notify is logged: pools still find the jobs on their next
+// poll.
+func (s *SQLiteStore) notifyJobsReady(ctx context.Context, now time.Time, ids []int64) {
+	if len(ids) == 0 {
+		return
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT queue FROM jobs
+		WHERE id IN (SELECT value FROM json_each(?)) AND status = 'pending' AND next_run_at <= ?
+	`, sqliteIDs(ids), sqliteTime(now))
+	if err != nil {
+		logger.Error("Failed to notify ready jobs", "error", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var queue string
+		if err := rows.Scan(&queue); err != nil {
+			logger.Error("Failed to notify ready jobs", "error", err)
+			return
+		}
+		s.listener.wake(queue)
+	}
+}
+
+// sqliteTime formats t as stored in TIMESTAMP columns.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeFormat)
+}
+
+// sqliteNullTime formats t like sqliteTime, or returns NULL for nil.
+func sqliteNullTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return sqliteTime(*t)
+}
+
+// sqliteIDs encodes ids as a JSON array, which queries expand with json_each
+// since SQLite has no array parameters.
+func sqliteIDs(ids []int64) string {
+	encoded, _ := json.Marshal(ids)
+	return string(encoded)
+}
+
+// timestamp scans a TIMESTAMP column into dest, or a nullable one into
+// nullDest. The driver returns time.Time for table columns and the stored
+// text for expressions.
+type timestamp struct {
+	dest     *time.Time
+	nullDest **time.Time
+}
+
+func (ts timestamp) Scan(src any) error {
+	var t time.Time
+	switch v := src.(type) {
+	case nil:
+		if ts.nullDest == nil {
+			return fmt.Errorf("timestamp is NULL")
+		}
+		*ts.nullDest = nil
+		return nil
+	case time.Time:
+		t = v.UTC()
+	case string:
+		var err error
+		if t, err = time.Parse(sqliteTimeFormat, v); err != nil {
+			return fmt.Errorf("parse timestamp: %w", err)
+		}
+	default:
+		return fmt.Errorf("unexpected timestamp %T", src)
+	}
+
+	if ts.nullDest != nil {
+		*ts.nullDest = &t
+	} else {
+		*ts.dest = t
+	}
+	return nil
+}
+
+// sqliteRow is satisfied by both *sql.Row and *sql.Rows.
+type sqliteRow interface {
+	Scan(dest ...any) error
+}
+
+// scanSQLiteJob scans a row selected with jobColumns, followed by the columns
+// scanned into extra.
+func scanSQLiteJob(row sqliteRow, extra ...any) (*Job, error) {
+	var job Job
+	var retryPolicy, result sql.NullString
+	dest := []any{
+		&job.ID,
+		&job.Type,
+		&job.Payload,
+		&job.Status,
+		timestamp{dest: &job.CreatedAt},
+		timestamp{dest: &job.UpdatedAt},
+		timestamp{nullDest: &job.StartedAt},
+		timestamp{nullDest: &job.CompletedAt},
+		&job.ErrorMessage,
+		&job.RetryCount,
+		timestamp{dest: &job.NextRunAt},
+		&job.Priority,
+		&job.Queue,
+		&job.IdempotencyKey,
+		timestamp{nullDest: &job.IdempotencyExpiresAt},
+		&job.ReplayCount,
+		timestamp{nullDest: &job.ReplayedAt},
+		&retryPolicy,
+		&job.TimeoutMs,
+		&job.LockedBy,
+		timestamp{nullDest: &job.LeaseExpiresAt},
+		&job.LeaseToken,
+		&result,
+		&job.Progress,
+		&job.ProgressMessage,
+		timestamp{nullDest: &job.ProgressUpdatedAt},
+		&job.UniqueKey,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if retryPolicy.Valid {
+		job.RetryPolicy = &RetryPolicy{}
+		if err := json.Unmarshal([]byte(retryPolicy.String), job.RetryPolicy); err != nil {
+			return nil, fmt.Errorf("decode retry policy: %w", err)
+		}
+	}
+	if result.Valid {
+		job.Result = json.RawMessage(result.String)
+	}
+	return &job, nil
+}
+
+func (s *SQLiteStore) CreateJob(ctx context.Context, params CreateJobParams) (*Job, error) {
+	var job *Job
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		var err error
+		job, err = tx.createJob(ctx, params)
+		return err
+	})
+	return job, err
+}
+
+// uniqueDuplicate returns the oldest job with unique key that blocks a
+// duplicate under spec, or nil.
+func (tx *sqliteTx) uniqueDuplicate(ctx context.Context, key string, spec *UniqueSpec) (*Job, error) {
+	// an empty cutoff sorts before every timestamp, so any age counts
+	var cutoff string
+	if spec.Period > 0 {
+		cutoff = sqliteTime(tx.now.Add(-spec.Period))
+	}
+	states, _ := json.Marshal(spec.BlockingStates())
+	job, err := scanSQLiteJob(tx.QueryRowContext(ctx, `
+		SELECT `+jobColumns+` FROM jobs
+		WHERE unique_key = ? AND status IN (SELECT value FROM json_each(?)) AND created_at > ?
+		ORDER BY id
+		LIMIT 1
+	`, key, string(states), cutoff))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get unique job: %w", err)
+	}
+	return job, nil
+}
+
+// createJob inserts a job. If the job carries an idempotency key that is
+// already held by an unexpired job, or is unique and has a duplicate, that job
+// is returned with Duplicate set instead of inserting a new row.
+func (tx *sqliteTx) createJob(ctx context.Context, params CreateJobParams) (*Job, error) {
+	if !json.Valid([]byte(params.Payload)) {
+		return nil, fmt.Errorf("insert job: payload is not valid JSON")
+	}
+
+	queue := params.Queue
+	if queue == "" {
+		queue = DefaultQueue
+	}
+
+	var uniqueKey any
+	if params.Unique != nil {
+		key, err := params.UniqueKey()
+		if err != nil {
+			return nil, fmt.Errorf("insert job: %w", err)
+		}
+
+		job, err := tx.uniqueDuplicate(ctx, key, params.Unique)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			job.Duplicate = true
+			return job, nil
+		}
+		uniqueKey = key
+	}
+
+	var idempotencyKey, idempotencyExpiresAt any
+	if params.IdempotencyKey != "" {
+		// release the key if the job holding it has outlived its retention window
+		_, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET idempotency_key = NULL, idempotency_expires_at = NULL
+			WHERE idempotency_key = ? AND idempotency_expires_at <= ?
+		`, params.IdempotencyKey, sqliteTime(tx.now))
+		if err != nil {
+			return nil, fmt.Errorf("release idempotency key: %w", err)
+		}
+
+		job, err := scanSQLiteJob(tx.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs WHERE idempotency_key = ?`, params.IdempotencyKey))
+		if err == nil {
+			job.Duplicate = true
+			return job, nil
+		}
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("get job by idempotency key: %w", err)
+		}
+
+		idempotencyKey = params.IdempotencyKey
+		idempotencyExpiresAt = sqliteTime(tx.now.Add(params.IdempotencyTTL))
+	}
+
+	var retryPolicy any
+	if params.RetryPolicy != nil {
+		encoded, err := json.Marshal(params.RetryPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("encode retry policy: %w", err)
+		}
+		retryPolicy = string(encoded)
+	}
+
+	var timeoutMs any
+	if params.Timeout > 0 {
+		timeoutMs = params.Timeout.Milliseconds()
+	}
+
+	nextRunAt := tx.now
+	if params.RunAt != nil {
+		nextRunAt = *params.RunAt
+	}
+
+	job, err := scanSQLiteJob(tx.QueryRowContext(ctx, `
+		INSERT INTO jobs (type, payload, status, created_at, updated_at, next_run_at, priority, queue, idempotency_key, idempotency_expires_at, retry_policy, timeout_ms, unique_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING `+jobColumns,
+		params.Type,
+		params.Payload,
+		JobStatusPending,
+		sqliteTime(tx.now),
+		sqliteTime(tx.now),
+		sqliteTime(nextRunAt),
+		params.Priority,
+		queue,
+		idempotencyKey,
+		idempotencyExpiresAt,
+		retryPolicy,
+		timeoutMs,
+		uniqueKey,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("insert job: %w", err)
+	}
+
+	tx.ready = append(tx.ready, job.ID)
+	return job, nil
+}
+
+// CreateJobs inserts many jobs in one transaction and returns them in the order
+// of params. Like the Postgres store, jobs with an idempotency key or unique
+// spec are inserted first, so duplicates (also within the batch) come back
+// with Duplicate set.
+func (s *SQLiteStore) CreateJobs(ctx context.Context, params []CreateJobParams) ([]Job, error) {
+	for _, p := range params {
+		if !json.Valid([]byte(p.Payload)) {
+			return nil, fmt.Errorf("insert jobs: payload is not valid JSON")
+		}
+	}
+
+	jobs := make([]Job, len(params))
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		var bulk []int
+		for i, p := range params {
+			if p.IdempotencyKey == "" && p.Unique == nil {
+				bulk = append(bulk, i)
+				continue
+			}
+			job, err := tx.createJob(ctx, p)
+			if err != nil {
+				return err
+			}
+			jobs[i] = *job
+		}
+
+		for _, i := range bulk {
+			job, err := tx.createJob(ctx, params[i])
+			if err != nil {
+				return err
+			}
+			jobs[i] = *job
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (s *SQLiteStore) GetJobByID(ctx context.Context, id int64) (*Job, error) {
+	job, err := scanSQLiteJob(s.db.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("job %d: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (s *SQLiteStore) GetPendingJobs(ctx context.Context, params ClaimParams) ([]Job, error) {
+	queue := params.Queue
+	if queue == "" {
+		queue = DefaultQueue
+	}
+
+	// With aging, every PriorityAging a job has been waiting counts as one
+	// extra priority point.
+	orderBy := `priority DESC, next_run_at ASC`
+	if params.PriorityAging > 0 {
+		orderBy = fmt.Sprintf(
+			`priority + CAST((julianday(?) - julianday(next_run_at)) * 86400 / %f AS INTEGER) DESC, next_run_at ASC`,
+			params.PriorityAging.Seconds(),
+		)
+	}
+
+	var jobs []Job
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		now := sqliteTime(tx.now)
+		args := []any{JobStatusPending, queue, now}
+		if params.PriorityAging > 0 {
+			args = append(args, now)
+		}
+
+		rows, err := tx.QueryContext(ctx, `
+			SELECT `+jobColumns+`
+			FROM jobs
+			WHERE status = ? AND queue = ? AND next_run_at <= ?
+				AND NOT EXISTS (
+					SELECT 1 FROM job_dependencies d
+					WHERE d.job_id = jobs.id AND NOT d.resolved
+				)
+			ORDER BY `+orderBy+`
+			LIMIT ?
+		`, append(args, params.Limit)...)
+		if err != nil {
+			return fmt.Errorf("get pending jobs: %w", err)
+		}
+		for rows.Next() {
+			job, err := scanSQLiteJob(rows)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("scan job: %w", err)
+			}
+			jobs = append(jobs, *job)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("get pending jobs: %w", err)
+		}
+
+		leaseExpiresAt := tx.now.Add(params.LeaseDuration)
+		for i := range jobs {
+			err := tx.QueryRowContext(ctx, `
+				UPDATE jobs
+				SET status = ?,
+					started_at = ?,
+					locked_by = ?,
+					lease_expires_at = ?,
+					lease_token = lease_token + 1,
+					progress = NULL,
+					progress_message = NULL,
+					progress_updated_at = NULL
+				WHERE id = ?
+				RETURNING lease_token
+			`, JobStatusRunning, now, params.Owner, sqliteTime(leaseExpiresAt), jobs[i].ID).Scan(&jobs[i].LeaseToken)
+			if err != nil {
+				return fmt.Errorf("mark job running: %w", err)
+			}
+			startedAt, expiresAt := tx.now, leaseExpiresAt.Truncate(time.Microsecond)
+			jobs[i].Status = JobStatusRunning
+			jobs[i].StartedAt = &startedAt
+			jobs[i].LockedBy = sql.NullString{String: params.Owner, Valid: true}
+			jobs[i].LeaseExpiresAt = &expiresAt
+			jobs[i].Progress, jobs[i].ProgressMessage, jobs[i].ProgressUpdatedAt = sql.NullInt32{}, sql.NullString{}, nil
+
+			if err := tx.startAttempt(ctx, jobs[i].ID, params.Owner); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// UpdateJobStatus records the outcome of an attempt and releases its lease.
+// The update only applies while lease is still the job's current lease;
+// otherwise ErrLeaseLost is returned. A job cancelled while running keeps
+// its cancellation.
+func (s *SQLiteStore) UpdateJobStatus(ctx context.Context, status JobStatus, id int64, lease Lease) error {
+	return s.finishJob(ctx, status, id, lease, nil)
+}
+
+// CompleteJob marks a job completed like UpdateJobStatus and stores the JSON
+// result its handler produced. A nil result leaves the column NULL.
+func (s *SQLiteStore) CompleteJob(ctx context.Context, id int64, lease Lease, result json.RawMessage) error {
+	return s.finishJob(ctx, JobStatusCompleted, id, lease, result)
+}
+
+func (s *SQLiteStore) finishJob(ctx context.Context, status JobStatus, id int64, lease Lease, result json.RawMessage) error {
+	var resultArg any
+	if result != nil {
+		resultArg = string(result)
+	}
+
+	return s.withTx(ctx, func(tx *sqliteTx) error {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET status = ?1,
+				started_at = CASE WHEN ?1 = 'running' THEN ?2 ELSE started_at END,
+				completed_at = CASE WHEN ?1 IN ('completed', 'failed') THEN ?2 ELSE completed_at END,
+				locked_by = NULL,
+				lease_expires_at = NULL,
+				result = ?3
+			WHERE id = ?4 AND status = 'running' AND locked_by = ?5 AND lease_token = ?6
+		`, status, sqliteTime(tx.now), resultArg, id, lease.Owner, lease.Token)
+		if err != nil {
+			return fmt.Errorf("update job: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			var current JobStatus
+			err := tx.QueryRowContext(ctx, `SELECT status FROM jobs WHERE id = ?`, id).Scan(&current)
+			if err == nil && current == JobStatusCancelled {
+				// the job was cancelled while running; keep the cancellation
+				return nil
+			}
+			return fmt.Errorf("job %d: %w", id, ErrLeaseLost)
+		}
+
+		if err := tx.finishAttempt(ctx, id, AttemptOutcome(status), ""); err != nil {
+			return err
+		}
+
+		switch status {
+		case JobStatusCompleted:
+			rows, err := tx.QueryContext(ctx, `UPDATE job_dependencies SET resolved = 1 WHERE depends_on = ? RETURNING job_id`, id)
+			if err != nil {
+				return fmt.Errorf("resolve dependencies: %w", err)
+			}
+			dependants, err := collectIDs(rows)
+			if err != nil {
+				return fmt.Errorf("resolve dependencies: %w", err)
+			}
+			tx.ready = append(tx.ready, dependants...)
+		case JobStatusFailed:
+			if err := tx.skipDependants(ctx, id, fmt.Sprintf("dependency job %d failed", id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// collectIDs reads and closes rows of a single id column.
+func collectIDs(rows *sql.Rows) ([]int64, error) {
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// HandleJobFailure schedules the next attempt of a failed job according to
+// policy, or moves it to dead_jobs once policy.MaxAttempts is exhausted. A zero
+// MaxAttempts falls back to the job's max_retries column. Like UpdateJobStatus,
+// it is fenced on lease.
+func (s *SQLiteStore) HandleJobFailure(ctx context.Context, jobId int64, errMsg string, policy RetryPolicy, lease Lease) error {
+	return s.withTx(ctx, func(tx *sqliteTx) error {
+		var retryCount, maxRetries int
+		var jobStatus JobStatus
+		var lockedBy sql.NullString
+		var leaseToken int64
+
+		err := tx.QueryRowContext(ctx, `SELECT status, retry_count, max_retries, locked_by, lease_token FROM jobs WHERE id = ?`, jobId).
+			Scan(&jobStatus, &retryCount, &maxRetries, &lockedBy, &leaseToken)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("job %d: %w", jobId, ErrLeaseLost)
+		}
+		if err != nil {
+			return fmt.Errorf("fetch job: %w", err)
+		}
+
+		if jobStatus == JobStatusCancelled {
+			logger.Info("Not retrying cancelled job", "job_id", jobId)
+			return nil
+		}
+
+		if jobStatus != JobStatusRunning || lockedBy.String != lease.Owner || leaseToken != lease.Token {
+			return fmt.Errorf("job %d: %w", jobId, ErrLeaseLost)
+		}
+
+		return tx.failJob(ctx, jobId, retryCount, maxRetries, errMsg, AttemptFailed, policy)
+	})
+}
+
+// failJob ends the open attempt of a job with outcome, then schedules its next
+// attempt according to policy or moves it to dead_jobs once policy.MaxAttempts
+// (or maxRetries, if zero) is exhausted.
+func (tx *sqliteTx) failJob(ctx context.Context, jobId int64, retryCount, maxRetries int, errMsg string, outcome AttemptOutcome, policy RetryPolicy) error {
+	if err := tx.finishAttempt(ctx, jobId, outcome, errMsg); err != nil {
+		return err
+	}
+
+	newRetryCount := retryCount + 1
+	if policy.MaxAttempts > 0 {
+		maxRetries = policy.MaxAttempts
+	}
+
+	if newRetryCount < maxRetries {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE jobs
+			SET status = 'pending',
+				retry_count = ?,
+				last_err = ?,
+				next_run_at = ?,
+				updated_at = ?,
+				locked_by = NULL,
+				lease_expires_at = NULL
+			WHERE id = ?
+		`, newRetryCount, errMsg, sqliteTime(tx.now.Add(policy.Delay(newRetryCount))), sqliteTime(tx.now), jobId)
+		if err != nil {
+			return fmt.Errorf("update retry: %w", err)
+		}
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO dead_jobs (id, type, payload, last_err, failed_at, retry_count, queue, priority, replay_count, retry_policy, timeout_ms, unique_key)
+		SELECT id, type, payload, ?, ?, ?, queue, priority, replay_count, retry_policy, timeout_ms, unique_key FROM jobs WHERE id = ?
+	`, errMsg, sqliteTime(tx.now), newRetryCount, jobId)
+	if err != nil {
+		return fmt.Errorf("move to dlq: %w", err)
+	}
+
+	// the idempotency key is held by the jobs row, so deleting it releases the key
+	if _, err := tx.ExecContext(ctx, `DELETE FROM jobs WHERE id = ?`, jobId); err != nil {
+		return fmt.Errorf("delete from jobs: %w", err)
+	}
+
+	if err := tx.skipDependants(ctx, jobId, fmt.Sprintf("dependency job %d was dead-lettered", jobId)); err != nil {
+		return err
+	}
+
+	logger.Info("Job moved to DLQ", "job_id", jobId)
+	return nil
+}
+
+func (s *SQLiteStore) GetArchivedJobs(ctx context.Context, duration time.Duration, limit int) ([]Job, error) {
+	query :=
+		`
+			SELECT id, type, payload, status, created_at, completed_at, result
+			FROM jobs
+			WHERE status = 'completed' AND completed_at < ?
+			LIMIT ?
+		`
+
+	rows, err := s.db.QueryContext(ctx, query, sqliteTime(time.Now().Add(-duration)), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []Job
+	for rows.Next() {
+		var j Job
+		var result sql.NullString
+		if err := rows.Scan(&j.ID, &j.Type, &j.Payload, &j.Status, timestamp{dest: &j.CreatedAt}, timestamp{nullDest: &j.CompletedAt}, &result); err != nil {
+			return nil, err
+		}
+		if result.Valid {
+			j.Result = json.RawMessage(result.String)
+		}
+		jobs = append(jobs, j)
+	}
+
+	return jobs, rows.Err()
+}
+
+// BatchDeleteJobs deletes jobs together with their attempt history. The status
+// of a deleted workflow job is kept in workflow_jobs.
+func (s *SQLiteStore) BatchDeleteJobs(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.withTx(ctx, func(tx *sqliteTx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE workflow_jobs SET status = j.status
+			FROM jobs j
+			WHERE workflow_jobs.job_id = j.id AND j.id IN (SELECT value FROM json_each(?))
+		`, sqliteIDs(ids))
+		if err != nil {
+			return fmt.Errorf("record workflow step status: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM job_attempts WHERE job_id IN (SELECT value FROM json_each(?))`, sqliteIDs(ids)); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM jobs WHERE id IN (SELECT value FROM json_each(?))`, sqliteIDs(ids))
+		return err
+	})
+}
+
+func (s *SQLiteStore) ListJobs(ctx context.Context, filter JobFilter, sort JobSort, page PageParams) (*PaginatedJobs, error) {
+	where, args := filter.sqliteWhere()
+	return s.listJobs(ctx, "jobs", jobColumns, where, args, sort, jobSortColumns, page, func(row sqliteRow) (*Job, error) { return scanSQLiteJob(row) })
+}
+
+// sqliteWhere renders the filter as a SQLite WHERE clause with its arguments.
+func (f JobFilter) sqliteWhere() (string, []any) {
+	var conditions []string
+	var args []any
+
+	if f.MinPriority != nil {
+		conditions = append(conditions, "priority >= ?")
+		args = append(args, *f.MinPriority)
+	}
+
+	if f.Queue != "" {
+		conditions = append(conditions, "queue = ?")
+		args = append(args, f.Queue)
+	}
+
+	if len(f.Statuses) > 0 {
+		statuses, _ := json.Marshal(f.Statuses)
+		conditions = append(conditions, "status IN (SELECT value FROM json_each(?))")
+		args = append(args, string(statuses))
+	}
+
+	if f.Type != "" {
+		conditions = append(conditions, "type = ?")
+		args = append(args, f.Type)
+	}
+
+	if f.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, sqliteTime(*f.CreatedAfter))
+	}
+
+	if f.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, sqliteTime(*f.CreatedBefore))
+	}
+
+	if f.CompletedAfter != nil {
+		conditions = append(conditions, "completed_at >= ?")
+		args = append(args, sqliteTime(*f.CompletedAfter))
+	}
+
+	if f.CompletedBefore != nil {
+		conditions = append(conditions, "completed_at < ?")
+		args = append(args, sqliteTime(*f.CompletedBefore))
+	}
+
+	if f.MinRetries != nil {
+		conditions = append(conditions, "retry_count >= ?")
+		args = append(args, *f.MinRetries)
+	}
+
+	if f.MaxRetries != nil {
+		conditions = append(conditions, "retry_count <= ?")
+		args = append(args, *f.MaxRetries)
+	}
+
+	if f.ErrorContains != "" {
+		conditions = append(conditions, "instr(last_err, ?) > 0")
+		args = append(args, f.ErrorContains)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (s *SQLiteStore) ListDeadJobs(ctx context.Context, filter DeadJobFilter, sort JobSort, page PageParams) (*PaginatedJobs, error) {
+	where, args := filter.sqliteWhere()
+	columns := `id, type, payload, 'failed' AS status, failed_at AS created_at, last_err, retry_count, queue, priority`
+	return s.listJobs(ctx, "dead_jobs", columns, where, args, sort, deadJobSortColumns, page, func(row sqliteRow) (*Job, error) {
+		var j Job
+		err := row.Scan(&j.ID, &j.Type, &j.Payload, &j.Status, timestamp{dest: &j.CreatedAt}, &j.ErrorMessage, &j.RetryCount, &j.Queue, &j.Priority)
+		return &j, err
+	})
+}
+
+// listJobs returns a page of the rows of table matching where, ordered by
+// sort, seeking past the cursor of token pages like the Postgres store. SQLite
+// has no row estimates, so TotalEstimated counts exactly.
+func (s *SQLiteStore) listJobs(
+	ctx context.Context,
+	table, columns, where string, args []any,
+	sort JobSort, sortColumns map[JobSortField]string,
+	page PageParams,
+	scan func(sqliteRow) (*Job, error),
+) (*PaginatedJobs, error) {
+	if page.PageToken != "" && page.Offset > 0 {
+		return nil, fmt.Errorf("%w: offset cannot be combined with a page token", ErrInvalidPageToken)
+	}
+
+	meta := PaginationMetadata{Limit: page.Limit, TotalCount: page.total()}
+
+	if meta.TotalCount != TotalNone {
+		if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+where, args...).Scan(&meta.TotalRecords); err != nil {
+			return nil, err
+		}
+		meta.TotalPages = int(math.Ceil(float64(meta.TotalRecords) / float64(page.Limit)))
+	}
+
+	if page.PageToken != "" {
+		cursor, err := decodePageToken(page.PageToken, sort)
+		if err != nil {
+			return nil, err
+		}
+		comparison := "<"
+		if cursor.Ascending {
+			comparison = ">"
+		}
+		value := cursor.value()
+		if t, ok := value.(time.Time); ok {
+			value = sqliteTime(t)
+		}
+		args = append(args, value, cursor.ID)
+		seek := fmt.Sprintf("(%s, id) %s (?, ?)", sortColumns[cursor.Field], comparison)
+		if where == "" {
+			where = " WHERE " + seek
+		} else {
+			where += " AND " + seek
+		}
+	} else {
+		meta.CurrentPage = (page.Offset / page.Limit) + 1
+	}
+
+	// one extra row tells whether there is a next page
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s%s%s
+		LIMIT ? OFFSET ?
+	`, columns, table, where, sort.orderBy(sortColumns))
+	rows, err := s.db.QueryContext(ctx, query, append(args, page.Limit+1, page.Offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []Job{}
+	for rows.Next() {
+		j, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &PaginatedJobs{Jobs: jobs, Meta: meta}
+	if len(jobs) > page.Limit {
+		result.Jobs = jobs[:page.Limit]
+		if cursor, ok := cursorAfter(result.Jobs[page.Limit-1], sort); ok {
+			result.NextPageToken = cursor.encode()
+		}
+	}
+
+	return result, nil
+}
+
+func (s *SQLiteStore) GetStats(ctx context.Context) (*JobStats, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM jobs GROUP BY status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := &JobStats{}
+	for rows.Next() {
+		var status string
+		var count int64
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+
+		switch JobStatus(status) {
+		case JobStatusPending:
+			stats.Pending = count
+		case JobStatusRunning:
+			stats.Running = count
+		case JobStatusCompleted:
+			stats.Completed = count
+		case JobStatusFailed:
+			stats.Failed = count
+		case JobStatusCancelled:
+			stats.Cancelled = count
+		case JobStatusSkipped:
+			stats.Skipped = count
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM dead_jobs`).Scan(&stats.Dead); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// UpdateJobProgress records the progress reported by the handler of a running
+// job. Like UpdateJobStatus it is fenced on lease and returns ErrLeaseLost when
+// the lease is no longer held.
+func (s *SQLiteStore) UpdateJobProgress(ctx context.Context, id int64, lease Lease, percent int, message string) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE jobs
+		SET progress = ?,
+			progress_message = NULLIF(?, ''),
+			progress_updated_at = ?
+		WHERE id = ? AND status = 'running' AND locked_by = ? AND lease_token = ?
+	`, percent, message, sqliteTime(time.Now()), id, lease.Owner, lease.Token)
+	if err != nil {
+		return fmt.Errorf("update progress: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("job %d: %w", id, ErrLeaseLost)
+	}
+	return nil
+}
+
+// ExtendLeases heartbeats the leases of running jobs, pushing their expiry
+// duration into the future. It returns the ids whose lease is no longer held,
+// so the caller can stop working on them.
+func (s *SQLiteStore) ExtendLeases(ctx context.Context, leases map[int64]Lease, duration time.Duration) ([]int64, error) {
+	if len(leases) == 0 {
+		return nil, nil
+	}
+
+	var lost []int64
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		expiresAt := sqliteTime(tx.now.Add(duration))
+		for id, lease := range leases {
+			res, err := tx.ExecContext(ctx, `
+				UPDATE jobs
+				SET lease_expires_at = ?
+				WHERE id = ? AND locked_by = ? AND lease_token = ?
+			`, expiresAt, id, lease.Owner, lease.Token)
+			if err != nil {
+				return fmt.Errorf("extend leases: %w", err)
+			}
+			if n, _ := res.RowsAffected(); n == 0 {
+				lost = append(lost, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(lost)
+	return lost, nil
+}
+
+// ReclaimExpiredJobs takes back running jobs whose lease expired, i.e. whose
+// worker stopped heartbeating. Each one counts as a failed attempt, closed as
+// lease_expired, and is retried or dead-lettered under policy(job).
+func (s *SQLiteStore) ReclaimExpiredJobs(ctx context.Context, policy func(Job) RetryPolicy) (int64, error) {
+	const reason = "job lease expired (worker lost)"
+
+	var reclaimed int64
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT `+jobColumns+`, max_retries FROM jobs
+			WHERE status = 'running' AND (lease_expires_at IS NULL OR lease_expires_at < ?)
+		`, sqliteTime(tx.now))
+		if err != nil {
+			return fmt.Errorf("select expired jobs: %w", err)
+		}
+		var expired []Job
+		var maxRetries []int
+		for rows.Next() {
+			var max int
+			job, err := scanSQLiteJob(rows, &max)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("scan expired job: %w", err)
+			}
+			expired = append(expired, *job)
+			maxRetries = append(maxRetries, max)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("select expired jobs: %w", err)
+		}
+
+		for i, job := range expired {
+			if err := tx.failJob(ctx, job.ID, job.RetryCount, maxRetries[i], reason, AttemptLeaseExpired, policy(job)); err != nil {
+				return err
+			}
+		}
+		reclaimed = int64(len(expired))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return reclaimed, nil
+}
+
+// CancelJob marks a pending or running job as cancelled. Pending jobs are never
+// claimed afterwards; workers running the job notice the status through
+// GetCancelledJobIDs and cancel the handler's context.
+func (s *SQLiteStore) CancelJob(ctx context.Context, id int64) (*Job, error) {
+	var job *Job
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		var current JobStatus
+		err := tx.QueryRowContext(ctx, `SELECT status FROM jobs WHERE id = ?`, id).Scan(&current)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("job %d: %w", id, ErrNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("fetch job: %w", err)
+		}
+
+		if current != JobStatusPending && current != JobStatusRunning {
+			return fmt.Errorf("job %d is %s: %w", id, current, ErrJobFinished)
+		}
+
+		job, err = scanSQLiteJob(tx.QueryRowContext(ctx, `
+			UPDATE jobs
+			SET status = ?, completed_at = ?, updated_at = ?
+			WHERE id = ?
+			RETURNING `+jobColumns, JobStatusCancelled, sqliteTime(tx.now), sqliteTime(tx.now), id))
+		if err != nil {
+			return fmt.Errorf("cancel job: %w", err)
+		}
+
+		if current == JobStatusRunning {
+			if err := tx.finishAttempt(ctx, id, AttemptCancelled, ""); err != nil {
+				return err
+			}
+		}
+
+		return tx.skipDependants(ctx, id, fmt.Sprintf("dependency job %d was cancelled", id))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetCancelledJobIDs returns the subset of ids whose jobs have been cancelled.
+func (s *SQLiteStore) GetCancelledJobIDs(ctx context.Context, ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM jobs
+		WHERE id IN (SELECT value FROM json_each(?)) AND status = ?
+	`, sqliteIDs(ids), JobStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("get cancelled jobs: %w", err)
+	}
+
+	return collectIDs(rows)
+}
@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"fmt"
+)
+
+// startAttempt opens the next attempt of a job claimed by worker.
+func (tx *sqliteTx) startAttempt(ctx context.Context, jobID int64, worker string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO job_attempts (job_id, attempt, worker, started_at)
+		SELECT ?1, COALESCE(MAX(attempt), 0) + 1, ?2, ?3
+		FROM job_attempts
+		WHERE job_id = ?1
+	`, jobID, worker, sqliteTime(tx.now))
+	if err != nil {
+		return fmt.Errorf("start attempt: %w", err)
+	}
+	return nil
+}
+
+// finishAttempt closes the open attempt of a job. An empty errMsg leaves the
+// error NULL.
+func (tx *sqliteTx) finishAttempt(ctx context.Context, jobID int64, outcome AttemptOutcome, errMsg string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE job_attempts
+		SET finished_at = ?, outcome = ?, error = NULLIF(?, '')
+		WHERE job_id = ? AND finished_at IS NULL
+	`, sqliteTime(tx.now), outcome, errMsg, jobID)
+	if err != nil {
+		return fmt.Errorf("finish attempt: %w", err)
+	}
+	return nil
+}
+
+// ListJobAttempts returns every attempt of a job, oldest first. It works for
+// pending, running, finished and dead-lettered jobs alike.
+func (s *SQLiteStore) ListJobAttempts(ctx context.Context, jobID int64) ([]JobAttempt, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT job_id, attempt, worker, started_at, finished_at, outcome, error
+		FROM job_attempts
+		WHERE job_id = ?
+		ORDER BY attempt
+	`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []JobAttempt
+	for rows.Next() {
+		var a JobAttempt
+		if err := rows.Scan(&a.JobID, &a.Attempt, &a.Worker, timestamp{dest: &a.StartedAt}, timestamp{nullDest: &a.FinishedAt}, &a.Outcome, &a.Error); err != nil {
+			return nil, fmt.Errorf("scan attempt: %w", err)
+		}
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list attempts: %w", err)
+	}
+	rows.Close()
+
+	if len(attempts) == 0 {
+		var exists bool
+		err := s.db.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM jobs WHERE id = ?1)
+				OR EXISTS (SELECT 1 FROM dead_jobs WHERE id = ?1)
+		`, jobID).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("check job: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("job %d: %w", jobID, ErrNotFound)
+		}
+	}
+
+	return attempts, nil
+}
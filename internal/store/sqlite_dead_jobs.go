@@ -0,0 +1,176 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+)
+
+// RetryDeadJob moves a dead-lettered job back into jobs under its original id
+// with a reset retry count. A non-nil payload replaces the stored one, and the
+// unique key is then recomputed with the spec unique returns for the job's
+// type; a job blocking the new key fails the replay with ErrAlreadyExists.
+func (s *SQLiteStore) RetryDeadJob(ctx context.Context, id int64, payload *string, unique func(jobType string) *UniqueSpec) (*Job, error) {
+	var job *Job
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		var jobType string
+		var uniqueKey sql.NullString
+		err := tx.QueryRowContext(ctx, `SELECT type, unique_key FROM dead_jobs WHERE id = ?`, id).Scan(&jobType, &uniqueKey)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("dead job %d: %w", id, ErrNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("get dead job: %w", err)
+		}
+
+		if payload != nil {
+			uniqueKey = sql.NullString{}
+			if spec := uniqueSpec(unique, jobType); spec != nil {
+				key, err := CreateJobParams{Type: jobType, Payload: *payload, Unique: spec}.UniqueKey()
+				if err != nil {
+					return fmt.Errorf("requeue dead job: %w", err)
+				}
+				existing, err := tx.uniqueDuplicate(ctx, key, spec)
+				if err != nil {
+					return err
+				}
+				if existing != nil {
+					return fmt.Errorf("dead job %d: duplicate of job %d: %w", id, existing.ID, ErrAlreadyExists)
+				}
+				uniqueKey = sql.NullString{String: key, Valid: true}
+			}
+		}
+
+		job, err = scanSQLiteJob(tx.QueryRowContext(ctx, `
+			INSERT INTO jobs (id, type, payload, status, created_at, updated_at, next_run_at, last_err, queue, priority, replay_count, replayed_at, retry_policy, timeout_ms, unique_key)
+			SELECT id, type, COALESCE(?2, payload), 'pending', ?3, ?3, ?3, last_err, queue, priority, replay_count + 1, ?3, retry_policy, timeout_ms, ?4
+			FROM dead_jobs
+			WHERE id = ?1
+			RETURNING `+jobColumns, id, payload, sqliteTime(tx.now), uniqueKey))
+		if err != nil {
+			return fmt.Errorf("requeue dead job: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM dead_jobs WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("requeue dead job: %w", err)
+		}
+
+		if err := tx.restoreDependants(ctx, []int64{id}); err != nil {
+			return err
+		}
+
+		tx.ready = append(tx.ready, id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Dead job requeued", "job_id", id, "replay_count", job.ReplayCount)
+
+	return job, nil
+}
+
+// RetryDeadJobs requeues every dead-lettered job matching filter and returns
+// their ids.
+func (s *SQLiteStore) RetryDeadJobs(ctx context.Context, filter DeadJobFilter) ([]int64, error) {
+	where, args := filter.sqliteWhere()
+
+	var ids []int64
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		now := sqliteTime(tx.now)
+		rows, err := tx.QueryContext(ctx, `
+			INSERT INTO jobs (id, type, payload, status, created_at, updated_at, next_run_at, last_err, queue, priority, replay_count, replayed_at, retry_policy, timeout_ms, unique_key)
+			SELECT id, type, payload, 'pending', ?, ?, ?, last_err, queue, priority, replay_count + 1, ?, retry_policy, timeout_ms, unique_key
+			FROM dead_jobs`+where+`
+			RETURNING id
+		`, append([]any{now, now, now, now}, args...)...)
+		if err != nil {
+			return fmt.Errorf("requeue dead jobs: %w", err)
+		}
+		if ids, err = collectIDs(rows); err != nil {
+			return fmt.Errorf("requeue dead jobs: %w", err)
+		}
+		slices.Sort(ids)
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM dead_jobs WHERE id IN (SELECT value FROM json_each(?))`, sqliteIDs(ids)); err != nil {
+			return fmt.Errorf("requeue dead jobs: %w", err)
+		}
+
+		if err := tx.restoreDependants(ctx, ids); err != nil {
+			return err
+		}
+
+		tx.ready = append(tx.ready, ids...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) > 0 {
+		logger.Info("Dead jobs requeued", "count", len(ids))
+	}
+
+	return ids, nil
+}
+
+// sqliteWhere renders the filter as a SQLite WHERE clause with its arguments.
+func (f DeadJobFilter) sqliteWhere() (string, []any) {
+	var conditions []string
+	var args []any
+
+	if len(f.IDs) > 0 {
+		conditions = append(conditions, "id IN (SELECT value FROM json_each(?))")
+		args = append(args, sqliteIDs(f.IDs))
+	}
+
+	if f.Type != "" {
+		conditions = append(conditions, "type = ?")
+		args = append(args, f.Type)
+	}
+
+	if f.ErrorContains != "" {
+		conditions = append(conditions, "instr(last_err, ?) > 0")
+		args = append(args, f.ErrorContains)
+	}
+
+	if f.FailedAfter != nil {
+		conditions = append(conditions, "failed_at >= ?")
+		args = append(args, sqliteTime(*f.FailedAfter))
+	}
+
+	if f.FailedBefore != nil {
+		conditions = append(conditions, "failed_at < ?")
+		args = append(args, sqliteTime(*f.FailedBefore))
+	}
+
+	if f.MinPriority != nil {
+		conditions = append(conditions, "priority >= ?")
+		args = append(args, *f.MinPriority)
+	}
+
+	if f.Queue != "" {
+		conditions = append(conditions, "queue = ?")
+		args = append(args, f.Queue)
+	}
+
+	if f.MinRetries != nil {
+		conditions = append(conditions, "retry_count >= ?")
+		args = append(args, *f.MinRetries)
+	}
+
+	if f.MaxRetries != nil {
+		conditions = append(conditions, "retry_count <= ?")
+		args = append(args, *f.MaxRetries)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/migrations"
+)
+
+// Migrate applies every pending SQLite migration in order, each in its own
+// transaction, and returns the ones it applied.
+func (s *SQLiteStore) Migrate(ctx context.Context) ([]Migration, error) {
+	all, done, err := s.migrationState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, mig := range all {
+		if _, ok := done[mig.Version]; ok {
+			continue
+		}
+		err := s.migrationTx(ctx, mig.Up, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+			mig.Version, mig.Name, sqliteTime(time.Now()))
+		if err != nil {
+			return applied, fmt.Errorf("apply migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		logger.Info("Migration applied", "version", mig.Version, "name", mig.Name)
+		applied = append(applied, mig)
+	}
+	return applied, nil
+}
+
+// MigrateDown reverts the last steps applied migrations, newest first, and
+// returns the ones it reverted.
+func (s *SQLiteStore) MigrateDown(ctx context.Context, steps int) ([]Migration, error) {
+	all, done, err := s.migrationState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for i := len(all) - 1; i >= 0 && len(reverted) < steps; i-- {
+		mig := all[i]
+		if _, ok := done[mig.Version]; !ok {
+			continue
+		}
+		err := s.migrationTx(ctx, mig.Down, `DELETE FROM schema_migrations WHERE version = ?`, mig.Version)
+		if err != nil {
+			return reverted, fmt.Errorf("revert migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		logger.Info("Migration reverted", "version", mig.Version, "name", mig.Name)
+		reverted = append(reverted, mig)
+	}
+	return reverted, nil
+}
+
+// MigrationStatus lists every known migration and when it was applied.
+func (s *SQLiteStore) MigrationStatus(ctx context.Context) ([]MigrationState, error) {
+	all, done, err := s.migrationState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var states []MigrationState
+	for _, mig := range all {
+		state := MigrationState{Migration: mig}
+		if at, ok := done[mig.Version]; ok {
+			state.AppliedAt = &at
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// migrationTx runs the statements of a migration and the bookkeeping query in
+// one transaction.
+func (s *SQLiteStore) migrationTx(ctx context.Context, statements, bookkeeping string, args ...any) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, statements); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// migrationState returns the embedded migrations and the versions already
+// applied. The database has a single writer, so no lock is needed.
+func (s *SQLiteStore) migrationState(ctx context.Context) ([]Migration, map[int64]time.Time, error) {
+	all, err := loadMigrations(migrations.SQLite)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	done := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, timestamp{dest: &at}); err != nil {
+			return nil, nil, fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		done[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	return all, done, nil
+}
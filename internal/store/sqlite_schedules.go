@@ -0,0 +1,186 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// scanSQLiteSchedule scans a row selected with scheduleColumns.
+func scanSQLiteSchedule(row sqliteRow) (*Schedule, error) {
+	var sc Schedule
+	err := row.Scan(
+		&sc.ID,
+		&sc.Name,
+		&sc.CronExpr,
+		&sc.Timezone,
+		&sc.JobType,
+		&sc.PayloadTemplate,
+		&sc.CatchupPolicy,
+		&sc.Paused,
+		timestamp{dest: &sc.NextRunAt},
+		timestamp{nullDest: &sc.LastRunAt},
+		timestamp{dest: &sc.CreatedAt},
+		timestamp{dest: &sc.UpdatedAt},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (s *SQLiteStore) CreateSchedule(ctx context.Context, params CreateScheduleParams) (*Schedule, error) {
+	now := sqliteTime(time.Now())
+	query := `
+		INSERT INTO schedules (name, cron_expr, timezone, job_type, payload_template, catchup_policy, next_run_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING ` + scheduleColumns
+
+	sc, err := scanSQLiteSchedule(s.db.QueryRowContext(ctx, query,
+		params.Name,
+		params.CronExpr,
+		params.Timezone,
+		params.JobType,
+		params.PayloadTemplate,
+		params.CatchupPolicy,
+		sqliteTime(params.NextRunAt),
+		now,
+		now,
+	))
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return nil, fmt.Errorf("schedule %q: %w", params.Name, ErrAlreadyExists)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("insert schedule: %w", err)
+	}
+	return sc, nil
+}
+
+func (s *SQLiteStore) GetSchedule(ctx context.Context, id int64) (*Schedule, error) {
+	sc, err := scanSQLiteSchedule(s.db.QueryRowContext(ctx, `SELECT `+scheduleColumns+` FROM schedules WHERE id = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("get schedule: %w", err)
+	}
+	return sc, nil
+}
+
+func (s *SQLiteStore) ListSchedules(ctx context.Context) ([]Schedule, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+scheduleColumns+` FROM schedules ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("list schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []Schedule{}
+	for rows.Next() {
+		sc, err := scanSQLiteSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan schedule: %w", err)
+		}
+		schedules = append(schedules, *sc)
+	}
+
+	return schedules, rows.Err()
+}
+
+func (s *SQLiteStore) SetSchedulePaused(ctx context.Context, id int64, paused bool, nextRunAt time.Time) (*Schedule, error) {
+	query := `
+		UPDATE schedules
+		SET paused = ?,
+			next_run_at = ?,
+			updated_at = ?
+		WHERE id = ?
+		RETURNING ` + scheduleColumns
+
+	sc, err := scanSQLiteSchedule(s.db.QueryRowContext(ctx, query, paused, sqliteTime(nextRunAt), sqliteTime(time.Now()), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+		}
+		return nil, fmt.Errorf("update schedule: %w", err)
+	}
+	return sc, nil
+}
+
+func (s *SQLiteStore) DeleteSchedule(ctx context.Context, id int64) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM schedules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete schedule: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("schedule %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) GetDueSchedules(ctx context.Context, now time.Time, limit int) ([]Schedule, error) {
+	query := `
+		SELECT ` + scheduleColumns + `
+		FROM schedules
+		WHERE paused = 0 AND next_run_at <= ?
+		ORDER BY next_run_at ASC
+		LIMIT ?
+	`
+	rows, err := s.db.QueryContext(ctx, query, sqliteTime(now), limit)
+	if err != nil {
+		return nil, fmt.Errorf("get due schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []Schedule
+	for rows.Next() {
+		sc, err := scanSQLiteSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan schedule: %w", err)
+		}
+		schedules = append(schedules, *sc)
+	}
+
+	return schedules, rows.Err()
+}
+
+// FireSchedule advances the schedule to nextRunAt and enqueues runs in a single
+// transaction. Like the Postgres store it only does so while the schedule still
+// has the next_run_at that was read, so a tick that was already fired is not
+// fired again; the caller gets false.
+func (s *SQLiteStore) FireSchedule(ctx context.Context, schedule Schedule, runs []CreateJobParams, nextRunAt time.Time) (bool, error) {
+	var fired bool
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE schedules
+			SET next_run_at = ?1,
+				last_run_at = CASE WHEN ?2 > 0 THEN ?3 ELSE last_run_at END,
+				updated_at = ?3
+			WHERE id = ?4 AND next_run_at = ?5 AND paused = 0
+		`, sqliteTime(nextRunAt), len(runs), sqliteTime(tx.now), schedule.ID, sqliteTime(schedule.NextRunAt))
+		if err != nil {
+			return fmt.Errorf("advance schedule: %w", err)
+		}
+
+		if n, _ := result.RowsAffected(); n == 0 {
+			return nil
+		}
+
+		for _, run := range runs {
+			if _, err := tx.createJob(ctx, run); err != nil {
+				return err
+			}
+		}
+		fired = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return fired, nil
+}
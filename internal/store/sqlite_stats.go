@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetJobTypeStats reports, per job type, how many jobs finished in the last
+// params.Window and how long they waited and ran, overall and per bucket.
+// Types without activity in the window are left out. SQLite has no
+// percentile_cont, so the rows of the window are aggregated in Go.
+func (s *SQLiteStore) GetJobTypeStats(ctx context.Context, params TypeStatsParams) ([]JobTypeStats, error) {
+	end := time.Now().UTC().Truncate(time.Microsecond)
+	start := end.Add(-params.Window)
+	args := []any{sqliteTime(start), sqliteTime(end), params.Type}
+
+	var samples []typeStatsSample
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT type, status, started_at, completed_at
+		FROM jobs
+		WHERE status IN ('completed', 'failed') AND completed_at >= ?1 AND completed_at < ?2
+			AND (?3 = '' OR type = ?3)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get finished stats: %w", err)
+	}
+	for rows.Next() {
+		var sample typeStatsSample
+		var status JobStatus
+		var startedAt *time.Time
+		if err := rows.Scan(&sample.jobType, &status, timestamp{nullDest: &startedAt}, timestamp{dest: &sample.at}); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan finished stats: %w", err)
+		}
+		sample.kind = sampleFailed
+		if status == JobStatusCompleted {
+			sample.kind = sampleCompleted
+		}
+		if startedAt != nil {
+			d := sample.at.Sub(*startedAt)
+			sample.value = &d
+		}
+		samples = append(samples, sample)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get finished stats: %w", err)
+	}
+
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT type, failed_at
+		FROM dead_jobs
+		WHERE failed_at >= ?1 AND failed_at < ?2
+			AND (?3 = '' OR type = ?3)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get finished stats: %w", err)
+	}
+	for rows.Next() {
+		sample := typeStatsSample{kind: sampleFailed}
+		if err := rows.Scan(&sample.jobType, timestamp{dest: &sample.at}); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan finished stats: %w", err)
+		}
+		samples = append(samples, sample)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get finished stats: %w", err)
+	}
+
+	rows, err = s.db.QueryContext(ctx, `
+		SELECT type, started_at, next_run_at
+		FROM jobs
+		WHERE started_at >= ?1 AND started_at < ?2
+			AND (?3 = '' OR type = ?3)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get wait stats: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		sample := typeStatsSample{kind: sampleStarted}
+		var nextRunAt time.Time
+		if err := rows.Scan(&sample.jobType, timestamp{dest: &sample.at}, timestamp{dest: &nextRunAt}); err != nil {
+			return nil, fmt.Errorf("scan wait stats: %w", err)
+		}
+		wait := max(sample.at.Sub(nextRunAt), 0)
+		sample.value = &wait
+		samples = append(samples, sample)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get wait stats: %w", err)
+	}
+
+	return aggregateTypeStats(params, start, end, samples), nil
+}
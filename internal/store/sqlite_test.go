@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	ctx := context.Background()
+	s, err := NewSQLiteStore(ctx, filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	if _, err := s.Migrate(ctx); err != nil {
+		s.Close()
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	return s
+}
+
+func TestSQLiteStore_Conformance(t *testing.T) {
+	logger.Init()
+	testStorerConformance(t, func(t *testing.T) Storer {
+		return newTestSQLiteStore(t)
+	})
+}
+
+func TestSQLiteStore_Migrations(t *testing.T) {
+	logger.Init()
+	ctx := context.Background()
+	s := newTestSQLiteStore(t)
+	defer s.Close()
+
+	// 1. Migrating again applies nothing
+	applied, err := s.Migrate(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Fatalf("Expected no pending migrations, got %d (%v)", len(applied), err)
+	}
+
+	// 2. Every migration reverts and applies again
+	states, err := s.MigrationStatus(ctx)
+	if err != nil {
+		t.Fatalf("MigrationStatus failed: %v", err)
+	}
+	reverted, err := s.MigrateDown(ctx, len(states))
+	if err != nil || len(reverted) != len(states) {
+		t.Fatalf("Expected %d reverted migrations, got %d (%v)", len(states), len(reverted), err)
+	}
+	if states, _ := s.MigrationStatus(ctx); states[0].AppliedAt != nil {
+		t.Errorf("Expected migration %d to be pending after reverting", states[0].Version)
+	}
+	if applied, err := s.Migrate(ctx); err != nil || len(applied) != len(states) {
+		t.Fatalf("Expected %d applied migrations, got %d (%v)", len(states), len(applied), err)
+	}
+	mustCreate(t, s, CreateJobParams{Type: "test:migrate", Payload: `{}`})
+}
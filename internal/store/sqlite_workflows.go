@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+)
+
+// CreateWorkflow inserts the workflow, its jobs and their dependency edges in a
+// single transaction, so no job of the workflow can be claimed before the whole
+// graph is stored. A unique step with a duplicate fails the workflow with
+// ErrAlreadyExists.
+func (s *SQLiteStore) CreateWorkflow(ctx context.Context, params CreateWorkflowParams) (*Workflow, error) {
+	wf := &Workflow{Name: params.Name}
+	err := s.withTx(ctx, func(tx *sqliteTx) error {
+		err := tx.QueryRowContext(ctx, `INSERT INTO workflows (name, created_at) VALUES (?, ?) RETURNING id`, params.Name, sqliteTime(tx.now)).
+			Scan(&wf.ID)
+		if err != nil {
+			return fmt.Errorf("insert workflow: %w", err)
+		}
+		wf.CreatedAt = tx.now
+
+		ids := make(map[string]int64, len(params.Steps))
+		for _, step := range params.Steps {
+			if _, ok := ids[step.Key]; ok {
+				return fmt.Errorf("duplicate workflow step %q", step.Key)
+			}
+
+			job, err := tx.createJob(ctx, step.Job)
+			if err != nil {
+				return fmt.Errorf("create step %q: %w", step.Key, err)
+			}
+			if job.Duplicate {
+				return fmt.Errorf("create step %q: duplicate of job %d: %w", step.Key, job.ID, ErrAlreadyExists)
+			}
+			ids[step.Key] = job.ID
+
+			_, err = tx.ExecContext(ctx, `INSERT INTO workflow_jobs (workflow_id, job_id, step) VALUES (?, ?, ?)`,
+				wf.ID, job.ID, step.Key)
+			if err != nil {
+				return fmt.Errorf("insert workflow job: %w", err)
+			}
+
+			for _, dep := range step.DependsOn {
+				parentID, ok := ids[dep]
+				if !ok {
+					return fmt.Errorf("step %q depends on %q, which is not declared before it", step.Key, dep)
+				}
+				_, err = tx.ExecContext(ctx, `INSERT INTO job_dependencies (job_id, depends_on) VALUES (?, ?)`, job.ID, parentID)
+				if err != nil {
+					return fmt.Errorf("insert dependency: %w", err)
+				}
+			}
+
+			wf.Steps = append(wf.Steps, WorkflowStep{
+				Key:       step.Key,
+				JobID:     job.ID,
+				Type:      job.Type,
+				Status:    job.Status,
+				DependsOn: step.DependsOn,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wf, nil
+}
+
+// GetWorkflow returns the workflow with the current status of each job. A job
+// that is no longer in the jobs table was either dead-lettered or archived, in
+// which case its final status was recorded in workflow_jobs.
+func (s *SQLiteStore) GetWorkflow(ctx context.Context, id int64) (*Workflow, error) {
+	wf := &Workflow{ID: id}
+	err := s.db.QueryRowContext(ctx, `SELECT name, created_at FROM workflows WHERE id = ?`, id).
+		Scan(&wf.Name, timestamp{dest: &wf.CreatedAt})
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("workflow %d: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get workflow: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT wj.step, wj.job_id,
+			COALESCE(j.type, d.type, ''),
+			COALESCE(j.status, CASE WHEN d.id IS NOT NULL THEN ?2 END, wj.status),
+			COALESCE(j.last_err, d.last_err)
+		FROM workflow_jobs wj
+		LEFT JOIN jobs j ON j.id = wj.job_id
+		LEFT JOIN dead_jobs d ON d.id = wj.job_id
+		WHERE wj.workflow_id = ?1
+		ORDER BY wj.job_id
+	`, id, JobStatusDead)
+	if err != nil {
+		return nil, fmt.Errorf("get workflow jobs: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make(map[int64]string)
+	index := make(map[int64]int)
+	for rows.Next() {
+		var step WorkflowStep
+		if err := rows.Scan(&step.Key, &step.JobID, &step.Type, &step.Status, &step.ErrorMessage); err != nil {
+			return nil, fmt.Errorf("scan workflow job: %w", err)
+		}
+		keys[step.JobID] = step.Key
+		index[step.JobID] = len(wf.Steps)
+		wf.Steps = append(wf.Steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get workflow jobs: %w", err)
+	}
+	rows.Close()
+
+	depRows, err := s.db.QueryContext(ctx, `
+		SELECT d.job_id, d.depends_on
+		FROM job_dependencies d
+		JOIN workflow_jobs wj ON wj.job_id = d.job_id
+		WHERE wj.workflow_id = ?
+		ORDER BY d.job_id, d.depends_on
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("get workflow dependencies: %w", err)
+	}
+	defer depRows.Close()
+
+	for depRows.Next() {
+		var jobID, parentID int64
+		if err := depRows.Scan(&jobID, &parentID); err != nil {
+			return nil, fmt.Errorf("scan workflow dependency: %w", err)
+		}
+		i := index[jobID]
+		wf.Steps[i].DependsOn = append(wf.Steps[i].DependsOn, keys[parentID])
+	}
+
+	return wf, depRows.Err()
+}
+
+// skipDependants marks every pending job that transitively depends on jobID as
+// skipped, recording reason as its error.
+func (tx *sqliteTx) skipDependants(ctx context.Context, jobID int64, reason string) error {
+	res, err := tx.ExecContext(ctx, `
+		WITH RECURSIVE dependants AS (
+			SELECT job_id FROM job_dependencies WHERE depends_on = ?
+			UNION
+			SELECT d.job_id FROM job_dependencies d JOIN dependants ON d.depends_on = dependants.job_id
+		)
+		UPDATE jobs
+		SET status = ?, last_err = ?, completed_at = ?, updated_at = ?
+		WHERE id IN (SELECT job_id FROM dependants) AND status = ?
+	`, jobID, JobStatusSkipped, reason, sqliteTime(tx.now), sqliteTime(tx.now), JobStatusPending)
+	if err != nil {
+		return fmt.Errorf("skip dependants: %w", err)
+	}
+
+	if n, _ := res.RowsAffected(); n > 0 {
+		logger.Info("Skipped dependant jobs", "job_id", jobID, "count", n)
+	}
+	return nil
+}
+
+// restoreDependants returns the jobs skipped because of the requeued jobs ids to
+// pending, level by level, as long as none of their other dependencies is
+// dead, failed, cancelled or still skipped.
+func (tx *sqliteTx) restoreDependants(ctx context.Context, ids []int64) error {
+	for len(ids) > 0 {
+		rows, err := tx.QueryContext(ctx, `
+			UPDATE jobs
+			SET status = ?2, last_err = NULL, completed_at = NULL, updated_at = ?6
+			WHERE status = ?3
+				AND id IN (SELECT job_id FROM job_dependencies WHERE depends_on IN (SELECT value FROM json_each(?1)))
+				AND NOT EXISTS (
+					SELECT 1 FROM job_dependencies d
+					LEFT JOIN jobs p ON p.id = d.depends_on
+					WHERE d.job_id = jobs.id AND NOT d.resolved
+						AND (p.id IS NULL OR p.status IN (?3, ?4, ?5))
+				)
+			RETURNING id
+		`, sqliteIDs(ids), JobStatusPending, JobStatusSkipped, JobStatusFailed, JobStatusCancelled, sqliteTime(tx.now))
+		if err != nil {
+			return fmt.Errorf("restore dependants: %w", err)
+		}
+
+		if ids, err = collectIDs(rows); err != nil {
+			return fmt.Errorf("restore dependants: %w", err)
+		}
+	}
+	return nil
+}
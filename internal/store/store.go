@@ -2,20 +2,77 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"time"
 )
 
+var ErrNotFound = errors.New("not found")
+
+// ErrJobFinished is returned when an operation needs a pending or running job
+// but the job already reached a final status.
+var ErrJobFinished = errors.New("job already finished")
+
+// ErrLeaseLost is returned when a worker tries to finish an attempt of a job
+// whose lease it no longer holds, e.g. because the lease expired and the job
+// was reclaimed by another worker.
+var ErrLeaseLost = errors.New("job lease lost")
+
+// ErrAlreadyExists is returned when creating something whose unique name is
+// already taken, e.g. a schedule.
+var ErrAlreadyExists = errors.New("already exists")
+
+// Listener is implemented by stores that can announce jobs as soon as they
+// become ready, so pools do not have to wait for their next poll.
+type Listener interface {
+	ListenJobs(ctx context.Context, queue string) <-chan struct{}
+}
+
+// JobPartitioner is implemented by stores that partition jobs by creation
+// time, so old jobs can be removed a whole partition at a time instead of
+// with BatchDeleteJobs.
+type JobPartitioner interface {
+	EnsureJobPartitions(ctx context.Context, ahead time.Duration) ([]string, error)
+	ListJobPartitions(ctx context.Context) ([]JobPartition, error)
+	DetachJobPartition(ctx context.Context, name string) error
+	ExportJobPartition(ctx context.Context, name string, w io.Writer) (int64, error)
+	DropJobPartition(ctx context.Context, name string) error
+}
+
 type Storer interface {
-	CreateJob(ctx context.Context, jobType, payload string) (*Job, error)
+	CreateJob(ctx context.Context, params CreateJobParams) (*Job, error)
+	CreateJobs(ctx context.Context, params []CreateJobParams) ([]Job, error)
 	GetJobByID(ctx context.Context, id int64) (*Job, error)
-	GetPendingJobs(ctx context.Context, limit int) ([]Job, error)
-	UpdateJobStatus(ctx context.Context, status JobStatus, id int64) error
-	HandleJobFailure(ctx context.Context, jobId int64, errMsg string) error
+	GetPendingJobs(ctx context.Context, params ClaimParams) ([]Job, error)
+	UpdateJobStatus(ctx context.Context, status JobStatus, id int64, lease Lease) error
+	CompleteJob(ctx context.Context, id int64, lease Lease, result json.RawMessage) error
+	UpdateJobProgress(ctx context.Context, id int64, lease Lease, percent int, message string) error
+	HandleJobFailure(ctx context.Context, jobId int64, errMsg string, policy RetryPolicy, lease Lease) error
 	GetArchivedJobs(ctx context.Context, duration time.Duration, limit int) ([]Job, error)
 	BatchDeleteJobs(ctx context.Context, ids []int64) error
-	ListJobs(ctx context.Context, limit, offset int) (*PaginatedJobs, error)
-	ListDeadJobs(ctx context.Context, limit, offset int) (*PaginatedJobs, error)
+	ListJobs(ctx context.Context, filter JobFilter, sort JobSort, page PageParams) (*PaginatedJobs, error)
+	ListDeadJobs(ctx context.Context, filter DeadJobFilter, sort JobSort, page PageParams) (*PaginatedJobs, error)
 	GetStats(ctx context.Context) (*JobStats, error)
-	RepeatStuckJobs(ctx context.Context, interval time.Duration) (int64, error)
+	GetJobTypeStats(ctx context.Context, params TypeStatsParams) ([]JobTypeStats, error)
+	ExtendLeases(ctx context.Context, leases map[int64]Lease, duration time.Duration) ([]int64, error)
+	ReclaimExpiredJobs(ctx context.Context, policy func(Job) RetryPolicy) (int64, error)
+	CancelJob(ctx context.Context, id int64) (*Job, error)
+	GetCancelledJobIDs(ctx context.Context, ids []int64) ([]int64, error)
+	RetryDeadJob(ctx context.Context, id int64, payload *string, unique func(jobType string) *UniqueSpec) (*Job, error)
+	RetryDeadJobs(ctx context.Context, filter DeadJobFilter) ([]int64, error)
+	ListJobAttempts(ctx context.Context, jobID int64) ([]JobAttempt, error)
+
+	CreateSchedule(ctx context.Context, params CreateScheduleParams) (*Schedule, error)
+	GetSchedule(ctx context.Context, id int64) (*Schedule, error)
+	ListSchedules(ctx context.Context) ([]Schedule, error)
+	SetSchedulePaused(ctx context.Context, id int64, paused bool, nextRunAt time.Time) (*Schedule, error)
+	DeleteSchedule(ctx context.Context, id int64) error
+	GetDueSchedules(ctx context.Context, now time.Time, limit int) ([]Schedule, error)
+	FireSchedule(ctx context.Context, schedule Schedule, runs []CreateJobParams, nextRunAt time.Time) (bool, error)
+
+	CreateWorkflow(ctx context.Context, params CreateWorkflowParams) (*Workflow, error)
+	GetWorkflow(ctx context.Context, id int64) (*Workflow, error)
+
 	Close()
 }
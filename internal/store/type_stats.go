@@ -0,0 +1,135 @@
+package store
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// typeStatsSample is a job that finished or was claimed at some point. The
+// stores without SQL aggregates collect them and leave the counting to
+// aggregateTypeStats.
+type typeStatsSample struct {
+	jobType string
+	at      time.Time
+	kind    sampleKind
+	// value is the run time of a finished job or the wait of a started one;
+	// nil for finished jobs that never started, such as dead-lettered ones
+	value *time.Duration
+}
+
+type sampleKind int
+
+const (
+	sampleCompleted sampleKind = iota
+	sampleFailed
+	sampleStarted
+)
+
+// aggregateTypeStats counts the samples of the window [start, end) per job
+// type, overall and per params.Bucket, like the Postgres GetJobTypeStats.
+func aggregateTypeStats(params TypeStatsParams, start, end time.Time, samples []typeStatsSample) []JobTypeStats {
+	bucket := params.Bucket
+	if bucket <= 0 {
+		bucket = params.Window
+	}
+	buckets := 1
+	if params.Bucket > 0 {
+		buckets = int(math.Ceil(float64(params.Window) / float64(bucket)))
+	}
+
+	// counts collects the samples of a type in the whole window (index 0) and
+	// in each bucket (index i+1)
+	type counts struct {
+		completed, failed, started []int64
+		durations, waits           [][]time.Duration
+	}
+	byType := map[string]*counts{}
+	record := func(c *counts, i int, s typeStatsSample) {
+		switch s.kind {
+		case sampleCompleted:
+			c.completed[i]++
+		case sampleFailed:
+			c.failed[i]++
+		case sampleStarted:
+			c.started[i]++
+			if s.value != nil {
+				c.waits[i] = append(c.waits[i], *s.value)
+			}
+			return
+		}
+		if s.value != nil {
+			c.durations[i] = append(c.durations[i], *s.value)
+		}
+	}
+
+	for _, s := range samples {
+		if s.at.Before(start) || !s.at.Before(end) || (params.Type != "" && s.jobType != params.Type) {
+			continue
+		}
+		c, ok := byType[s.jobType]
+		if !ok {
+			c = &counts{
+				completed: make([]int64, buckets+1),
+				failed:    make([]int64, buckets+1),
+				started:   make([]int64, buckets+1),
+				durations: make([][]time.Duration, buckets+1),
+				waits:     make([][]time.Duration, buckets+1),
+			}
+			byType[s.jobType] = c
+		}
+		record(c, 0, s)
+		if params.Bucket > 0 {
+			if b := int(s.at.Sub(start) / bucket); b < buckets {
+				record(c, b+1, s)
+			}
+		}
+	}
+
+	window := func(c *counts, i int, from, to time.Time) WindowStats {
+		w := WindowStats{Start: from, End: to, Completed: c.completed[i], Failed: c.failed[i], Started: c.started[i]}
+		w.AvgDuration, w.P95Duration = summarize(c.durations[i])
+		w.AvgWait, w.P95Wait = summarize(c.waits[i])
+		return w
+	}
+
+	stats := make([]JobTypeStats, 0, len(byType))
+	for jobType, c := range byType {
+		st := JobTypeStats{Type: jobType, Total: window(c, 0, start, end)}
+		if params.Bucket > 0 {
+			for i := 0; i < buckets; i++ {
+				bucketEnd := start.Add(time.Duration(i+1) * bucket)
+				if bucketEnd.After(end) {
+					bucketEnd = end
+				}
+				st.Buckets = append(st.Buckets, window(c, i+1, start.Add(time.Duration(i)*bucket), bucketEnd))
+			}
+		}
+		stats = append(stats, st)
+	}
+	slices.SortFunc(stats, func(a, b JobTypeStats) int { return strings.Compare(a.Type, b.Type) })
+
+	return stats
+}
+
+// summarize returns the average and the interpolated 95th percentile of
+// values, like AVG and percentile_cont(0.95), or zeros if there are none.
+func summarize(values []time.Duration) (avg, p95 time.Duration) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	slices.Sort(values)
+
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+	}
+
+	rank := 0.95 * float64(len(values)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	p := float64(values[lower]) + (rank-float64(lower))*float64(values[upper]-values[lower])
+
+	return time.Duration(sum / float64(len(values))), time.Duration(p)
+}
@@ -9,3 +9,11 @@ type Handler interface {
 	Handle(ctx context.Context, job store.Job) error
 }
 
+// ResultHandler is implemented by handlers whose jobs produce an output, such
+// as the location of a generated file. When a registered handler implements
+// it, the pool calls HandleResult instead of Handle and stores the result,
+// marshalled to JSON, with the completed job. A nil result stores nothing.
+type ResultHandler interface {
+	Handler
+	HandleResult(ctx context.Context, job store.Job) (any, error)
+}
@@ -2,6 +2,10 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -10,49 +14,133 @@ import (
 	"github.com/bhanuprakaash/job-scheduler/internal/store"
 )
 
+// ErrJobCancelled is the cause of a handler's context being cancelled because
+// the job was cancelled through the API. Handlers can tell it apart from a
+// shutdown with context.Cause(ctx).
+var ErrJobCancelled = errors.New("job cancelled")
+
+// ErrJobTimedOut is the cause of a handler's context being cancelled because
+// the job ran longer than its timeout.
+var ErrJobTimedOut = errors.New("job timed out")
+
+// claimBatchSize is the maximum number of jobs claimed by one dispatch.
+const claimBatchSize = 10
+
+// defaultLeaseDuration is how long a claimed job stays leased to a pool
+// without a heartbeat.
+const defaultLeaseDuration = 30 * time.Second
+
+// PoolConfig describes the queue a Pool serves and how it polls for work.
+type PoolConfig struct {
+	Queue         string
+	Workers       int
+	PollInterval  time.Duration
+	PriorityAging time.Duration
+	// LeaseDuration is how long a claimed job stays owned by the pool without
+	// a heartbeat. Leases are renewed every third of it; jobs whose lease
+	// expires are reclaimed and run again.
+	LeaseDuration time.Duration
+	// Identity is recorded as the owner of claimed jobs. It defaults to
+	// host:pid:queue.
+	Identity string
+	// ProgressInterval is the minimum time between two progress writes of a
+	// job reported through ReportProgress.
+	ProgressInterval time.Duration
+}
+
 type Pool struct {
-	store        store.Storer
-	registry     *Registry
-	numWorkers   int
-	pollInterval time.Duration
-	stopCh       chan struct{}
-	jobCh        chan store.Job
-	wg           sync.WaitGroup
+	store         store.Storer
+	registry      *Registry
+	queue         string
+	numWorkers    int
+	pollInterval  time.Duration
+	priorityAging time.Duration
+	leaseDuration time.Duration
+	identity      string
+	progressEvery time.Duration
+	stopCh        chan struct{}
+	drainedCh     chan struct{}
+	jobCh         chan store.Job
+	wg            sync.WaitGroup
+
+	// running maps the jobs claimed by the pool, whether queued or being
+	// processed, to their leases and the cancel funcs of their handler contexts
+	runningMu sync.Mutex
+	running   map[int64]runningJob
+}
+
+type runningJob struct {
+	lease store.Lease
+	// cancel is nil until a worker starts processing the job
+	cancel context.CancelCauseFunc
+	// dropped marks a queued job that must not be started because it was
+	// cancelled or its lease was lost
+	dropped bool
 }
 
-func NewPool(s store.Storer, registry *Registry, numWorkers int, pollInterval time.Duration) *Pool {
+func NewPool(s store.Storer, registry *Registry, cfg PoolConfig) *Pool {
+	queue := cfg.Queue
+	if queue == "" {
+		queue = store.DefaultQueue
+	}
+
+	leaseDuration := cfg.LeaseDuration
+	if leaseDuration <= 0 {
+		leaseDuration = defaultLeaseDuration
+	}
+
+	progressEvery := cfg.ProgressInterval
+	if progressEvery <= 0 {
+		progressEvery = defaultProgressInterval
+	}
+
+	identity := cfg.Identity
+	if identity == "" {
+		host, _ := os.Hostname()
+		identity = fmt.Sprintf("%s:%d:%s", host, os.Getpid(), queue)
+	}
+
 	return &Pool{
-		store:        s,
-		numWorkers:   numWorkers,
-		registry:     registry,
-		pollInterval: pollInterval,
-		stopCh:       make(chan struct{}),
-		jobCh:        make(chan store.Job, 10),
+		store:         s,
+		numWorkers:    cfg.Workers,
+		registry:      registry,
+		queue:         queue,
+		pollInterval:  cfg.PollInterval,
+		priorityAging: cfg.PriorityAging,
+		leaseDuration: leaseDuration,
+		identity:      identity,
+		progressEvery: progressEvery,
+		stopCh:        make(chan struct{}),
+		drainedCh:     make(chan struct{}),
+		jobCh:         make(chan store.Job, 10),
+		running:       make(map[int64]runningJob),
 	}
 }
 
 func (p *Pool) Start(ctx context.Context) {
-	logger.Info("worker pool started", "workers", p.numWorkers)
+	logger.Info("worker pool started", "queue", p.queue, "workers", p.numWorkers)
 	for i := 0; i < p.numWorkers; i++ {
 		p.wg.Add(1)
 		go p.worker(ctx, i+1)
 	}
 
 	go p.StartDispatcher(ctx)
+	go p.heartbeatLoop(ctx)
 }
 
 func (p *Pool) Stop() {
-	logger.Info("worker pool shutting down")
+	logger.Info("worker pool shutting down", "queue", p.queue)
 	close(p.stopCh)
 	p.wg.Wait()
-	logger.Info("worker pool stopped")
+	close(p.drainedCh)
+	logger.Info("worker pool stopped", "queue", p.queue)
 
 }
 
 func (p *Pool) worker(ctx context.Context, id int) {
 	defer p.wg.Done()
 
-	logger.Info("Worker started: ", "worker", id)
+	logger.Info("Worker started: ", "queue", p.queue, "worker", id)
 
 	for job := range p.jobCh {
 		logger.Info("Worker picked up job", "id", id, "job_id", job.ID)
@@ -63,14 +151,28 @@ func (p *Pool) worker(ctx context.Context, id int) {
 
 }
 
+// StartDispatcher claims jobs and hands them to the workers. If the store is a
+// store.Listener, the dispatcher claims as soon as it is notified of new jobs
+// and polling only catches delayed jobs and missed notifications. A full batch
+// is followed by another claim right away.
 func (p *Pool) StartDispatcher(ctx context.Context) {
-	logger.Info("starting dispatcher")
+	logger.Info("starting dispatcher", "queue", p.queue)
 	defer close(p.jobCh)
 
 	ticker := time.NewTicker(p.pollInterval)
 	defer ticker.Stop()
 
-	reaperTicker := time.NewTicker(1 * time.Minute)
+	var notify <-chan struct{}
+	if l, ok := p.store.(store.Listener); ok {
+		listenCtx, stopListening := context.WithCancel(ctx)
+		defer stopListening()
+		notify = l.ListenJobs(listenCtx, p.queue)
+	}
+
+	// more fires right away after a full batch was claimed
+	var more <-chan time.Time
+
+	reaperTicker := time.NewTicker(p.leaseDuration)
 	defer reaperTicker.Stop()
 
 	for {
@@ -80,40 +182,79 @@ func (p *Pool) StartDispatcher(ctx context.Context) {
 			return
 
 		case <-reaperTicker.C:
-			stuckTime := 10 * time.Minute
-			count, err := p.store.RepeatStuckJobs(ctx, stuckTime)
+			// a running job whose lease expired has lost its worker; that
+			// counts as a failed attempt under the job's retry policy
+			count, err := p.store.ReclaimExpiredJobs(ctx, p.registry.RetryPolicy)
 			if err != nil {
-				logger.Error("failed to reset stuck jobs", "error", err)
+				logger.Error("failed to reclaim expired jobs", "error", err)
 				continue
 			}
 			if count > 0 {
-				logger.Info("Reaper reset stuck jobs", "count", count)
+				logger.Info("Reaper reclaimed jobs with expired leases", "count", count)
 			}
+			continue
 
-		case <-ticker.C:
-			jobs, err := p.store.GetPendingJobs(ctx, 10)
-			if err != nil {
-				logger.Error("fetching jobs", "err", err)
+		case _, ok := <-notify:
+			if !ok {
+				notify = nil
 				continue
 			}
 
-			if len(jobs) > 0 {
-				logger.Info("Dispatcher found jobs", "jobs", len(jobs))
-			}
+		case <-more:
+			// the last batch was full, there may be more ready jobs
 
-			for _, job := range jobs {
-				select {
-				case <-p.stopCh:
-					return
-				case <-ctx.Done():
-					return
-				case p.jobCh <- job:
-					// inserted
-				}
-			}
+		case <-ticker.C:
+			p.cancelRunningJobs(ctx)
+		}
+
+		full, stopped := p.dispatch(ctx)
+		if stopped {
+			return
+		}
+		more = nil
+		if full {
+			more = time.After(0)
+		}
+	}
+
+}
+
+// dispatch claims a batch of ready jobs and queues them for the workers. It
+// reports whether the batch was full and whether the pool is stopping.
+func (p *Pool) dispatch(ctx context.Context) (full, stopped bool) {
+	jobs, err := p.store.GetPendingJobs(ctx, store.ClaimParams{
+		Queue:         p.queue,
+		Limit:         claimBatchSize,
+		PriorityAging: p.priorityAging,
+		Owner:         p.identity,
+		LeaseDuration: p.leaseDuration,
+	})
+	if err != nil {
+		logger.Error("fetching jobs", "err", err)
+		return false, false
+	}
+
+	if len(jobs) > 0 {
+		logger.Info("Dispatcher found jobs", "queue", p.queue, "jobs", len(jobs))
+	}
+
+	for _, job := range jobs {
+		if job.StartedAt != nil {
+			metrics.JobWait.WithLabelValues(job.Queue, job.Type).Observe(job.StartedAt.Sub(job.NextRunAt).Seconds())
+		}
+
+		p.track(job)
+		select {
+		case <-p.stopCh:
+			return false, true
+		case <-ctx.Done():
+			return false, true
+		case p.jobCh <- job:
+			// inserted
 		}
 	}
 
+	return len(jobs) == claimBatchSize, false
 }
 
 func (p *Pool) ProcessNextJob(ctx context.Context, workerId int, job store.Job) {
@@ -121,13 +262,12 @@ func (p *Pool) ProcessNextJob(ctx context.Context, workerId int, job store.Job)
 
 	metrics.ActiveWorkers.Inc()
 	defer metrics.ActiveWorkers.Dec()
-
-	startTime := time.Now()
+	defer p.untrack(job.ID)
 
 	handler, limiter, err := p.registry.Get(job.Type)
 	if err != nil {
 		logger.Error("no handler found", "error", err)
-		updateFail := p.store.UpdateJobStatus(ctx, store.JobStatusFailed, job.ID)
+		updateFail := p.store.UpdateJobStatus(ctx, store.JobStatusFailed, job.ID, job.Lease())
 		if updateFail != nil {
 			logger.Error("CRITICAL: Failed to update job status", "error", updateFail)
 		}
@@ -135,17 +275,65 @@ func (p *Pool) ProcessNextJob(ctx context.Context, workerId int, job store.Job)
 		return
 	}
 
-	if err := limiter.Wait(ctx); err != nil {
-		logger.Error("Rate limiter wait failed", "error", err)
+	jobCtx, cancelJob := context.WithCancelCause(ctx)
+	if !p.begin(job, cancelJob) {
+		logger.Info("Skipping job cancelled or reclaimed while queued", "worker_id", workerId, "job_id", job.ID)
 		return
 	}
 
-	err = handler.Handle(ctx, job)
+	// the rate limit is waited out before the timeout starts, so waiting does
+	// not eat into the job's execution time
+	if err := limiter.Wait(jobCtx); err != nil {
+		p.abortWait(ctx, workerId, job, context.Cause(jobCtx))
+		return
+	}
+
+	startTime := time.Now()
+	timeout := p.registry.Timeout(job)
+	jobCtx, stop := context.WithTimeoutCause(jobCtx, timeout, ErrJobTimedOut)
+	defer stop()
+
+	progress := newProgressReporter(p.store, job, p.progressEvery)
+	jobCtx = context.WithValue(jobCtx, progressKey{}, progress)
+
+	var result any
+	if rh, ok := handler.(ResultHandler); ok {
+		result, err = rh.HandleResult(jobCtx, job)
+	} else {
+		err = handler.Handle(jobCtx, job)
+	}
 	duration := time.Since(startTime).Seconds()
 	metrics.JobDuration.WithLabelValues(job.Type).Observe(duration)
+	progress.flush(ctx)
+
+	// the job is already marked cancelled in the store, so it must neither be
+	// retried nor completed
+	if errors.Is(context.Cause(jobCtx), ErrJobCancelled) {
+		logger.Info("Job cancelled", "worker_id", workerId, "job_id", job.ID)
+		metrics.JobsProcessed.WithLabelValues(job.Type, "cancelled").Inc()
+		return
+	}
+
+	// another worker may own the job by now; its result must not be overwritten
+	if errors.Is(context.Cause(jobCtx), store.ErrLeaseLost) {
+		logger.Error("Job lease lost, dropping the result", "worker_id", workerId, "job_id", job.ID)
+		metrics.JobsProcessed.WithLabelValues(job.Type, "lease_lost").Inc()
+		return
+	}
+
+	if err != nil && errors.Is(context.Cause(jobCtx), ErrJobTimedOut) {
+		metrics.JobTimeouts.WithLabelValues(job.Type).Inc()
+		err = fmt.Errorf("%w after %s: %v", ErrJobTimedOut, timeout, err)
+	}
+
 	if err != nil {
 		logger.Error("Job failed ", "worker_id", workerId, "job_id", job.ID, "error", err)
-		failErr := p.store.HandleJobFailure(ctx, job.ID, err.Error())
+		failErr := p.store.HandleJobFailure(ctx, job.ID, err.Error(), p.registry.RetryPolicy(job), job.Lease())
+		if errors.Is(failErr, store.ErrLeaseLost) {
+			logger.Error("Job lease lost, dropping the failure", "job_id", job.ID)
+			metrics.JobsProcessed.WithLabelValues(job.Type, "lease_lost").Inc()
+			return
+		}
 		if failErr != nil {
 			logger.Error("CRITICAL: Failed to update job status", "error", failErr)
 		}
@@ -153,7 +341,21 @@ func (p *Pool) ProcessNextJob(ctx context.Context, workerId int, job store.Job)
 		return
 	}
 
-	updateFail := p.store.UpdateJobStatus(ctx, store.JobStatusCompleted, job.ID)
+	var encoded json.RawMessage
+	if result != nil {
+		encoded, err = json.Marshal(result)
+		if err != nil {
+			logger.Error("Failed to encode job result, completing without it", "job_id", job.ID, "error", err)
+			encoded = nil
+		}
+	}
+
+	updateFail := p.store.CompleteJob(ctx, job.ID, job.Lease(), encoded)
+	if errors.Is(updateFail, store.ErrLeaseLost) {
+		logger.Error("Job lease lost, dropping the result", "job_id", job.ID)
+		metrics.JobsProcessed.WithLabelValues(job.Type, "lease_lost").Inc()
+		return
+	}
 	if updateFail != nil {
 		logger.Error("CRITICAL: Failed to update job status", "error", updateFail)
 		return
@@ -164,3 +366,153 @@ func (p *Pool) ProcessNextJob(ctx context.Context, workerId int, job store.Job)
 	logger.Info("Worker completed the job", "worker", workerId, "job_id", job.ID)
 
 }
+
+// abortWait settles a job whose rate limiter wait ended with cause before the
+// handler ran. A cancelled job or a lost lease needs nothing more; otherwise
+// (the pool is shutting down) the attempt is failed, so the job is retried
+// under its policy instead of staying running until its lease expires.
+func (p *Pool) abortWait(ctx context.Context, workerId int, job store.Job, cause error) {
+	if errors.Is(cause, ErrJobCancelled) {
+		logger.Info("Job cancelled while waiting for the rate limiter", "worker_id", workerId, "job_id", job.ID)
+		metrics.JobsProcessed.WithLabelValues(job.Type, "cancelled").Inc()
+		return
+	}
+	if errors.Is(cause, store.ErrLeaseLost) {
+		logger.Error("Job lease lost while waiting for the rate limiter", "worker_id", workerId, "job_id", job.ID)
+		metrics.JobsProcessed.WithLabelValues(job.Type, "lease_lost").Inc()
+		return
+	}
+
+	logger.Error("Rate limiter wait failed", "worker_id", workerId, "job_id", job.ID, "error", cause)
+	// ctx may be the cancelled pool context
+	failErr := p.store.HandleJobFailure(context.WithoutCancel(ctx), job.ID,
+		fmt.Sprintf("rate limiter wait aborted: %v", cause), p.registry.RetryPolicy(job), job.Lease())
+	if failErr != nil && !errors.Is(failErr, store.ErrLeaseLost) {
+		logger.Error("CRITICAL: Failed to update job status", "error", failErr)
+	}
+	metrics.JobsProcessed.WithLabelValues(job.Type, "failed").Inc()
+}
+
+// track records a job claimed by the dispatcher so its lease is heartbeated
+// while it waits for a worker.
+func (p *Pool) track(job store.Job) {
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	p.running[job.ID] = runningJob{lease: job.Lease()}
+}
+
+// begin attaches the handler's cancel func to a tracked job. It reports false
+// if the job was dropped while queued.
+func (p *Pool) begin(job store.Job, cancel context.CancelCauseFunc) bool {
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	rj, ok := p.running[job.ID]
+	if !ok {
+		rj = runningJob{lease: job.Lease()}
+	}
+	if rj.dropped {
+		return false
+	}
+	rj.cancel = cancel
+	p.running[job.ID] = rj
+	return true
+}
+
+func (p *Pool) untrack(jobID int64) {
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	if rj, ok := p.running[jobID]; ok {
+		if rj.cancel != nil {
+			rj.cancel(nil)
+		}
+		delete(p.running, jobID)
+	}
+}
+
+// drop stops a tracked job with cause: a running handler has its context
+// cancelled, a queued job is never started. The caller holds runningMu.
+func (p *Pool) drop(jobID int64, cause error) {
+	rj, ok := p.running[jobID]
+	if !ok {
+		return
+	}
+	if rj.cancel != nil {
+		rj.cancel(cause)
+		return
+	}
+	rj.dropped = true
+	p.running[jobID] = rj
+}
+
+func (p *Pool) heartbeatLoop(ctx context.Context) {
+	ticker := time.NewTicker(p.leaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.drainedCh:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.heartbeat(ctx)
+		}
+	}
+}
+
+// heartbeat renews the leases of all running jobs and cancels the handler
+// context of jobs whose lease could not be renewed with store.ErrLeaseLost;
+// such a job may already be running on another worker.
+func (p *Pool) heartbeat(ctx context.Context) {
+	p.runningMu.Lock()
+	leases := make(map[int64]store.Lease, len(p.running))
+	for id, rj := range p.running {
+		leases[id] = rj.lease
+	}
+	p.runningMu.Unlock()
+
+	if len(leases) == 0 {
+		return
+	}
+
+	lost, err := p.store.ExtendLeases(ctx, leases, p.leaseDuration)
+	if err != nil {
+		logger.Error("extending job leases", "err", err)
+		return
+	}
+
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	for _, id := range lost {
+		logger.Error("Lost lease of claimed job", "queue", p.queue, "job_id", id)
+		p.drop(id, store.ErrLeaseLost)
+	}
+}
+
+// cancelRunningJobs cancels the handler context of every running job that has
+// been cancelled in the store, possibly by another replica.
+func (p *Pool) cancelRunningJobs(ctx context.Context) {
+	p.runningMu.Lock()
+	ids := make([]int64, 0, len(p.running))
+	for id := range p.running {
+		ids = append(ids, id)
+	}
+	p.runningMu.Unlock()
+
+	if len(ids) == 0 {
+		return
+	}
+
+	cancelled, err := p.store.GetCancelledJobIDs(ctx, ids)
+	if err != nil {
+		logger.Error("checking for cancelled jobs", "err", err)
+		return
+	}
+
+	p.runningMu.Lock()
+	defer p.runningMu.Unlock()
+	for _, id := range cancelled {
+		logger.Info("Cancelling running job", "queue", p.queue, "job_id", id)
+		p.drop(id, ErrJobCancelled)
+	}
+}
@@ -2,7 +2,11 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,66 +14,59 @@ import (
 	"github.com/bhanuprakaash/job-scheduler/internal/store"
 )
 
-type MemoryStore struct {
-	mu       sync.Mutex
-	jobs     []store.Job
-	finished map[int64]store.JobStatus
-}
-
-func NewMemoryStore(jobs []store.Job) *MemoryStore {
-	return &MemoryStore{
-		jobs:     jobs,
-		finished: make(map[int64]store.JobStatus),
+// seedJobs creates a pending job of each type in s and returns their ids.
+func seedJobs(t *testing.T, s store.Storer, types ...string) []int64 {
+	t.Helper()
+	var ids []int64
+	for _, jobType := range types {
+		job, err := s.CreateJob(context.Background(), store.CreateJobParams{Type: jobType, Payload: "{}"})
+		if err != nil {
+			t.Fatalf("CreateJob failed: %v", err)
+		}
+		ids = append(ids, job.ID)
 	}
+	return ids
 }
 
-func (m *MemoryStore) GetPendingJobs(ctx context.Context, limit int) ([]store.Job, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if len(m.jobs) == 0 {
-		return nil, nil
+func getJob(t *testing.T, s store.Storer, id int64) *store.Job {
+	t.Helper()
+	job, err := s.GetJobByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetJobByID failed: %v", err)
 	}
-
-	count := limit
-	if count > len(m.jobs) {
-		count = len(m.jobs)
-	}
-
-	batch := m.jobs[:count]
-	m.jobs = m.jobs[count:]
-
-	return batch, nil
+	return job
 }
 
-func (m *MemoryStore) UpdateJobStatus(ctx context.Context, status store.JobStatus, id int64) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.finished[id] = status
-	return nil
+// progressStore records every progress write.
+type progressStore struct {
+	*store.MemoryStore
+	mu       sync.Mutex
+	progress []int
 }
 
-func (m *MemoryStore) CreateJob(ctx context.Context, jobType, payload string) (*store.Job, error) {
-	return nil, nil
-}
-func (m *MemoryStore) GetJobByID(ctx context.Context, id int64) (*store.Job, error) { return nil, nil }
-func (m *MemoryStore) GetArchivedJobs(ctx context.Context, d time.Duration, l int) ([]store.Job, error) {
-	return nil, nil
+func (p *progressStore) UpdateJobProgress(ctx context.Context, id int64, lease store.Lease, percent int, message string) error {
+	p.mu.Lock()
+	p.progress = append(p.progress, percent)
+	p.mu.Unlock()
+	return p.MemoryStore.UpdateJobProgress(ctx, id, lease, percent, message)
 }
-func (m *MemoryStore) HandleJobFailure(ctx context.Context, id int64, errMsg string) error {
-	return nil
-}
-func (m *MemoryStore) BatchDeleteJobs(ctx context.Context, ids []int64) error { return nil }
-func (m *MemoryStore) ListJobs(ctx context.Context, limit, offset int) (*store.PaginatedJobs, error) {
-	return nil, nil
-}
-func (m *MemoryStore) ListDeadJobs(ctx context.Context, limit, offset int) (*store.PaginatedJobs, error) {
-	return nil, nil
+
+// lostLeaseStore reports every lease as lost once lost is set, as if the jobs
+// had been reclaimed by another worker.
+type lostLeaseStore struct {
+	*store.MemoryStore
+	lost atomic.Bool
 }
-func (m *MemoryStore) GetStats(ctx context.Context) (*store.JobStats, error) { return nil, nil }
-func (m *MemoryStore) Close()                                                {}
-func (m *MemoryStore) RepeatStuckJobs(ctx context.Context, interval time.Duration) (int64, error) {
-	return 0, nil
+
+func (l *lostLeaseStore) ExtendLeases(ctx context.Context, leases map[int64]store.Lease, d time.Duration) ([]int64, error) {
+	if !l.lost.Load() {
+		return l.MemoryStore.ExtendLeases(ctx, leases, d)
+	}
+	var ids []int64
+	for id := range leases {
+		ids = append(ids, id)
+	}
+	return ids, nil
 }
 
 type HandlerFunc func(ctx context.Context, job store.Job) error
@@ -78,6 +75,15 @@ func (f HandlerFunc) Handle(ctx context.Context, job store.Job) error {
 	return f(ctx, job)
 }
 
+type resultHandler struct {
+	HandlerFunc
+	result any
+}
+
+func (h resultHandler) HandleResult(ctx context.Context, job store.Job) (any, error) {
+	return h.result, h.Handle(ctx, job)
+}
+
 func TestPool_LoadTest(t *testing.T) {
 	logger.Init()
 
@@ -87,17 +93,10 @@ func TestPool_LoadTest(t *testing.T) {
 		PollTime    = 5 * time.Millisecond
 	)
 
-	var initialJobs []store.Job
+	memStore := store.NewMemoryStore()
 	for i := 1; i <= TotalJobs; i++ {
-		initialJobs = append(initialJobs, store.Job{
-			ID:      int64(i),
-			Type:    "load:test",
-			Payload: "{}",
-			Status:  store.JobStatusPending,
-		})
+		seedJobs(t, memStore, "load:test")
 	}
-
-	memStore := NewMemoryStore(initialJobs)
 	registry := NewRegistry()
 
 	var wg sync.WaitGroup
@@ -109,7 +108,7 @@ func TestPool_LoadTest(t *testing.T) {
 		return nil
 	}), 0)
 
-	pool := NewPool(memStore, registry, WorkerCount, PollTime)
+	pool := NewPool(memStore, registry, PoolConfig{Workers: WorkerCount, PollInterval: PollTime})
 	ctx, cancel := context.WithCancel(context.Background())
 
 	startTime := time.Now()
@@ -132,18 +131,20 @@ func TestPool_LoadTest(t *testing.T) {
 	cancel()
 	pool.Stop()
 
-	memStore.mu.Lock()
-	defer memStore.mu.Unlock()
-	if len(memStore.finished) != TotalJobs {
-		t.Errorf("Expected %d completed jobs, got %d", TotalJobs, len(memStore.finished))
+	stats, err := memStore.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.Completed != TotalJobs {
+		t.Errorf("Expected %d completed jobs, got %d", TotalJobs, stats.Completed)
 	}
 }
 
 func TestPool_GracefulShutdown(t *testing.T) {
 	logger.Init()
 
-	jobs := []store.Job{{ID: 1, Type: "long:job"}}
-	memStore := NewMemoryStore(jobs)
+	memStore := store.NewMemoryStore()
+	seedJobs(t, memStore, "long:job")
 
 	registry := NewRegistry()
 
@@ -158,7 +159,7 @@ func TestPool_GracefulShutdown(t *testing.T) {
 		}
 	}), 0)
 
-	pool := NewPool(memStore, registry, 1, 10*time.Millisecond)
+	pool := NewPool(memStore, registry, PoolConfig{Workers: 1, PollInterval: 10 * time.Millisecond})
 	ctx, cancel := context.WithCancel(context.Background())
 
 	pool.Start(ctx)
@@ -171,3 +172,291 @@ func TestPool_GracefulShutdown(t *testing.T) {
 
 	t.Log("✓ Pool shut down gracefully")
 }
+
+func TestPool_CancelRunningJob(t *testing.T) {
+	logger.Init()
+
+	memStore := store.NewMemoryStore()
+	id := seedJobs(t, memStore, "long:job")[0]
+	registry := NewRegistry()
+
+	started := make(chan struct{})
+	cause := make(chan error, 1)
+	registry.Register("long:job", HandlerFunc(func(ctx context.Context, j store.Job) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			cause <- context.Cause(ctx)
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	}), 0)
+
+	pool := NewPool(memStore, registry, PoolConfig{Workers: 1, PollInterval: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool.Start(ctx)
+	<-started
+
+	// 1. Cancel the job in the store; the pool must notice on its next poll
+	if _, err := memStore.CancelJob(ctx, id); err != nil {
+		t.Fatalf("CancelJob failed: %v", err)
+	}
+
+	select {
+	case err := <-cause:
+		if !errors.Is(err, ErrJobCancelled) {
+			t.Errorf("Expected cause ErrJobCancelled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("✗ Timeout: handler context was not cancelled")
+	}
+
+	pool.Stop()
+
+	// 2. A cancelled job is neither retried nor completed
+	job := getJob(t, memStore, id)
+	if job.Status != store.JobStatusCancelled || job.RetryCount != 0 {
+		t.Errorf("Expected the job to stay cancelled without retries, got %s (retries %d)", job.Status, job.RetryCount)
+	}
+}
+
+func TestPool_JobTimeout(t *testing.T) {
+	logger.Init()
+
+	memStore := store.NewMemoryStore()
+	id := seedJobs(t, memStore, "hung:job")[0]
+	registry := NewRegistry()
+
+	done := make(chan struct{})
+	registry.Register("hung:job", HandlerFunc(func(ctx context.Context, j store.Job) error {
+		defer close(done)
+		<-ctx.Done()
+		return ctx.Err()
+	}), 0, WithTimeout(20*time.Millisecond))
+
+	pool := NewPool(memStore, registry, PoolConfig{Workers: 1, PollInterval: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool.Start(ctx)
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("✗ Timeout: handler context was never cancelled")
+	}
+
+	pool.Stop()
+
+	// The attempt fails through HandleJobFailure with a distinct error
+	attempts, err := memStore.ListJobAttempts(context.Background(), id)
+	if err != nil {
+		t.Fatalf("ListJobAttempts failed: %v", err)
+	}
+	if len(attempts) != 1 || attempts[0].Outcome != store.AttemptFailed {
+		t.Fatalf("Expected 1 failed attempt, got %+v", attempts)
+	}
+	if !strings.HasPrefix(attempts[0].Error.String, "job timed out after 20ms") {
+		t.Errorf("Expected a timed out error, got %q", attempts[0].Error.String)
+	}
+}
+
+func TestPool_RateLimitWaitAborted(t *testing.T) {
+	logger.Init()
+
+	memStore := store.NewMemoryStore()
+	id := seedJobs(t, memStore, "limited:job")[0]
+	registry := NewRegistry()
+
+	var ran atomic.Bool
+	registry.Register("limited:job", HandlerFunc(func(ctx context.Context, j store.Job) error {
+		ran.Store(true)
+		return nil
+	}), 1)
+
+	// the only token is taken, so the job has to wait for the limiter
+	_, limiter, _ := registry.Get("limited:job")
+	limiter.Allow()
+
+	pool := NewPool(memStore, registry, PoolConfig{Workers: 1, PollInterval: time.Hour})
+	jobs, err := memStore.GetPendingJobs(context.Background(), store.ClaimParams{
+		Queue: store.DefaultQueue, Limit: 1, Owner: "w1", LeaseDuration: time.Minute,
+	})
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("Expected to claim the job, got %d (%v)", len(jobs), err)
+	}
+
+	// the pool shuts down while the job waits
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pool.ProcessNextJob(ctx, 1, jobs[0])
+
+	if ran.Load() {
+		t.Error("Expected the handler not to run")
+	}
+	job := getJob(t, memStore, id)
+	if job.Status != store.JobStatusPending || job.RetryCount != 1 || job.LockedBy.Valid {
+		t.Errorf("Expected the attempt to fail and the job to be retried, got %s retry_count=%d locked=%v", job.Status, job.RetryCount, job.LockedBy.Valid)
+	}
+	attempts, _ := memStore.ListJobAttempts(context.Background(), id)
+	if len(attempts) != 1 || attempts[0].Outcome != store.AttemptFailed {
+		t.Errorf("Expected 1 failed attempt, got %+v", attempts)
+	}
+}
+
+func TestPool_LeaseLost(t *testing.T) {
+	logger.Init()
+
+	memStore := &lostLeaseStore{MemoryStore: store.NewMemoryStore()}
+	id := seedJobs(t, memStore, "long:job")[0]
+	registry := NewRegistry()
+
+	started := make(chan struct{})
+	cause := make(chan error, 1)
+	registry.Register("long:job", HandlerFunc(func(ctx context.Context, j store.Job) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			cause <- context.Cause(ctx)
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	}), 0)
+
+	pool := NewPool(memStore, registry, PoolConfig{
+		Workers:       1,
+		PollInterval:  10 * time.Millisecond,
+		LeaseDuration: 30 * time.Millisecond,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool.Start(ctx)
+	<-started
+
+	// 1. The job is reclaimed elsewhere; the next heartbeat must notice
+	memStore.lost.Store(true)
+
+	select {
+	case err := <-cause:
+		if !errors.Is(err, store.ErrLeaseLost) {
+			t.Errorf("Expected cause ErrLeaseLost, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("✗ Timeout: handler context was not cancelled")
+	}
+
+	pool.Stop()
+
+	// 2. The worker that lost the lease writes nothing
+	job := getJob(t, memStore, id)
+	if job.Status != store.JobStatusRunning || job.RetryCount != 0 {
+		t.Errorf("Expected the job to be left running without retries, got %s (retries %d)", job.Status, job.RetryCount)
+	}
+}
+
+func TestPool_StoresResult(t *testing.T) {
+	logger.Init()
+
+	memStore := store.NewMemoryStore()
+	ids := seedJobs(t, memStore, "with:result", "no:result")
+	registry := NewRegistry()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	noop := HandlerFunc(func(ctx context.Context, j store.Job) error {
+		wg.Done()
+		return nil
+	})
+	registry.Register("with:result", resultHandler{noop, map[string]string{"object": "secure/invoices/42.pdf"}}, 0)
+	registry.Register("no:result", noop, 0)
+
+	pool := NewPool(memStore, registry, PoolConfig{Workers: 1, PollInterval: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool.Start(ctx)
+	wg.Wait()
+	pool.Stop()
+
+	withResult, noResult := getJob(t, memStore, ids[0]), getJob(t, memStore, ids[1])
+	if got := string(withResult.Result); got != `{"object":"secure/invoices/42.pdf"}` {
+		t.Errorf("Expected the handler's result to be stored, got %q", got)
+	}
+	if noResult.Result != nil {
+		t.Errorf("Expected no result for a plain handler, got %s", noResult.Result)
+	}
+	if withResult.Status != store.JobStatusCompleted || noResult.Status != store.JobStatusCompleted {
+		t.Errorf("Expected both jobs completed, got %s and %s", withResult.Status, noResult.Status)
+	}
+}
+
+func TestPool_ProgressThrottled(t *testing.T) {
+	logger.Init()
+
+	memStore := &progressStore{MemoryStore: store.NewMemoryStore()}
+	seedJobs(t, memStore, "archive:job")
+	registry := NewRegistry()
+
+	done := make(chan struct{})
+	registry.Register("archive:job", HandlerFunc(func(ctx context.Context, j store.Job) error {
+		defer close(done)
+		for percent := 10; percent <= 90; percent += 10 {
+			ReportProgress(ctx, percent, "archiving")
+		}
+		return nil
+	}), 0)
+
+	pool := NewPool(memStore, registry, PoolConfig{
+		Workers:          1,
+		PollInterval:     10 * time.Millisecond,
+		ProgressInterval: time.Hour,
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool.Start(ctx)
+	<-done
+	pool.Stop()
+
+	// Only the first report and, once the handler returns, the last one are written
+	memStore.mu.Lock()
+	defer memStore.mu.Unlock()
+	if !slices.Equal(memStore.progress, []int{10, 90}) {
+		t.Errorf("Expected progress writes [10 90], got %v", memStore.progress)
+	}
+}
+
+func TestPool_NotifyWakesDispatcher(t *testing.T) {
+	logger.Init()
+
+	memStore := store.NewMemoryStore()
+	registry := NewRegistry()
+
+	done := make(chan struct{})
+	registry.Register("notify:job", HandlerFunc(func(ctx context.Context, j store.Job) error {
+		close(done)
+		return nil
+	}), 0)
+
+	// 1. Polling alone would not pick the job up during the test
+	pool := NewPool(memStore, registry, PoolConfig{Workers: 1, PollInterval: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool.Start(ctx)
+
+	// 2. The store's notification makes the dispatcher claim right away
+	time.Sleep(20 * time.Millisecond)
+	seedJobs(t, memStore, "notify:job")
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the notified job to be processed without waiting for the poll interval")
+	}
+	pool.Stop()
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/logger"
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+)
+
+// defaultProgressInterval is the minimum time between two progress writes of
+// the same job.
+const defaultProgressInterval = 2 * time.Second
+
+type progressKey struct{}
+
+// ReportProgress records how far the job handled under ctx has got, as a
+// percentage between 0 and 100 and an optional short message. Reports are
+// throttled: only the latest one is written when several arrive within the
+// pool's progress interval, and the last one is always written before the
+// job finishes. Outside of a pool, e.g. in tests, it does nothing.
+func ReportProgress(ctx context.Context, percent int, message string) {
+	r, ok := ctx.Value(progressKey{}).(*progressReporter)
+	if !ok {
+		return
+	}
+	r.report(ctx, min(max(percent, 0), 100), message)
+}
+
+// progressReporter throttles the progress writes of a single job.
+type progressReporter struct {
+	store    store.Storer
+	job      store.Job
+	interval time.Duration
+
+	mu        sync.Mutex
+	lastWrite time.Time
+	pending   bool
+	percent   int
+	message   string
+}
+
+func newProgressReporter(s store.Storer, job store.Job, interval time.Duration) *progressReporter {
+	return &progressReporter{store: s, job: job, interval: interval}
+}
+
+func (r *progressReporter) report(ctx context.Context, percent int, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.percent, r.message, r.pending = percent, message, true
+	if time.Since(r.lastWrite) >= r.interval || percent == 100 {
+		r.write(ctx)
+	}
+}
+
+// flush writes a report held back by throttling.
+func (r *progressReporter) flush(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pending {
+		r.write(ctx)
+	}
+}
+
+// write persists the latest report. The caller holds r.mu.
+func (r *progressReporter) write(ctx context.Context) {
+	r.pending = false
+	r.lastWrite = time.Now()
+
+	err := r.store.UpdateJobProgress(ctx, r.job.ID, r.job.Lease(), r.percent, r.message)
+	if err != nil && !errors.Is(err, store.ErrLeaseLost) {
+		logger.Error("Failed to record job progress", "job_id", r.job.ID, "error", err)
+	}
+}
@@ -2,14 +2,60 @@ package worker
 
 import (
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
 	"golang.org/x/time/rate"
 )
 
 type registryEntry struct {
-	handler Handler
-	limiter *rate.Limiter
+	handler     Handler
+	limiter     *rate.Limiter
+	queue       string
+	retryPolicy store.RetryPolicy
+	timeout     time.Duration
+	unique      *store.UniqueSpec
+}
+
+// DefaultTimeout bounds the execution of job types registered without WithTimeout.
+const DefaultTimeout = 10 * time.Minute
+
+// Option configures how a job type is handled when it is registered.
+type Option func(*registryEntry)
+
+// WithQueue routes jobs of the registered type to the named queue unless
+// the submitter picks a different one.
+func WithQueue(queue string) Option {
+	return func(e *registryEntry) {
+		e.queue = queue
+	}
+}
+
+// WithRetryPolicy sets how failed jobs of the registered type are retried.
+// Fields left zero keep the value of store.DefaultRetryPolicy.
+func WithRetryPolicy(policy store.RetryPolicy) Option {
+	return func(e *registryEntry) {
+		e.retryPolicy = store.DefaultRetryPolicy.Merge(policy)
+	}
+}
+
+// WithTimeout bounds how long a single execution of the registered type may
+// run before its context is cancelled and the attempt fails as timed out.
+func WithTimeout(timeout time.Duration) Option {
+	return func(e *registryEntry) {
+		e.timeout = timeout
+	}
+}
+
+// WithUnique makes submissions of the registered type return an existing
+// duplicate job instead of creating a new one, as described by spec. The spec
+// is checked by the store when a job is created.
+func WithUnique(spec store.UniqueSpec) Option {
+	return func(e *registryEntry) {
+		e.unique = &spec
+	}
 }
 
 type Registry struct {
@@ -23,7 +69,7 @@ func NewRegistry() *Registry {
 	}
 }
 
-func (r *Registry) Register(jobType string, handler Handler, eventsPerSecond int) {
+func (r *Registry) Register(jobType string, handler Handler, eventsPerSecond int, opts ...Option) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -34,10 +80,18 @@ func (r *Registry) Register(jobType string, handler Handler, eventsPerSecond int
 		limiter = rate.NewLimiter(rate.Inf, 0)
 	}
 
-	r.entries[jobType] = registryEntry{
-		handler: handler,
-		limiter: limiter,
+	entry := registryEntry{
+		handler:     handler,
+		limiter:     limiter,
+		queue:       store.DefaultQueue,
+		retryPolicy: store.DefaultRetryPolicy,
+		timeout:     DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(&entry)
 	}
+
+	r.entries[jobType] = entry
 }
 
 func (r *Registry) Get(jobType string) (Handler, *rate.Limiter, error) {
@@ -60,3 +114,78 @@ func (r *Registry) Has(jobType string) bool {
 	_, exists := r.entries[jobType]
 	return exists
 }
+
+// Queue returns the default queue of a registered job type.
+func (r *Registry) Queue(jobType string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, exists := r.entries[jobType]
+	if !exists {
+		return store.DefaultQueue
+	}
+	return entry.queue
+}
+
+// Unique returns the uniqueness spec of a registered job type, or nil if its
+// jobs are not unique.
+func (r *Registry) Unique(jobType string) *store.UniqueSpec {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, exists := r.entries[jobType]
+	if !exists || entry.unique == nil {
+		return nil
+	}
+	spec := *entry.unique
+	return &spec
+}
+
+// RetryPolicy returns the effective retry policy of a job: the policy of its
+// type, with any fields overridden by the job itself.
+func (r *Registry) RetryPolicy(job store.Job) store.RetryPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policy := store.DefaultRetryPolicy
+	if entry, exists := r.entries[job.Type]; exists {
+		policy = entry.retryPolicy
+	}
+	if job.RetryPolicy != nil {
+		policy = policy.Merge(*job.RetryPolicy)
+	}
+	return policy
+}
+
+// Timeout returns the execution timeout of a job: its own timeout if it has
+// one, otherwise the timeout of its type.
+func (r *Registry) Timeout(job store.Job) time.Duration {
+	if t := job.Timeout(); t > 0 {
+		return t
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if entry, exists := r.entries[job.Type]; exists {
+		return entry.timeout
+	}
+	return DefaultTimeout
+}
+
+// Queues returns the sorted, distinct default queues of all registered job types.
+func (r *Registry) Queues() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var queues []string
+	for _, entry := range r.entries {
+		if !seen[entry.queue] {
+			seen[entry.queue] = true
+			queues = append(queues, entry.queue)
+		}
+	}
+	sort.Strings(queues)
+	return queues
+}
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bhanuprakaash/job-scheduler/internal/store"
+)
+
+func TestRegistry_Queues(t *testing.T) {
+	noop := HandlerFunc(func(ctx context.Context, j store.Job) error { return nil })
+
+	registry := NewRegistry()
+	registry.Register("notification:email", noop, 0, WithQueue("notifications"))
+	registry.Register("media:resize_image", noop, 0, WithQueue("media"))
+	registry.Register("finance:invoice", noop, 0)
+
+	if got := registry.Queue("notification:email"); got != "notifications" {
+		t.Errorf("Expected queue 'notifications', got '%s'", got)
+	}
+	if got := registry.Queue("finance:invoice"); got != store.DefaultQueue {
+		t.Errorf("Expected the default queue for a type without WithQueue, got '%s'", got)
+	}
+
+	want := []string{store.DefaultQueue, "media", "notifications"}
+	if got := registry.Queues(); !slices.Equal(got, want) {
+		t.Errorf("Expected queues %v, got %v", want, got)
+	}
+}
+
+func TestRegistry_RetryPolicy(t *testing.T) {
+	noop := HandlerFunc(func(ctx context.Context, j store.Job) error { return nil })
+
+	registry := NewRegistry()
+	registry.Register("notification:email", noop, 0, WithRetryPolicy(store.RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+		Backoff:     store.BackoffLinear,
+	}))
+	registry.Register("finance:invoice", noop, 0)
+
+	// 1. Types without a policy use the default
+	if got := registry.RetryPolicy(store.Job{Type: "finance:invoice"}); got != store.DefaultRetryPolicy {
+		t.Errorf("Expected the default policy, got %+v", got)
+	}
+
+	// 2. A per-job override only replaces the fields it sets
+	got := registry.RetryPolicy(store.Job{
+		Type:        "notification:email",
+		RetryPolicy: &store.RetryPolicy{MaxAttempts: 2},
+	})
+	want := store.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Second, Backoff: store.BackoffLinear}
+	if got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+	noop := HandlerFunc(func(ctx context.Context, j store.Job) error { return nil })
+
+	registry := NewRegistry()
+	registry.Register("media:resize_image", noop, 0, WithTimeout(2*time.Minute))
+	registry.Register("maintenance:archive", noop, 0, WithTimeout(30*time.Minute))
+	registry.Register("finance:invoice", noop, 0)
+
+	if got := registry.Timeout(store.Job{Type: "finance:invoice"}); got != DefaultTimeout {
+		t.Errorf("Expected the default timeout, got %v", got)
+	}
+
+	job := store.Job{Type: "media:resize_image"}
+	if got := registry.Timeout(job); got != 2*time.Minute {
+		t.Errorf("Expected the type's timeout, got %v", got)
+	}
+
+	job.TimeoutMs = sql.NullInt64{Int64: 5000, Valid: true}
+	if got := registry.Timeout(job); got != 5*time.Second {
+		t.Errorf("Expected the job's own timeout, got %v", got)
+	}
+}
+
+func TestRegistry_Unique(t *testing.T) {
+	noop := HandlerFunc(func(ctx context.Context, j store.Job) error { return nil })
+
+	registry := NewRegistry()
+	registry.Register("finance:invoice", noop, 0, WithUnique(store.UniqueSpec{Fields: []string{"invoice_id"}}))
+	registry.Register("notification:email", noop, 0)
+
+	if got := registry.Unique("notification:email"); got != nil {
+		t.Errorf("Expected no spec for a type without WithUnique, got %+v", got)
+	}
+	if got := registry.Unique("unknown"); got != nil {
+		t.Errorf("Expected no spec for an unknown type, got %+v", got)
+	}
+
+	got := registry.Unique("finance:invoice")
+	if got == nil || !slices.Equal(got.Fields, []string{"invoice_id"}) {
+		t.Errorf("Expected the type's spec, got %+v", got)
+	}
+}
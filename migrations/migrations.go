@@ -0,0 +1,29 @@
+// Package migrations embeds the versioned schema migrations of each store
+// backend, one directory per backend: postgres/ is applied by store.Migrate
+// and sqlite/ by SQLiteStore.Migrate. Files are named <version>_<name>.up.sql
+// and <version>_<name>.down.sql; versions are applied in ascending order and
+// every schema change gets a new file instead of editing an applied one.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+var (
+	// Postgres holds the migrations of the Postgres store.
+	Postgres = sub("postgres")
+	// SQLite holds the migrations of the SQLite store.
+	SQLite = sub("sqlite")
+)
+
+func sub(dir string) fs.FS {
+	fsys, err := fs.Sub(files, dir)
+	if err != nil {
+		panic(err)
+	}
+	return fsys
+}
@@ -0,0 +1,21 @@
+CREATE TABLE schedules (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    cron_expr TEXT NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    job_type TEXT NOT NULL,
+    payload_template TEXT NOT NULL DEFAULT '{}',
+    catchup_policy TEXT NOT NULL DEFAULT 'skip',
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    next_run_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    last_run_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    CONSTRAINT schedules_catchup_policy_check CHECK (
+        catchup_policy IN ('skip', 'once', 'all')
+    )
+);
+
+CREATE INDEX idx_schedules_next_run_at ON schedules (next_run_at)
+WHERE
+    paused = FALSE;
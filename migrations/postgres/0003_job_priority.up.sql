@@ -0,0 +1,6 @@
+ALTER TABLE jobs
+ADD COLUMN priority INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_jobs_pending_priority ON jobs (priority DESC, next_run_at ASC)
+WHERE
+    status = 'pending';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_jobs_pending_queue_priority;
+
+CREATE INDEX idx_jobs_pending_priority ON jobs (priority DESC, next_run_at ASC)
+WHERE
+    status = 'pending';
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS queue;
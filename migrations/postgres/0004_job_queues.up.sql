@@ -0,0 +1,8 @@
+ALTER TABLE jobs
+ADD COLUMN queue TEXT NOT NULL DEFAULT 'default';
+
+DROP INDEX IF EXISTS idx_jobs_pending_priority;
+
+CREATE INDEX idx_jobs_pending_queue_priority ON jobs (queue, priority DESC, next_run_at ASC)
+WHERE
+    status = 'pending';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_jobs_idempotency_key;
+
+ALTER TABLE jobs
+DROP COLUMN IF EXISTS idempotency_key,
+DROP COLUMN IF EXISTS idempotency_expires_at;
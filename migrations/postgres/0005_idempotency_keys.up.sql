@@ -0,0 +1,7 @@
+ALTER TABLE jobs
+ADD COLUMN idempotency_key TEXT,
+ADD COLUMN idempotency_expires_at TIMESTAMP WITHOUT TIME ZONE;
+
+CREATE UNIQUE INDEX idx_jobs_idempotency_key ON jobs (idempotency_key)
+WHERE
+    idempotency_key IS NOT NULL;
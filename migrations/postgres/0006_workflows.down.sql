@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS job_dependencies;
+DROP TABLE IF EXISTS workflow_jobs;
+DROP TABLE IF EXISTS workflows;
+
+ALTER TABLE jobs
+DROP CONSTRAINT jobs_status_check,
+ADD CONSTRAINT jobs_status_check CHECK (
+    status IN ('pending', 'running', 'completed', 'failed')
+);
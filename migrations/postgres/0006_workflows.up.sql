@@ -0,0 +1,32 @@
+ALTER TABLE jobs
+DROP CONSTRAINT jobs_status_check,
+ADD CONSTRAINT jobs_status_check CHECK (
+    status IN ('pending', 'running', 'completed', 'failed', 'skipped')
+);
+
+CREATE TABLE workflows (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- job ids are not foreign keys: completed jobs are archived and dead jobs move
+-- to dead_jobs, but the workflow graph must outlive both.
+CREATE TABLE workflow_jobs (
+    workflow_id BIGINT NOT NULL REFERENCES workflows (id) ON DELETE CASCADE,
+    job_id BIGINT NOT NULL,
+    step TEXT NOT NULL,
+    PRIMARY KEY (workflow_id, job_id),
+    UNIQUE (workflow_id, step)
+);
+
+CREATE INDEX idx_workflow_jobs_job_id ON workflow_jobs (job_id);
+
+CREATE TABLE job_dependencies (
+    job_id BIGINT NOT NULL,
+    depends_on BIGINT NOT NULL,
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (job_id, depends_on)
+);
+
+CREATE INDEX idx_job_dependencies_depends_on ON job_dependencies (depends_on);
@@ -0,0 +1,5 @@
+ALTER TABLE jobs
+DROP CONSTRAINT jobs_status_check,
+ADD CONSTRAINT jobs_status_check CHECK (
+    status IN ('pending', 'running', 'completed', 'failed', 'skipped')
+);
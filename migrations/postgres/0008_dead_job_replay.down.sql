@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_dead_jobs_type_failed_at;
+
+ALTER TABLE dead_jobs
+DROP COLUMN IF EXISTS queue,
+DROP COLUMN IF EXISTS priority,
+DROP COLUMN IF EXISTS replay_count;
+
+ALTER TABLE jobs
+DROP COLUMN IF EXISTS replay_count,
+DROP COLUMN IF EXISTS replayed_at;
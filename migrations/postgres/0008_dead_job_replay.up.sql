@@ -0,0 +1,10 @@
+ALTER TABLE jobs
+ADD COLUMN replay_count INT NOT NULL DEFAULT 0,
+ADD COLUMN replayed_at TIMESTAMP WITHOUT TIME ZONE;
+
+ALTER TABLE dead_jobs
+ADD COLUMN queue TEXT NOT NULL DEFAULT 'default',
+ADD COLUMN priority INT NOT NULL DEFAULT 0,
+ADD COLUMN replay_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_dead_jobs_type_failed_at ON dead_jobs (type, failed_at);
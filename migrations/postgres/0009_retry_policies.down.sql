@@ -0,0 +1,3 @@
+ALTER TABLE dead_jobs DROP COLUMN IF EXISTS retry_policy;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS retry_policy;
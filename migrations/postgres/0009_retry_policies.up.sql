@@ -0,0 +1,5 @@
+ALTER TABLE jobs
+ADD COLUMN retry_policy JSONB;
+
+ALTER TABLE dead_jobs
+ADD COLUMN retry_policy JSONB;
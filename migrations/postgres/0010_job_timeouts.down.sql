@@ -0,0 +1,3 @@
+ALTER TABLE dead_jobs DROP COLUMN IF EXISTS timeout_ms;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS timeout_ms;
@@ -0,0 +1,5 @@
+ALTER TABLE jobs
+ADD COLUMN timeout_ms BIGINT;
+
+ALTER TABLE dead_jobs
+ADD COLUMN timeout_ms BIGINT;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_jobs_running_lease;
+
+ALTER TABLE jobs
+DROP COLUMN IF EXISTS locked_by,
+DROP COLUMN IF EXISTS lease_expires_at,
+DROP COLUMN IF EXISTS lease_token;
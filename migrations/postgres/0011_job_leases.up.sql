@@ -0,0 +1,6 @@
+ALTER TABLE jobs
+ADD COLUMN locked_by TEXT,
+ADD COLUMN lease_expires_at TIMESTAMP WITHOUT TIME ZONE,
+ADD COLUMN lease_token BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_jobs_running_lease ON jobs (lease_expires_at) WHERE status = 'running';
@@ -0,0 +1,4 @@
+ALTER TABLE jobs
+DROP COLUMN IF EXISTS progress,
+DROP COLUMN IF EXISTS progress_message,
+DROP COLUMN IF EXISTS progress_updated_at;
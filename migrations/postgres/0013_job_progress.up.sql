@@ -0,0 +1,4 @@
+ALTER TABLE jobs
+ADD COLUMN progress SMALLINT CHECK (progress BETWEEN 0 AND 100),
+ADD COLUMN progress_message TEXT,
+ADD COLUMN progress_updated_at TIMESTAMP WITHOUT TIME ZONE;
@@ -0,0 +1,15 @@
+-- job_id is not a foreign key: attempts of dead-lettered jobs stay linked to
+-- the dead_jobs row, which keeps the job's id.
+CREATE TABLE job_attempts (
+    job_id BIGINT NOT NULL,
+    attempt INT NOT NULL,
+    worker TEXT NOT NULL,
+    started_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    finished_at TIMESTAMP WITHOUT TIME ZONE,
+    outcome TEXT NOT NULL DEFAULT 'running',
+    error TEXT,
+    PRIMARY KEY (job_id, attempt),
+    CONSTRAINT job_attempts_outcome_check CHECK (
+        outcome IN ('running', 'completed', 'failed', 'cancelled', 'lease_expired')
+    )
+);
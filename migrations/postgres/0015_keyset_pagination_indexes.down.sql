@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_dead_jobs_failed_at_id;
+DROP INDEX IF EXISTS idx_jobs_created_at_id;
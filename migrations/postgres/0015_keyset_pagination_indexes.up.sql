@@ -0,0 +1,4 @@
+-- keyset pagination of job listings seeks on (created_at, id)
+CREATE INDEX idx_jobs_created_at_id ON jobs (created_at, id);
+
+CREATE INDEX idx_dead_jobs_failed_at_id ON dead_jobs (failed_at, id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_jobs_started_at;
+DROP INDEX IF EXISTS idx_jobs_completed_at;
@@ -0,0 +1,4 @@
+-- per-type statistics scan recently finished and recently started jobs
+CREATE INDEX idx_jobs_completed_at ON jobs (completed_at) WHERE completed_at IS NOT NULL;
+
+CREATE INDEX idx_jobs_started_at ON jobs (started_at) WHERE started_at IS NOT NULL;
@@ -0,0 +1,110 @@
+-- jobs is range-partitioned by created_at into daily partitions named
+-- jobs_pYYYYMMDD. Partitions are created ahead of time by the server and old
+-- ones are detached, exported and dropped whole by maintenance:archive.
+-- Rows created before partitioning stay in jobs_legacy.
+--
+-- The primary key of a partitioned table must include the partition key, so
+-- idempotency keys are kept unique in job_idempotency_keys, and ids come from
+-- a plain sequence (partitioned tables cannot have identity columns before
+-- Postgres 17).
+CREATE TABLE jobs_partitioned (
+    id BIGINT NOT NULL,
+    type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT now(),
+    started_at TIMESTAMP WITHOUT TIME ZONE,
+    completed_at TIMESTAMP WITHOUT TIME ZONE,
+    retry_count INT NOT NULL DEFAULT 0,
+    max_retries INT NOT NULL DEFAULT 3,
+    last_err TEXT,
+    next_run_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    priority INT NOT NULL DEFAULT 0,
+    queue TEXT NOT NULL DEFAULT 'default',
+    idempotency_key TEXT,
+    idempotency_expires_at TIMESTAMP WITHOUT TIME ZONE,
+    replay_count INT NOT NULL DEFAULT 0,
+    replayed_at TIMESTAMP WITHOUT TIME ZONE,
+    retry_policy JSONB,
+    timeout_ms BIGINT,
+    locked_by TEXT,
+    lease_expires_at TIMESTAMP WITHOUT TIME ZONE,
+    lease_token BIGINT NOT NULL DEFAULT 0,
+    result JSONB,
+    progress SMALLINT,
+    progress_message TEXT,
+    progress_updated_at TIMESTAMP WITHOUT TIME ZONE,
+    CONSTRAINT jobs_status_check CHECK (
+        status IN ('pending', 'running', 'completed', 'failed', 'skipped', 'cancelled')
+    ),
+    CONSTRAINT jobs_progress_check CHECK (progress BETWEEN 0 AND 100)
+) PARTITION BY RANGE (created_at);
+
+DO $$
+BEGIN
+    EXECUTE format(
+        'CREATE TABLE jobs_legacy PARTITION OF jobs_partitioned FOR VALUES FROM (MINVALUE) TO (%L)',
+        date_trunc('day', NOW()::TIMESTAMP) + INTERVAL '1 day'
+    );
+END $$;
+
+INSERT INTO jobs_partitioned (
+    id, type, payload, status, created_at, updated_at, started_at, completed_at, retry_count, max_retries,
+    last_err, next_run_at, priority, queue, idempotency_key, idempotency_expires_at, replay_count, replayed_at,
+    retry_policy, timeout_ms, locked_by, lease_expires_at, lease_token, result, progress, progress_message,
+    progress_updated_at
+)
+SELECT
+    id, type, payload, status, created_at, updated_at, started_at, completed_at, retry_count, max_retries,
+    last_err, next_run_at, priority, queue, idempotency_key, idempotency_expires_at, replay_count, replayed_at,
+    retry_policy, timeout_ms, locked_by, lease_expires_at, lease_token, result, progress, progress_message,
+    progress_updated_at
+FROM jobs;
+
+CREATE TABLE job_idempotency_keys (
+    key TEXT PRIMARY KEY,
+    job_id BIGINT NOT NULL,
+    expires_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_job_idempotency_keys_job_id ON job_idempotency_keys (job_id);
+
+INSERT INTO job_idempotency_keys (key, job_id, expires_at)
+SELECT idempotency_key, id, idempotency_expires_at
+FROM jobs
+WHERE idempotency_key IS NOT NULL AND idempotency_expires_at IS NOT NULL;
+
+DROP TABLE jobs;
+
+ALTER TABLE jobs_partitioned RENAME TO jobs;
+
+ALTER TABLE jobs ADD CONSTRAINT jobs_pkey PRIMARY KEY (id, created_at);
+
+CREATE SEQUENCE jobs_id_seq OWNED BY jobs.id;
+
+SELECT setval('jobs_id_seq', GREATEST(
+    (SELECT COALESCE(MAX(id), 0) FROM jobs),
+    (SELECT COALESCE(MAX(id), 0) FROM dead_jobs),
+    1
+));
+
+ALTER TABLE jobs ALTER COLUMN id SET DEFAULT nextval('jobs_id_seq');
+
+CREATE INDEX idx_jobs_status_created ON jobs(status, created_at)
+WHERE
+    status = 'pending';
+
+CREATE INDEX idx_jobs_status_next_run_at ON jobs (status, next_run_at);
+
+CREATE INDEX idx_jobs_pending_queue_priority ON jobs (queue, priority DESC, next_run_at ASC)
+WHERE
+    status = 'pending';
+
+CREATE INDEX idx_jobs_running_lease ON jobs (lease_expires_at) WHERE status = 'running';
+
+CREATE INDEX idx_jobs_created_at_id ON jobs (created_at, id);
+
+CREATE INDEX idx_jobs_completed_at ON jobs (completed_at) WHERE completed_at IS NOT NULL;
+
+CREATE INDEX idx_jobs_started_at ON jobs (started_at) WHERE started_at IS NOT NULL;
@@ -0,0 +1,7 @@
+-- unique_key is set on jobs submitted with a uniqueness spec: the job type
+-- followed by the values of the unique payload fields. Duplicates are not
+-- rejected by a constraint, since whether a job blocks one depends on its
+-- status and age; CreateJob serializes them with an advisory lock instead.
+ALTER TABLE jobs ADD COLUMN unique_key TEXT;
+
+CREATE INDEX idx_jobs_unique_key ON jobs (unique_key, created_at) WHERE unique_key IS NOT NULL;
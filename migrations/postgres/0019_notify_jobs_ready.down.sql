@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS jobs_notify_ready ON jobs;
+
+DROP FUNCTION IF EXISTS notify_jobs_ready();
@@ -0,0 +1,21 @@
+-- Announces inserted jobs that can be claimed now on the jobs_ready channel,
+-- with the job's queue as payload, in the same statement as the INSERT.
+-- Notifications are delivered on commit and deduplicated per transaction, so
+-- a batch insert sends one per queue. This also wakes dispatchers for jobs
+-- inserted by other services through the enqueue package.
+CREATE FUNCTION notify_jobs_ready() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('jobs_ready', ready.queue)
+    FROM (
+        SELECT DISTINCT queue FROM inserted
+        WHERE status = 'pending' AND next_run_at <= NOW()
+    ) ready;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER jobs_notify_ready
+    AFTER INSERT ON jobs
+    REFERENCING NEW TABLE AS inserted
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION notify_jobs_ready();
@@ -0,0 +1,9 @@
+-- the rows of jobs_default have no other partition to go to
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM jobs_default) THEN
+        RAISE EXCEPTION 'jobs_default still holds jobs, run EnsureJobPartitions first';
+    END IF;
+END $$;
+
+DROP TABLE jobs_default;
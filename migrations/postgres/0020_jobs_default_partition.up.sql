@@ -0,0 +1,4 @@
+-- jobs_default catches rows no daily partition covers yet, e.g. when the
+-- server has not created partitions for longer than JOB_PARTITIONS_AHEAD_DAYS.
+-- EnsureJobPartitions moves them into the partition it creates for their day.
+CREATE TABLE jobs_default PARTITION OF jobs DEFAULT;
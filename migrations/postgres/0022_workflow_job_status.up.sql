@@ -0,0 +1,8 @@
+-- the final status of a workflow step whose job was archived or dropped with
+-- its partition; NULL while the job is still in jobs or dead_jobs
+ALTER TABLE workflow_jobs ADD COLUMN status TEXT;
+
+-- until now only completed jobs were archived
+UPDATE workflow_jobs wj SET status = 'completed'
+WHERE NOT EXISTS (SELECT 1 FROM jobs j WHERE j.id = wj.job_id)
+    AND NOT EXISTS (SELECT 1 FROM dead_jobs d WHERE d.id = wj.job_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS job_attempts;
+DROP TABLE IF EXISTS job_dependencies;
+DROP TABLE IF EXISTS workflow_jobs;
+DROP TABLE IF EXISTS workflows;
+DROP TABLE IF EXISTS schedules;
+DROP TABLE IF EXISTS dead_jobs;
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,138 @@
+-- SQLite has no timestamp type: TIMESTAMP columns hold UTC text in the fixed
+-- format 2006-01-02 15:04:05.000000, which sorts chronologically. JSON
+-- columns are TEXT checked with json_valid.
+CREATE TABLE jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    type TEXT NOT NULL,
+    payload TEXT NOT NULL CHECK (json_valid(payload)),
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    retry_count INTEGER NOT NULL DEFAULT 0,
+    max_retries INTEGER NOT NULL DEFAULT 3,
+    last_err TEXT,
+    next_run_at TIMESTAMP NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    queue TEXT NOT NULL DEFAULT 'default',
+    idempotency_key TEXT,
+    idempotency_expires_at TIMESTAMP,
+    replay_count INTEGER NOT NULL DEFAULT 0,
+    replayed_at TIMESTAMP,
+    retry_policy TEXT CHECK (json_valid(retry_policy)),
+    timeout_ms INTEGER,
+    locked_by TEXT,
+    lease_expires_at TIMESTAMP,
+    lease_token INTEGER NOT NULL DEFAULT 0,
+    result TEXT CHECK (json_valid(result)),
+    progress INTEGER CHECK (progress BETWEEN 0 AND 100),
+    progress_message TEXT,
+    progress_updated_at TIMESTAMP,
+    CONSTRAINT jobs_status_check CHECK (
+        status IN ('pending', 'running', 'completed', 'failed', 'skipped', 'cancelled')
+    )
+);
+
+CREATE INDEX idx_jobs_pending_queue_priority ON jobs (queue, priority DESC, next_run_at ASC)
+WHERE
+    status = 'pending';
+
+CREATE UNIQUE INDEX idx_jobs_idempotency_key ON jobs (idempotency_key)
+WHERE
+    idempotency_key IS NOT NULL;
+
+CREATE INDEX idx_jobs_running_lease ON jobs (lease_expires_at) WHERE status = 'running';
+
+CREATE INDEX idx_jobs_created_at_id ON jobs (created_at, id);
+
+CREATE INDEX idx_jobs_completed_at ON jobs (completed_at) WHERE completed_at IS NOT NULL;
+
+CREATE INDEX idx_jobs_started_at ON jobs (started_at) WHERE started_at IS NOT NULL;
+
+
+CREATE TABLE dead_jobs (
+    id INTEGER PRIMARY KEY,
+    type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    last_err TEXT,
+    failed_at TIMESTAMP NOT NULL,
+    retry_count INTEGER NOT NULL,
+    queue TEXT NOT NULL DEFAULT 'default',
+    priority INTEGER NOT NULL DEFAULT 0,
+    replay_count INTEGER NOT NULL DEFAULT 0,
+    retry_policy TEXT,
+    timeout_ms INTEGER
+);
+
+CREATE INDEX idx_dead_jobs_type_failed_at ON dead_jobs (type, failed_at);
+
+CREATE INDEX idx_dead_jobs_failed_at_id ON dead_jobs (failed_at, id);
+
+
+CREATE TABLE schedules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    cron_expr TEXT NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    job_type TEXT NOT NULL,
+    payload_template TEXT NOT NULL DEFAULT '{}',
+    catchup_policy TEXT NOT NULL DEFAULT 'skip',
+    paused INTEGER NOT NULL DEFAULT 0,
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT schedules_catchup_policy_check CHECK (
+        catchup_policy IN ('skip', 'once', 'all')
+    )
+);
+
+CREATE INDEX idx_schedules_next_run_at ON schedules (next_run_at)
+WHERE
+    paused = 0;
+
+
+CREATE TABLE workflows (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- job ids are not foreign keys: completed jobs are archived and dead jobs move
+-- to dead_jobs, but the workflow graph must outlive both.
+CREATE TABLE workflow_jobs (
+    workflow_id INTEGER NOT NULL REFERENCES workflows (id) ON DELETE CASCADE,
+    job_id INTEGER NOT NULL,
+    step TEXT NOT NULL,
+    PRIMARY KEY (workflow_id, job_id),
+    UNIQUE (workflow_id, step)
+);
+
+CREATE INDEX idx_workflow_jobs_job_id ON workflow_jobs (job_id);
+
+CREATE TABLE job_dependencies (
+    job_id INTEGER NOT NULL,
+    depends_on INTEGER NOT NULL,
+    resolved INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (job_id, depends_on)
+);
+
+CREATE INDEX idx_job_dependencies_depends_on ON job_dependencies (depends_on);
+
+
+-- job_id is not a foreign key: attempts of dead-lettered jobs stay linked to
+-- the dead_jobs row, which keeps the job's id.
+CREATE TABLE job_attempts (
+    job_id INTEGER NOT NULL,
+    attempt INTEGER NOT NULL,
+    worker TEXT NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    outcome TEXT NOT NULL DEFAULT 'running',
+    error TEXT,
+    PRIMARY KEY (job_id, attempt),
+    CONSTRAINT job_attempts_outcome_check CHECK (
+        outcome IN ('running', 'completed', 'failed', 'cancelled', 'lease_expired')
+    )
+);
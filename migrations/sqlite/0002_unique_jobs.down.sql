@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_jobs_unique_key;
+
+ALTER TABLE jobs DROP COLUMN unique_key;
@@ -0,0 +1,5 @@
+-- unique_key is set on jobs submitted with a uniqueness spec: the job type
+-- followed by the values of the unique payload fields.
+ALTER TABLE jobs ADD COLUMN unique_key TEXT;
+
+CREATE INDEX idx_jobs_unique_key ON jobs (unique_key, created_at) WHERE unique_key IS NOT NULL;
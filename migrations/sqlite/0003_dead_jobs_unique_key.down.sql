@@ -0,0 +1 @@
+ALTER TABLE dead_jobs DROP COLUMN unique_key;
@@ -0,0 +1,3 @@
+-- dead jobs keep their unique_key, so a replayed job blocks its duplicates
+-- again
+ALTER TABLE dead_jobs ADD COLUMN unique_key TEXT;
@@ -0,0 +1 @@
+ALTER TABLE workflow_jobs DROP COLUMN status;
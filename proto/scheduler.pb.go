@@ -23,28 +23,2276 @@ const (
 )
 
 type SubmitJobRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Absolute time (RFC 3339) before which the job will not be dispatched.
+	RunAt string `protobuf:"bytes,3,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	// Relative delay from submission. Mutually exclusive with run_at.
+	DelaySeconds int64 `protobuf:"varint,4,opt,name=delay_seconds,json=delaySeconds,proto3" json:"delay_seconds,omitempty"`
+	// Higher priority jobs are dispatched first. Defaults to 0; may be negative.
+	Priority int32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Queue to run the job on. Defaults to the queue registered for the job type.
+	Queue string `protobuf:"bytes,6,opt,name=queue,proto3" json:"queue,omitempty"`
+	// Client-chosen key that makes the submission safe to retry: while a job with
+	// the same key is retained, that job is returned instead of creating another.
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Overrides fields of the retry policy registered for the job type.
+	RetryPolicy *RetryPolicy `protobuf:"bytes,8,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	// Overrides the execution timeout registered for the job type.
+	TimeoutMs     int64 `protobuf:"varint,9,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobRequest) Reset() {
+	*x = SubmitJobRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobRequest) ProtoMessage() {}
+
+func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobRequest.ProtoReflect.Descriptor instead.
+func (*SubmitJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitJobRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetRunAt() string {
+	if x != nil {
+		return x.RunAt
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetDelaySeconds() int64 {
+	if x != nil {
+		return x.DelaySeconds
+	}
+	return 0
+}
+
+func (x *SubmitJobRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *SubmitJobRequest) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SubmitJobRequest) GetRetryPolicy() *RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *SubmitJobRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+// RetryPolicy controls how a failed job is retried. Unset (zero) fields fall back
+// to the policy registered for the job type.
+type RetryPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of failures after which the job is dead-lettered.
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	BaseDelayMs int64 `protobuf:"varint,2,opt,name=base_delay_ms,json=baseDelayMs,proto3" json:"base_delay_ms,omitempty"`
+	MaxDelayMs  int64 `protobuf:"varint,3,opt,name=max_delay_ms,json=maxDelayMs,proto3" json:"max_delay_ms,omitempty"`
+	// "exponential", "linear" or "fixed".
+	Backoff string `protobuf:"bytes,4,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	// Randomizes each delay by up to this fraction (0-1) in either direction.
+	Jitter        float64 `protobuf:"fixed64,5,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_proto_scheduler_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RetryPolicy) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetBaseDelayMs() int64 {
+	if x != nil {
+		return x.BaseDelayMs
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetMaxDelayMs() int64 {
+	if x != nil {
+		return x.MaxDelayMs
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetBackoff() string {
+	if x != nil {
+		return x.Backoff
+	}
+	return ""
+}
+
+func (x *RetryPolicy) GetJitter() float64 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+type SubmitJobResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	JobId  string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Set when an existing job was returned for a repeated idempotency key.
+	Duplicate     bool `protobuf:"varint,3,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobResponse) Reset() {
+	*x = SubmitJobResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobResponse) ProtoMessage() {}
+
+func (x *SubmitJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobResponse.ProtoReflect.Descriptor instead.
+func (*SubmitJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubmitJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *SubmitJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SubmitJobResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type SubmitJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*SubmitJobRequest    `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobsRequest) Reset() {
+	*x = SubmitJobsRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobsRequest) ProtoMessage() {}
+
+func (x *SubmitJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobsRequest.ProtoReflect.Descriptor instead.
+func (*SubmitJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SubmitJobsRequest) GetJobs() []*SubmitJobRequest {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type SubmitJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SubmitJobsResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobsResponse) Reset() {
+	*x = SubmitJobsResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobsResponse) ProtoMessage() {}
+
+func (x *SubmitJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobsResponse.ProtoReflect.Descriptor instead.
+func (*SubmitJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubmitJobsResponse) GetResults() []*SubmitJobsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// SubmitJobsResult is the outcome of one job of a batch: either the created (or, for a
+// repeated idempotency key, the existing) job, or the reason it was rejected.
+type SubmitJobsResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Duplicate     bool                   `protobuf:"varint,3,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitJobsResult) Reset() {
+	*x = SubmitJobsResult{}
+	mi := &file_proto_scheduler_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitJobsResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobsResult) ProtoMessage() {}
+
+func (x *SubmitJobsResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobsResult.ProtoReflect.Descriptor instead.
+func (*SubmitJobsResult) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubmitJobsResult) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *SubmitJobsResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SubmitJobsResult) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *SubmitJobsResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetJobResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JobId          string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Payload        string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status         string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt    string                 `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ErrorMessage   string                 `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	RetryCount     string                 `protobuf:"bytes,8,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+	NextRunAt      string                 `protobuf:"bytes,9,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	Priority       int32                  `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`
+	Queue          string                 `protobuf:"bytes,11,opt,name=queue,proto3" json:"queue,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,12,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// Number of times the job was requeued from the dead letter queue.
+	ReplayCount int32  `protobuf:"varint,13,opt,name=replay_count,json=replayCount,proto3" json:"replay_count,omitempty"`
+	ReplayedAt  string `protobuf:"bytes,14,opt,name=replayed_at,json=replayedAt,proto3" json:"replayed_at,omitempty"`
+	// Per-job execution timeout; 0 when the job uses the timeout of its type.
+	TimeoutMs int64 `protobuf:"varint,15,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// Worker holding the lease of a running job, and when that lease lapses.
+	LockedBy       string `protobuf:"bytes,16,opt,name=locked_by,json=lockedBy,proto3" json:"locked_by,omitempty"`
+	LeaseExpiresAt string `protobuf:"bytes,17,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"`
+	// JSON output of a completed job, if its handler produces one.
+	Result string `protobuf:"bytes,18,opt,name=result,proto3" json:"result,omitempty"`
+	// Progress last reported by the job's handler: a percentage between 0 and 100,
+	// an optional message and when it was reported. Unset until the handler reports.
+	Progress          *int32 `protobuf:"varint,19,opt,name=progress,proto3,oneof" json:"progress,omitempty"`
+	ProgressMessage   string `protobuf:"bytes,20,opt,name=progress_message,json=progressMessage,proto3" json:"progress_message,omitempty"`
+	ProgressUpdatedAt string `protobuf:"bytes,21,opt,name=progress_updated_at,json=progressUpdatedAt,proto3" json:"progress_updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetJobResponse) Reset() {
+	*x = GetJobResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobResponse) ProtoMessage() {}
+
+func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
+func (*GetJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetRetryCount() string {
+	if x != nil {
+		return x.RetryCount
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *GetJobResponse) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetReplayCount() int32 {
+	if x != nil {
+		return x.ReplayCount
+	}
+	return 0
+}
+
+func (x *GetJobResponse) GetReplayedAt() string {
+	if x != nil {
+		return x.ReplayedAt
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *GetJobResponse) GetLockedBy() string {
+	if x != nil {
+		return x.LockedBy
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetLeaseExpiresAt() string {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetProgress() int32 {
+	if x != nil && x.Progress != nil {
+		return *x.Progress
+	}
+	return 0
+}
+
+func (x *GetJobResponse) GetProgressMessage() string {
+	if x != nil {
+		return x.ProgressMessage
+	}
+	return ""
+}
+
+func (x *GetJobResponse) GetProgressUpdatedAt() string {
+	if x != nil {
+		return x.ProgressUpdatedAt
+	}
+	return ""
+}
+
+type ListJobAttemptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobAttemptsRequest) Reset() {
+	*x = ListJobAttemptsRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobAttemptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobAttemptsRequest) ProtoMessage() {}
+
+func (x *ListJobAttemptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobAttemptsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobAttemptsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListJobAttemptsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type JobAttempt struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Attempt   int32                  `protobuf:"varint,1,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Worker    string                 `protobuf:"bytes,2,opt,name=worker,proto3" json:"worker,omitempty"`
+	StartedAt string                 `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Empty while the attempt is running.
+	FinishedAt string `protobuf:"bytes,4,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	DurationMs int64  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// One of running, completed, failed, cancelled or lease_expired.
+	Outcome       string `protobuf:"bytes,6,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobAttempt) Reset() {
+	*x = JobAttempt{}
+	mi := &file_proto_scheduler_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobAttempt) ProtoMessage() {}
+
+func (x *JobAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobAttempt.ProtoReflect.Descriptor instead.
+func (*JobAttempt) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JobAttempt) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *JobAttempt) GetWorker() string {
+	if x != nil {
+		return x.Worker
+	}
+	return ""
+}
+
+func (x *JobAttempt) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *JobAttempt) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+func (x *JobAttempt) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *JobAttempt) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *JobAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListJobAttemptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Attempts      []*JobAttempt          `protobuf:"bytes,2,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobAttemptsResponse) Reset() {
+	*x = ListJobAttemptsResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobAttemptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobAttemptsResponse) ProtoMessage() {}
+
+func (x *ListJobAttemptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobAttemptsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobAttemptsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListJobAttemptsResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ListJobAttemptsResponse) GetAttempts() []*JobAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type CancelJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobResponse) Reset() {
+	*x = CancelJobResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobResponse) ProtoMessage() {}
+
+func (x *CancelJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CancelJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *CancelJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListJobRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Limit  int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Only return jobs with at least this priority.
+	MinPriority *int32 `protobuf:"varint,3,opt,name=min_priority,json=minPriority,proto3,oneof" json:"min_priority,omitempty"`
+	// Only return jobs on this queue.
+	Queue string `protobuf:"bytes,4,opt,name=queue,proto3" json:"queue,omitempty"`
+	// Only return jobs in one of these statuses.
+	Status []string `protobuf:"bytes,5,rep,name=status,proto3" json:"status,omitempty"`
+	Type   string   `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	// RFC 3339 bounds on creation and completion time; the lower bound is inclusive.
+	CreatedAfter    string `protobuf:"bytes,7,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore   string `protobuf:"bytes,8,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	CompletedAfter  string `protobuf:"bytes,9,opt,name=completed_after,json=completedAfter,proto3" json:"completed_after,omitempty"`
+	CompletedBefore string `protobuf:"bytes,10,opt,name=completed_before,json=completedBefore,proto3" json:"completed_before,omitempty"`
+	MinRetries      *int32 `protobuf:"varint,11,opt,name=min_retries,json=minRetries,proto3,oneof" json:"min_retries,omitempty"`
+	MaxRetries      *int32 `protobuf:"varint,12,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`
+	// Only return jobs whose last error contains this text.
+	ErrorContains string `protobuf:"bytes,13,opt,name=error_contains,json=errorContains,proto3" json:"error_contains,omitempty"`
+	// One of created_at (default), completed_at, priority or retry_count.
+	SortBy string `protobuf:"bytes,14,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// asc or desc (default).
+	SortOrder string `protobuf:"bytes,15,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	// next_page_token of the previous page. Token pages seek instead of skipping rows and
+	// stay fast on large tables; the token is tied to the sort and cannot be combined with offset.
+	PageToken string `protobuf:"bytes,16,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// How total_records is computed: exact (default without page_token), estimated from
+	// the query planner, or none (default with page_token).
+	TotalCount    string `protobuf:"bytes,17,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobRequest) Reset() {
+	*x = ListJobRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobRequest) ProtoMessage() {}
+
+func (x *ListJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobRequest.ProtoReflect.Descriptor instead.
+func (*ListJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListJobRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListJobRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListJobRequest) GetMinPriority() int32 {
+	if x != nil && x.MinPriority != nil {
+		return *x.MinPriority
+	}
+	return 0
+}
+
+func (x *ListJobRequest) GetQueue() string {
+	if x != nil {
+		return x.Queue
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetStatus() []string {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListJobRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetCompletedAfter() string {
+	if x != nil {
+		return x.CompletedAfter
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetCompletedBefore() string {
+	if x != nil {
+		return x.CompletedBefore
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetMinRetries() int32 {
+	if x != nil && x.MinRetries != nil {
+		return *x.MinRetries
+	}
+	return 0
+}
+
+func (x *ListJobRequest) GetMaxRetries() int32 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return 0
+}
+
+func (x *ListJobRequest) GetErrorContains() string {
+	if x != nil {
+		return x.ErrorContains
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListJobRequest) GetTotalCount() string {
+	if x != nil {
+		return x.TotalCount
+	}
+	return ""
+}
+
+type PaginationMetaData struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 for pages selected by page_token.
+	CurrentPage  int32 `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages   int32 `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	TotalRecords int64 `protobuf:"varint,3,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
+	Limit        int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// exact, estimated or none; total_records and total_pages are 0 for none.
+	TotalCount    string `protobuf:"bytes,5,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaginationMetaData) Reset() {
+	*x = PaginationMetaData{}
+	mi := &file_proto_scheduler_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaginationMetaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaginationMetaData) ProtoMessage() {}
+
+func (x *PaginationMetaData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaginationMetaData.ProtoReflect.Descriptor instead.
+func (*PaginationMetaData) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PaginationMetaData) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *PaginationMetaData) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *PaginationMetaData) GetTotalRecords() int64 {
+	if x != nil {
+		return x.TotalRecords
+	}
+	return 0
+}
+
+func (x *PaginationMetaData) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PaginationMetaData) GetTotalCount() string {
+	if x != nil {
+		return x.TotalCount
+	}
+	return ""
+}
+
+type ListJobResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Jobs  []*GetJobResponse      `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Meta  *PaginationMetaData    `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	// Empty on the last page, and when sorting by completed_at.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobResponse) Reset() {
+	*x = ListJobResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobResponse) ProtoMessage() {}
+
+func (x *ListJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobResponse.ProtoReflect.Descriptor instead.
+func (*ListJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListJobResponse) GetJobs() []*GetJobResponse {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *ListJobResponse) GetMeta() *PaginationMetaData {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *ListJobResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RetryDeadJobRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	JobId string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	// Replaces the stored payload when set.
+	Payload       string `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryDeadJobRequest) Reset() {
+	*x = RetryDeadJobRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryDeadJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryDeadJobRequest) ProtoMessage() {}
+
+func (x *RetryDeadJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryDeadJobRequest.ProtoReflect.Descriptor instead.
+func (*RetryDeadJobRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RetryDeadJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *RetryDeadJobRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+type RetryDeadJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ReplayCount   int32                  `protobuf:"varint,3,opt,name=replay_count,json=replayCount,proto3" json:"replay_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryDeadJobResponse) Reset() {
+	*x = RetryDeadJobResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryDeadJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryDeadJobResponse) ProtoMessage() {}
+
+func (x *RetryDeadJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryDeadJobResponse.ProtoReflect.Descriptor instead.
+func (*RetryDeadJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RetryDeadJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *RetryDeadJobResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RetryDeadJobResponse) GetReplayCount() int32 {
+	if x != nil {
+		return x.ReplayCount
+	}
+	return 0
+}
+
+type RetryDeadJobsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	JobIds []string               `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	Type   string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Matches dead jobs whose last error contains this text.
+	ErrorContains string `protobuf:"bytes,3,opt,name=error_contains,json=errorContains,proto3" json:"error_contains,omitempty"`
+	// RFC 3339 bounds on the time the job was dead-lettered.
+	FailedAfter   string `protobuf:"bytes,4,opt,name=failed_after,json=failedAfter,proto3" json:"failed_after,omitempty"`
+	FailedBefore  string `protobuf:"bytes,5,opt,name=failed_before,json=failedBefore,proto3" json:"failed_before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryDeadJobsRequest) Reset() {
+	*x = RetryDeadJobsRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryDeadJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryDeadJobsRequest) ProtoMessage() {}
+
+func (x *RetryDeadJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryDeadJobsRequest.ProtoReflect.Descriptor instead.
+func (*RetryDeadJobsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RetryDeadJobsRequest) GetJobIds() []string {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+func (x *RetryDeadJobsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RetryDeadJobsRequest) GetErrorContains() string {
+	if x != nil {
+		return x.ErrorContains
+	}
+	return ""
+}
+
+func (x *RetryDeadJobsRequest) GetFailedAfter() string {
+	if x != nil {
+		return x.FailedAfter
+	}
+	return ""
+}
+
+func (x *RetryDeadJobsRequest) GetFailedBefore() string {
+	if x != nil {
+		return x.FailedBefore
+	}
+	return ""
+}
+
+type RetryDeadJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobIds        []string               `protobuf:"bytes,1,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryDeadJobsResponse) Reset() {
+	*x = RetryDeadJobsResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryDeadJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryDeadJobsResponse) ProtoMessage() {}
+
+func (x *RetryDeadJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryDeadJobsResponse.ProtoReflect.Descriptor instead.
+func (*RetryDeadJobsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RetryDeadJobsResponse) GetJobIds() []string {
+	if x != nil {
+		return x.JobIds
+	}
+	return nil
+}
+
+type GetJobStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobStatsRequest) Reset() {
+	*x = GetJobStatsRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobStatsRequest) ProtoMessage() {}
+
+func (x *GetJobStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{20}
+}
+
+type GetJobStatusResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TotalJobs   int64                  `protobuf:"varint,1,opt,name=total_jobs,json=totalJobs,proto3" json:"total_jobs,omitempty"`
+	PendingJobs int64                  `protobuf:"varint,2,opt,name=pending_jobs,json=pendingJobs,proto3" json:"pending_jobs,omitempty"`
+	RunningJobs int64                  `protobuf:"varint,3,opt,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
+	// Jobs that failed without a retry plus dead-lettered jobs.
+	FailedJobs    int64 `protobuf:"varint,4,opt,name=failed_jobs,json=failedJobs,proto3" json:"failed_jobs,omitempty"`
+	CompletedJobs int64 `protobuf:"varint,5,opt,name=completed_jobs,json=completedJobs,proto3" json:"completed_jobs,omitempty"`
+	CancelledJobs int64 `protobuf:"varint,6,opt,name=cancelled_jobs,json=cancelledJobs,proto3" json:"cancelled_jobs,omitempty"`
+	SkippedJobs   int64 `protobuf:"varint,7,opt,name=skipped_jobs,json=skippedJobs,proto3" json:"skipped_jobs,omitempty"`
+	DeadJobs      int64 `protobuf:"varint,8,opt,name=dead_jobs,json=deadJobs,proto3" json:"dead_jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobStatusResponse) Reset() {
+	*x = GetJobStatusResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobStatusResponse) ProtoMessage() {}
+
+func (x *GetJobStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetJobStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetJobStatusResponse) GetTotalJobs() int64 {
+	if x != nil {
+		return x.TotalJobs
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetPendingJobs() int64 {
+	if x != nil {
+		return x.PendingJobs
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetRunningJobs() int64 {
+	if x != nil {
+		return x.RunningJobs
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetFailedJobs() int64 {
+	if x != nil {
+		return x.FailedJobs
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetCompletedJobs() int64 {
+	if x != nil {
+		return x.CompletedJobs
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetCancelledJobs() int64 {
+	if x != nil {
+		return x.CancelledJobs
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetSkippedJobs() int64 {
+	if x != nil {
+		return x.SkippedJobs
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetDeadJobs() int64 {
+	if x != nil {
+		return x.DeadJobs
+	}
+	return 0
+}
+
+type GetJobTypeStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only report this job type.
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Length of the window ending now; defaults to one hour.
+	WindowSeconds int64 `protobuf:"varint,2,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"`
+	// Length of each bucket; defaults to one minute, 0 returns no buckets.
+	BucketSeconds *int64 `protobuf:"varint,3,opt,name=bucket_seconds,json=bucketSeconds,proto3,oneof" json:"bucket_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobTypeStatsRequest) Reset() {
+	*x = GetJobTypeStatsRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobTypeStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobTypeStatsRequest) ProtoMessage() {}
+
+func (x *GetJobTypeStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobTypeStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobTypeStatsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetJobTypeStatsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetJobTypeStatsRequest) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *GetJobTypeStatsRequest) GetBucketSeconds() int64 {
+	if x != nil && x.BucketSeconds != nil {
+		return *x.BucketSeconds
+	}
+	return 0
+}
+
+type JobWindowStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RFC 3339 bounds of the window.
+	Start     string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End       string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Completed int64  `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	// Failed and dead-lettered jobs.
+	Failed int64 `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Jobs claimed by a worker.
+	Started             int64   `protobuf:"varint,5,opt,name=started,proto3" json:"started,omitempty"`
+	ThroughputPerMinute float64 `protobuf:"fixed64,6,opt,name=throughput_per_minute,json=throughputPerMinute,proto3" json:"throughput_per_minute,omitempty"`
+	ThroughputPerHour   float64 `protobuf:"fixed64,7,opt,name=throughput_per_hour,json=throughputPerHour,proto3" json:"throughput_per_hour,omitempty"`
+	// Share of finished jobs that completed; 0 when none finished.
+	SuccessRatio float64 `protobuf:"fixed64,8,opt,name=success_ratio,json=successRatio,proto3" json:"success_ratio,omitempty"`
+	// Time from a job being due until a worker claimed it.
+	AvgWaitMs int64 `protobuf:"varint,9,opt,name=avg_wait_ms,json=avgWaitMs,proto3" json:"avg_wait_ms,omitempty"`
+	P95WaitMs int64 `protobuf:"varint,10,opt,name=p95_wait_ms,json=p95WaitMs,proto3" json:"p95_wait_ms,omitempty"`
+	// Execution time of finished jobs.
+	AvgDurationMs int64 `protobuf:"varint,11,opt,name=avg_duration_ms,json=avgDurationMs,proto3" json:"avg_duration_ms,omitempty"`
+	P95DurationMs int64 `protobuf:"varint,12,opt,name=p95_duration_ms,json=p95DurationMs,proto3" json:"p95_duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobWindowStats) Reset() {
+	*x = JobWindowStats{}
+	mi := &file_proto_scheduler_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobWindowStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobWindowStats) ProtoMessage() {}
+
+func (x *JobWindowStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobWindowStats.ProtoReflect.Descriptor instead.
+func (*JobWindowStats) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *JobWindowStats) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *JobWindowStats) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *JobWindowStats) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetStarted() int64 {
+	if x != nil {
+		return x.Started
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetThroughputPerMinute() float64 {
+	if x != nil {
+		return x.ThroughputPerMinute
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetThroughputPerHour() float64 {
+	if x != nil {
+		return x.ThroughputPerHour
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetSuccessRatio() float64 {
+	if x != nil {
+		return x.SuccessRatio
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetAvgWaitMs() int64 {
+	if x != nil {
+		return x.AvgWaitMs
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetP95WaitMs() int64 {
+	if x != nil {
+		return x.P95WaitMs
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetAvgDurationMs() int64 {
+	if x != nil {
+		return x.AvgDurationMs
+	}
+	return 0
+}
+
+func (x *JobWindowStats) GetP95DurationMs() int64 {
+	if x != nil {
+		return x.P95DurationMs
+	}
+	return 0
+}
+
+type JobTypeStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Total         *JobWindowStats        `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
+	Buckets       []*JobWindowStats      `protobuf:"bytes,3,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobTypeStats) Reset() {
+	*x = JobTypeStats{}
+	mi := &file_proto_scheduler_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobTypeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobTypeStats) ProtoMessage() {}
+
+func (x *JobTypeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobTypeStats.ProtoReflect.Descriptor instead.
+func (*JobTypeStats) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *JobTypeStats) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *JobTypeStats) GetTotal() *JobWindowStats {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *JobTypeStats) GetBuckets() []*JobWindowStats {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type GetJobTypeStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         []*JobTypeStats        `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobTypeStatsResponse) Reset() {
+	*x = GetJobTypeStatsResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobTypeStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobTypeStatsResponse) ProtoMessage() {}
+
+func (x *GetJobTypeStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobTypeStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetJobTypeStatsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetJobTypeStatsResponse) GetTypes() []*JobTypeStats {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type CreateScheduleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Standard 5-field cron expression or descriptor such as "@daily".
+	CronExpr string `protobuf:"bytes,2,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
+	// IANA time zone the cron expression is evaluated in. Defaults to UTC.
+	Timezone string `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	JobType  string `protobuf:"bytes,4,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	// Go text/template rendered into the job payload on every tick.
+	// Available fields: {{.Name}}, {{.ScheduledAt}} (RFC 3339) and {{.Date}} (YYYY-MM-DD).
+	PayloadTemplate string `protobuf:"bytes,5,opt,name=payload_template,json=payloadTemplate,proto3" json:"payload_template,omitempty"`
+	// What to do with ticks missed while the scheduler was down: "skip" (default), "once" or "all".
+	CatchupPolicy string `protobuf:"bytes,6,opt,name=catchup_policy,json=catchupPolicy,proto3" json:"catchup_policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduleRequest) Reset() {
+	*x = CreateScheduleRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduleRequest) ProtoMessage() {}
+
+func (x *CreateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateScheduleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetCronExpr() string {
+	if x != nil {
+		return x.CronExpr
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetPayloadTemplate() string {
+	if x != nil {
+		return x.PayloadTemplate
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetCatchupPolicy() string {
+	if x != nil {
+		return x.CatchupPolicy
+	}
+	return ""
+}
+
+type ScheduleResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId      string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CronExpr        string                 `protobuf:"bytes,3,opt,name=cron_expr,json=cronExpr,proto3" json:"cron_expr,omitempty"`
+	Timezone        string                 `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	JobType         string                 `protobuf:"bytes,5,opt,name=job_type,json=jobType,proto3" json:"job_type,omitempty"`
+	PayloadTemplate string                 `protobuf:"bytes,6,opt,name=payload_template,json=payloadTemplate,proto3" json:"payload_template,omitempty"`
+	CatchupPolicy   string                 `protobuf:"bytes,7,opt,name=catchup_policy,json=catchupPolicy,proto3" json:"catchup_policy,omitempty"`
+	Paused          bool                   `protobuf:"varint,8,opt,name=paused,proto3" json:"paused,omitempty"`
+	NextRunAt       string                 `protobuf:"bytes,9,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastRunAt       string                 `protobuf:"bytes,10,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScheduleResponse) Reset() {
+	*x = ScheduleResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleResponse) ProtoMessage() {}
+
+func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ScheduleResponse) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetCronExpr() string {
+	if x != nil {
+		return x.CronExpr
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetJobType() string {
+	if x != nil {
+		return x.JobType
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetPayloadTemplate() string {
+	if x != nil {
+		return x.PayloadTemplate
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetCatchupPolicy() string {
+	if x != nil {
+		return x.CatchupPolicy
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *ScheduleResponse) GetNextRunAt() string {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetLastRunAt() string {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return ""
+}
+
+func (x *ScheduleResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{28}
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*ScheduleResponse    `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListSchedulesResponse) GetSchedules() []*ScheduleResponse {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type PauseScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	Payload       string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	Resume        bool                   `protobuf:"varint,2,opt,name=resume,proto3" json:"resume,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitJobRequest) Reset() {
-	*x = SubmitJobRequest{}
-	mi := &file_proto_scheduler_proto_msgTypes[0]
+func (x *PauseScheduleRequest) Reset() {
+	*x = PauseScheduleRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitJobRequest) String() string {
+func (x *PauseScheduleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitJobRequest) ProtoMessage() {}
+func (*PauseScheduleRequest) ProtoMessage() {}
 
-func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[0]
+func (x *PauseScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,48 +2303,47 @@ func (x *SubmitJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitJobRequest.ProtoReflect.Descriptor instead.
-func (*SubmitJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use PauseScheduleRequest.ProtoReflect.Descriptor instead.
+func (*PauseScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *SubmitJobRequest) GetType() string {
+func (x *PauseScheduleRequest) GetScheduleId() string {
 	if x != nil {
-		return x.Type
+		return x.ScheduleId
 	}
 	return ""
 }
 
-func (x *SubmitJobRequest) GetPayload() string {
+func (x *PauseScheduleRequest) GetResume() bool {
 	if x != nil {
-		return x.Payload
+		return x.Resume
 	}
-	return ""
+	return false
 }
 
-type SubmitJobResponse struct {
+type DeleteScheduleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubmitJobResponse) Reset() {
-	*x = SubmitJobResponse{}
-	mi := &file_proto_scheduler_proto_msgTypes[1]
+func (x *DeleteScheduleRequest) Reset() {
+	*x = DeleteScheduleRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubmitJobResponse) String() string {
+func (x *DeleteScheduleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubmitJobResponse) ProtoMessage() {}
+func (*DeleteScheduleRequest) ProtoMessage() {}
 
-func (x *SubmitJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[1]
+func (x *DeleteScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,47 +2354,39 @@ func (x *SubmitJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubmitJobResponse.ProtoReflect.Descriptor instead.
-func (*SubmitJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *SubmitJobResponse) GetJobId() string {
-	if x != nil {
-		return x.JobId
-	}
-	return ""
+// Deprecated: Use DeleteScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *SubmitJobResponse) GetStatus() string {
+func (x *DeleteScheduleRequest) GetScheduleId() string {
 	if x != nil {
-		return x.Status
+		return x.ScheduleId
 	}
 	return ""
 }
 
-type GetJobRequest struct {
+type DeleteScheduleResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobRequest) Reset() {
-	*x = GetJobRequest{}
-	mi := &file_proto_scheduler_proto_msgTypes[2]
+func (x *DeleteScheduleResponse) Reset() {
+	*x = DeleteScheduleResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobRequest) String() string {
+func (x *DeleteScheduleResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobRequest) ProtoMessage() {}
+func (*DeleteScheduleResponse) ProtoMessage() {}
 
-func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[2]
+func (x *DeleteScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -158,47 +2397,41 @@ func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
-func (*GetJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *GetJobRequest) GetJobId() string {
-	if x != nil {
-		return x.JobId
-	}
-	return ""
+// Deprecated: Use DeleteScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{32}
 }
 
-type GetJobResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Payload       string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	CompletedAt   string                 `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	RetryCount    string                 `protobuf:"bytes,8,opt,name=retry_count,json=retryCount,proto3" json:"retry_count,omitempty"`
+type WorkflowJob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the job within the workflow, referenced by depends_on of other jobs.
+	Key           string       `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Type          string       `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Payload       string       `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	DependsOn     []string     `protobuf:"bytes,4,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Priority      int32        `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Queue         string       `protobuf:"bytes,6,opt,name=queue,proto3" json:"queue,omitempty"`
+	RetryPolicy   *RetryPolicy `protobuf:"bytes,7,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	TimeoutMs     int64        `protobuf:"varint,8,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobResponse) Reset() {
-	*x = GetJobResponse{}
-	mi := &file_proto_scheduler_proto_msgTypes[3]
+func (x *WorkflowJob) Reset() {
+	*x = WorkflowJob{}
+	mi := &file_proto_scheduler_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobResponse) String() string {
+func (x *WorkflowJob) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobResponse) ProtoMessage() {}
+func (*WorkflowJob) ProtoMessage() {}
 
-func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[3]
+func (x *WorkflowJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -209,90 +2442,90 @@ func (x *GetJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobResponse.ProtoReflect.Descriptor instead.
-func (*GetJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use WorkflowJob.ProtoReflect.Descriptor instead.
+func (*WorkflowJob) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *GetJobResponse) GetJobId() string {
+func (x *WorkflowJob) GetKey() string {
 	if x != nil {
-		return x.JobId
+		return x.Key
 	}
 	return ""
 }
 
-func (x *GetJobResponse) GetType() string {
+func (x *WorkflowJob) GetType() string {
 	if x != nil {
 		return x.Type
 	}
 	return ""
 }
 
-func (x *GetJobResponse) GetPayload() string {
+func (x *WorkflowJob) GetPayload() string {
 	if x != nil {
 		return x.Payload
 	}
 	return ""
 }
 
-func (x *GetJobResponse) GetStatus() string {
+func (x *WorkflowJob) GetDependsOn() []string {
 	if x != nil {
-		return x.Status
+		return x.DependsOn
 	}
-	return ""
+	return nil
 }
 
-func (x *GetJobResponse) GetCreatedAt() string {
+func (x *WorkflowJob) GetPriority() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Priority
 	}
-	return ""
+	return 0
 }
 
-func (x *GetJobResponse) GetCompletedAt() string {
+func (x *WorkflowJob) GetQueue() string {
 	if x != nil {
-		return x.CompletedAt
+		return x.Queue
 	}
 	return ""
 }
 
-func (x *GetJobResponse) GetErrorMessage() string {
+func (x *WorkflowJob) GetRetryPolicy() *RetryPolicy {
 	if x != nil {
-		return x.ErrorMessage
+		return x.RetryPolicy
 	}
-	return ""
+	return nil
 }
 
-func (x *GetJobResponse) GetRetryCount() string {
+func (x *WorkflowJob) GetTimeoutMs() int64 {
 	if x != nil {
-		return x.RetryCount
+		return x.TimeoutMs
 	}
-	return ""
+	return 0
 }
 
-type ListJobRequest struct {
+type SubmitWorkflowRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Jobs          []*WorkflowJob         `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobRequest) Reset() {
-	*x = ListJobRequest{}
-	mi := &file_proto_scheduler_proto_msgTypes[4]
+func (x *SubmitWorkflowRequest) Reset() {
+	*x = SubmitWorkflowRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobRequest) String() string {
+func (x *SubmitWorkflowRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobRequest) ProtoMessage() {}
+func (*SubmitWorkflowRequest) ProtoMessage() {}
 
-func (x *ListJobRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[4]
+func (x *SubmitWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,50 +2536,53 @@ func (x *ListJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobRequest.ProtoReflect.Descriptor instead.
-func (*ListJobRequest) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SubmitWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*SubmitWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *ListJobRequest) GetLimit() int32 {
+func (x *SubmitWorkflowRequest) GetName() string {
 	if x != nil {
-		return x.Limit
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ListJobRequest) GetOffset() int32 {
+func (x *SubmitWorkflowRequest) GetJobs() []*WorkflowJob {
 	if x != nil {
-		return x.Offset
+		return x.Jobs
 	}
-	return 0
+	return nil
 }
 
-type PaginationMetaData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CurrentPage   int32                  `protobuf:"varint,1,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
-	TotalPages    int32                  `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
-	TotalRecords  int64                  `protobuf:"varint,3,opt,name=total_records,json=totalRecords,proto3" json:"total_records,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+type WorkflowJobStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	JobId string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Type  string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// pending, running, completed, failed, skipped or dead.
+	Status        string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorMessage  string   `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	DependsOn     []string `protobuf:"bytes,6,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaginationMetaData) Reset() {
-	*x = PaginationMetaData{}
-	mi := &file_proto_scheduler_proto_msgTypes[5]
+func (x *WorkflowJobStatus) Reset() {
+	*x = WorkflowJobStatus{}
+	mi := &file_proto_scheduler_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaginationMetaData) String() string {
+func (x *WorkflowJobStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaginationMetaData) ProtoMessage() {}
+func (*WorkflowJobStatus) ProtoMessage() {}
 
-func (x *PaginationMetaData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[5]
+func (x *WorkflowJobStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -357,62 +2593,76 @@ func (x *PaginationMetaData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaginationMetaData.ProtoReflect.Descriptor instead.
-func (*PaginationMetaData) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use WorkflowJobStatus.ProtoReflect.Descriptor instead.
+func (*WorkflowJobStatus) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *PaginationMetaData) GetCurrentPage() int32 {
+func (x *WorkflowJobStatus) GetKey() string {
 	if x != nil {
-		return x.CurrentPage
+		return x.Key
 	}
-	return 0
+	return ""
 }
 
-func (x *PaginationMetaData) GetTotalPages() int32 {
+func (x *WorkflowJobStatus) GetJobId() string {
 	if x != nil {
-		return x.TotalPages
+		return x.JobId
 	}
-	return 0
+	return ""
 }
 
-func (x *PaginationMetaData) GetTotalRecords() int64 {
+func (x *WorkflowJobStatus) GetType() string {
 	if x != nil {
-		return x.TotalRecords
+		return x.Type
 	}
-	return 0
+	return ""
 }
 
-func (x *PaginationMetaData) GetLimit() int32 {
+func (x *WorkflowJobStatus) GetStatus() string {
 	if x != nil {
-		return x.Limit
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-type ListJobResponse struct {
+func (x *WorkflowJobStatus) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *WorkflowJobStatus) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+type SubmitWorkflowResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Jobs          []*GetJobResponse      `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
-	Meta          *PaginationMetaData    `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Jobs          []*WorkflowJobStatus   `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListJobResponse) Reset() {
-	*x = ListJobResponse{}
-	mi := &file_proto_scheduler_proto_msgTypes[6]
+func (x *SubmitWorkflowResponse) Reset() {
+	*x = SubmitWorkflowResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListJobResponse) String() string {
+func (x *SubmitWorkflowResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListJobResponse) ProtoMessage() {}
+func (*SubmitWorkflowResponse) ProtoMessage() {}
 
-func (x *ListJobResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[6]
+func (x *SubmitWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -423,46 +2673,47 @@ func (x *ListJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListJobResponse.ProtoReflect.Descriptor instead.
-func (*ListJobResponse) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SubmitWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*SubmitWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ListJobResponse) GetJobs() []*GetJobResponse {
+func (x *SubmitWorkflowResponse) GetWorkflowId() string {
 	if x != nil {
-		return x.Jobs
+		return x.WorkflowId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListJobResponse) GetMeta() *PaginationMetaData {
+func (x *SubmitWorkflowResponse) GetJobs() []*WorkflowJobStatus {
 	if x != nil {
-		return x.Meta
+		return x.Jobs
 	}
 	return nil
 }
 
-type GetJobStatsRequest struct {
+type GetWorkflowRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobStatsRequest) Reset() {
-	*x = GetJobStatsRequest{}
-	mi := &file_proto_scheduler_proto_msgTypes[7]
+func (x *GetWorkflowRequest) Reset() {
+	*x = GetWorkflowRequest{}
+	mi := &file_proto_scheduler_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobStatsRequest) String() string {
+func (x *GetWorkflowRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobStatsRequest) ProtoMessage() {}
+func (*GetWorkflowRequest) ProtoMessage() {}
 
-func (x *GetJobStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[7]
+func (x *GetWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,37 +2724,47 @@ func (x *GetJobStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetJobStatsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{37}
 }
 
-type GetJobStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TotalJobs     int64                  `protobuf:"varint,1,opt,name=total_jobs,json=totalJobs,proto3" json:"total_jobs,omitempty"`
-	PendingJobs   int64                  `protobuf:"varint,2,opt,name=pending_jobs,json=pendingJobs,proto3" json:"pending_jobs,omitempty"`
-	RunningJobs   int64                  `protobuf:"varint,3,opt,name=running_jobs,json=runningJobs,proto3" json:"running_jobs,omitempty"`
-	FailedJobs    int64                  `protobuf:"varint,4,opt,name=failed_jobs,json=failedJobs,proto3" json:"failed_jobs,omitempty"`
-	CompletedJobs int64                  `protobuf:"varint,5,opt,name=completed_jobs,json=completedJobs,proto3" json:"completed_jobs,omitempty"`
+func (x *GetWorkflowRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+type GetWorkflowResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// pending, running, completed or failed.
+	Status    string               `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt string               `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Jobs      []*WorkflowJobStatus `protobuf:"bytes,5,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// Number of jobs per job status.
+	StatusCounts  map[string]int32 `protobuf:"bytes,6,rep,name=status_counts,json=statusCounts,proto3" json:"status_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetJobStatusResponse) Reset() {
-	*x = GetJobStatusResponse{}
-	mi := &file_proto_scheduler_proto_msgTypes[8]
+func (x *GetWorkflowResponse) Reset() {
+	*x = GetWorkflowResponse{}
+	mi := &file_proto_scheduler_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetJobStatusResponse) String() string {
+func (x *GetWorkflowResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobStatusResponse) ProtoMessage() {}
+func (*GetWorkflowResponse) ProtoMessage() {}
 
-func (x *GetJobStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_scheduler_proto_msgTypes[8]
+func (x *GetWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_scheduler_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,59 +2775,91 @@ func (x *GetJobStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetJobStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_scheduler_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*GetWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_scheduler_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetJobStatusResponse) GetTotalJobs() int64 {
+func (x *GetWorkflowResponse) GetWorkflowId() string {
 	if x != nil {
-		return x.TotalJobs
+		return x.WorkflowId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetJobStatusResponse) GetPendingJobs() int64 {
+func (x *GetWorkflowResponse) GetName() string {
 	if x != nil {
-		return x.PendingJobs
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *GetJobStatusResponse) GetRunningJobs() int64 {
+func (x *GetWorkflowResponse) GetStatus() string {
 	if x != nil {
-		return x.RunningJobs
+		return x.Status
 	}
-	return 0
+	return ""
 }
 
-func (x *GetJobStatusResponse) GetFailedJobs() int64 {
+func (x *GetWorkflowResponse) GetCreatedAt() string {
 	if x != nil {
-		return x.FailedJobs
+		return x.CreatedAt
 	}
-	return 0
+	return ""
 }
 
-func (x *GetJobStatusResponse) GetCompletedJobs() int64 {
+func (x *GetWorkflowResponse) GetJobs() []*WorkflowJobStatus {
 	if x != nil {
-		return x.CompletedJobs
+		return x.Jobs
 	}
-	return 0
+	return nil
+}
+
+func (x *GetWorkflowResponse) GetStatusCounts() map[string]int32 {
+	if x != nil {
+		return x.StatusCounts
+	}
+	return nil
 }
 
 var File_proto_scheduler_proto protoreflect.FileDescriptor
 
 const file_proto_scheduler_proto_rawDesc = "" +
 	"\n" +
-	"\x15proto/scheduler.proto\x12\tscheduler\x1a\x1cgoogle/api/annotations.proto\"@\n" +
+	"\x15proto/scheduler.proto\x12\tscheduler\x1a\x1cgoogle/api/annotations.proto\"\xb1\x02\n" +
 	"\x10SubmitJobRequest\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12\x18\n" +
-	"\apayload\x18\x02 \x01(\tR\apayload\"B\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\x12\x15\n" +
+	"\x06run_at\x18\x03 \x01(\tR\x05runAt\x12#\n" +
+	"\rdelay_seconds\x18\x04 \x01(\x03R\fdelaySeconds\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x14\n" +
+	"\x05queue\x18\x06 \x01(\tR\x05queue\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\x129\n" +
+	"\fretry_policy\x18\b \x01(\v2\x16.scheduler.RetryPolicyR\vretryPolicy\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\t \x01(\x03R\ttimeoutMs\"\xa8\x01\n" +
+	"\vRetryPolicy\x12!\n" +
+	"\fmax_attempts\x18\x01 \x01(\x05R\vmaxAttempts\x12\"\n" +
+	"\rbase_delay_ms\x18\x02 \x01(\x03R\vbaseDelayMs\x12 \n" +
+	"\fmax_delay_ms\x18\x03 \x01(\x03R\n" +
+	"maxDelayMs\x12\x18\n" +
+	"\abackoff\x18\x04 \x01(\tR\abackoff\x12\x16\n" +
+	"\x06jitter\x18\x05 \x01(\x01R\x06jitter\"`\n" +
 	"\x11SubmitJobResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"&\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
+	"\tduplicate\x18\x03 \x01(\bR\tduplicate\"D\n" +
+	"\x11SubmitJobsRequest\x12/\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x1b.scheduler.SubmitJobRequestR\x04jobs\"K\n" +
+	"\x12SubmitJobsResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.scheduler.SubmitJobsResultR\aresults\"u\n" +
+	"\x10SubmitJobsResult\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1c\n" +
+	"\tduplicate\x18\x03 \x01(\bR\tduplicate\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"&\n" +
 	"\rGetJobRequest\x12\x15\n" +
-	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xf5\x01\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xbb\x05\n" +
 	"\x0eGetJobResponse\x12\x15\n" +
 	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
@@ -577,20 +2870,101 @@ const file_proto_scheduler_proto_rawDesc = "" +
 	"\fcompleted_at\x18\x06 \x01(\tR\vcompletedAt\x12#\n" +
 	"\rerror_message\x18\a \x01(\tR\ferrorMessage\x12\x1f\n" +
 	"\vretry_count\x18\b \x01(\tR\n" +
-	"retryCount\">\n" +
+	"retryCount\x12\x1e\n" +
+	"\vnext_run_at\x18\t \x01(\tR\tnextRunAt\x12\x1a\n" +
+	"\bpriority\x18\n" +
+	" \x01(\x05R\bpriority\x12\x14\n" +
+	"\x05queue\x18\v \x01(\tR\x05queue\x12'\n" +
+	"\x0fidempotency_key\x18\f \x01(\tR\x0eidempotencyKey\x12!\n" +
+	"\freplay_count\x18\r \x01(\x05R\vreplayCount\x12\x1f\n" +
+	"\vreplayed_at\x18\x0e \x01(\tR\n" +
+	"replayedAt\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x0f \x01(\x03R\ttimeoutMs\x12\x1b\n" +
+	"\tlocked_by\x18\x10 \x01(\tR\blockedBy\x12(\n" +
+	"\x10lease_expires_at\x18\x11 \x01(\tR\x0eleaseExpiresAt\x12\x16\n" +
+	"\x06result\x18\x12 \x01(\tR\x06result\x12\x1f\n" +
+	"\bprogress\x18\x13 \x01(\x05H\x00R\bprogress\x88\x01\x01\x12)\n" +
+	"\x10progress_message\x18\x14 \x01(\tR\x0fprogressMessage\x12.\n" +
+	"\x13progress_updated_at\x18\x15 \x01(\tR\x11progressUpdatedAtB\v\n" +
+	"\t_progress\"/\n" +
+	"\x16ListJobAttemptsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xcf\x01\n" +
+	"\n" +
+	"JobAttempt\x12\x18\n" +
+	"\aattempt\x18\x01 \x01(\x05R\aattempt\x12\x16\n" +
+	"\x06worker\x18\x02 \x01(\tR\x06worker\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x04 \x01(\tR\n" +
+	"finishedAt\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x18\n" +
+	"\aoutcome\x18\x06 \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"c\n" +
+	"\x17ListJobAttemptsResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x121\n" +
+	"\battempts\x18\x02 \x03(\v2\x15.scheduler.JobAttemptR\battempts\")\n" +
+	"\x10CancelJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"B\n" +
+	"\x11CancelJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\xe4\x04\n" +
 	"\x0eListJobRequest\x12\x14\n" +
 	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x02 \x01(\x05R\x06offset\"\x93\x01\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12&\n" +
+	"\fmin_priority\x18\x03 \x01(\x05H\x00R\vminPriority\x88\x01\x01\x12\x14\n" +
+	"\x05queue\x18\x04 \x01(\tR\x05queue\x12\x16\n" +
+	"\x06status\x18\x05 \x03(\tR\x06status\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12#\n" +
+	"\rcreated_after\x18\a \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\b \x01(\tR\rcreatedBefore\x12'\n" +
+	"\x0fcompleted_after\x18\t \x01(\tR\x0ecompletedAfter\x12)\n" +
+	"\x10completed_before\x18\n" +
+	" \x01(\tR\x0fcompletedBefore\x12$\n" +
+	"\vmin_retries\x18\v \x01(\x05H\x01R\n" +
+	"minRetries\x88\x01\x01\x12$\n" +
+	"\vmax_retries\x18\f \x01(\x05H\x02R\n" +
+	"maxRetries\x88\x01\x01\x12%\n" +
+	"\x0eerror_contains\x18\r \x01(\tR\rerrorContains\x12\x17\n" +
+	"\asort_by\x18\x0e \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x0f \x01(\tR\tsortOrder\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x10 \x01(\tR\tpageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x11 \x01(\tR\n" +
+	"totalCountB\x0f\n" +
+	"\r_min_priorityB\x0e\n" +
+	"\f_min_retriesB\x0e\n" +
+	"\f_max_retries\"\xb4\x01\n" +
 	"\x12PaginationMetaData\x12!\n" +
 	"\fcurrent_page\x18\x01 \x01(\x05R\vcurrentPage\x12\x1f\n" +
 	"\vtotal_pages\x18\x02 \x01(\x05R\n" +
 	"totalPages\x12#\n" +
 	"\rtotal_records\x18\x03 \x01(\x03R\ftotalRecords\x12\x14\n" +
-	"\x05limit\x18\x04 \x01(\x05R\x05limit\"s\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vtotal_count\x18\x05 \x01(\tR\n" +
+	"totalCount\"\x9b\x01\n" +
 	"\x0fListJobResponse\x12-\n" +
 	"\x04jobs\x18\x01 \x03(\v2\x19.scheduler.GetJobResponseR\x04jobs\x121\n" +
-	"\x04meta\x18\x02 \x01(\v2\x1d.scheduler.PaginationMetaDataR\x04meta\"\x14\n" +
-	"\x12GetJobStatsRequest\"\xc3\x01\n" +
+	"\x04meta\x18\x02 \x01(\v2\x1d.scheduler.PaginationMetaDataR\x04meta\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"F\n" +
+	"\x13RetryDeadJobRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\"h\n" +
+	"\x14RetryDeadJobResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
+	"\freplay_count\x18\x03 \x01(\x05R\vreplayCount\"\xb2\x01\n" +
+	"\x14RetryDeadJobsRequest\x12\x17\n" +
+	"\ajob_ids\x18\x01 \x03(\tR\x06jobIds\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12%\n" +
+	"\x0eerror_contains\x18\x03 \x01(\tR\rerrorContains\x12!\n" +
+	"\ffailed_after\x18\x04 \x01(\tR\vfailedAfter\x12#\n" +
+	"\rfailed_before\x18\x05 \x01(\tR\ffailedBefore\"0\n" +
+	"\x15RetryDeadJobsResponse\x12\x17\n" +
+	"\ajob_ids\x18\x01 \x03(\tR\x06jobIds\"\x14\n" +
+	"\x12GetJobStatsRequest\"\xaa\x02\n" +
 	"\x14GetJobStatusResponse\x12\x1d\n" +
 	"\n" +
 	"total_jobs\x18\x01 \x01(\x03R\ttotalJobs\x12!\n" +
@@ -598,14 +2972,130 @@ const file_proto_scheduler_proto_rawDesc = "" +
 	"\frunning_jobs\x18\x03 \x01(\x03R\vrunningJobs\x12\x1f\n" +
 	"\vfailed_jobs\x18\x04 \x01(\x03R\n" +
 	"failedJobs\x12%\n" +
-	"\x0ecompleted_jobs\x18\x05 \x01(\x03R\rcompletedJobs2\xda\x03\n" +
+	"\x0ecompleted_jobs\x18\x05 \x01(\x03R\rcompletedJobs\x12%\n" +
+	"\x0ecancelled_jobs\x18\x06 \x01(\x03R\rcancelledJobs\x12!\n" +
+	"\fskipped_jobs\x18\a \x01(\x03R\vskippedJobs\x12\x1b\n" +
+	"\tdead_jobs\x18\b \x01(\x03R\bdeadJobs\"\x92\x01\n" +
+	"\x16GetJobTypeStatsRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12%\n" +
+	"\x0ewindow_seconds\x18\x02 \x01(\x03R\rwindowSeconds\x12*\n" +
+	"\x0ebucket_seconds\x18\x03 \x01(\x03H\x00R\rbucketSeconds\x88\x01\x01B\x11\n" +
+	"\x0f_bucket_seconds\"\xa1\x03\n" +
+	"\x0eJobWindowStats\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\x03R\tcompleted\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x03R\x06failed\x12\x18\n" +
+	"\astarted\x18\x05 \x01(\x03R\astarted\x122\n" +
+	"\x15throughput_per_minute\x18\x06 \x01(\x01R\x13throughputPerMinute\x12.\n" +
+	"\x13throughput_per_hour\x18\a \x01(\x01R\x11throughputPerHour\x12#\n" +
+	"\rsuccess_ratio\x18\b \x01(\x01R\fsuccessRatio\x12\x1e\n" +
+	"\vavg_wait_ms\x18\t \x01(\x03R\tavgWaitMs\x12\x1e\n" +
+	"\vp95_wait_ms\x18\n" +
+	" \x01(\x03R\tp95WaitMs\x12&\n" +
+	"\x0favg_duration_ms\x18\v \x01(\x03R\ravgDurationMs\x12&\n" +
+	"\x0fp95_duration_ms\x18\f \x01(\x03R\rp95DurationMs\"\x88\x01\n" +
+	"\fJobTypeStats\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12/\n" +
+	"\x05total\x18\x02 \x01(\v2\x19.scheduler.JobWindowStatsR\x05total\x123\n" +
+	"\abuckets\x18\x03 \x03(\v2\x19.scheduler.JobWindowStatsR\abuckets\"H\n" +
+	"\x17GetJobTypeStatsResponse\x12-\n" +
+	"\x05types\x18\x01 \x03(\v2\x17.scheduler.JobTypeStatsR\x05types\"\xd1\x01\n" +
+	"\x15CreateScheduleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tcron_expr\x18\x02 \x01(\tR\bcronExpr\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12\x19\n" +
+	"\bjob_type\x18\x04 \x01(\tR\ajobType\x12)\n" +
+	"\x10payload_template\x18\x05 \x01(\tR\x0fpayloadTemplate\x12%\n" +
+	"\x0ecatchup_policy\x18\x06 \x01(\tR\rcatchupPolicy\"\xe4\x02\n" +
+	"\x10ScheduleResponse\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tcron_expr\x18\x03 \x01(\tR\bcronExpr\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12\x19\n" +
+	"\bjob_type\x18\x05 \x01(\tR\ajobType\x12)\n" +
+	"\x10payload_template\x18\x06 \x01(\tR\x0fpayloadTemplate\x12%\n" +
+	"\x0ecatchup_policy\x18\a \x01(\tR\rcatchupPolicy\x12\x16\n" +
+	"\x06paused\x18\b \x01(\bR\x06paused\x12\x1e\n" +
+	"\vnext_run_at\x18\t \x01(\tR\tnextRunAt\x12\x1e\n" +
+	"\vlast_run_at\x18\n" +
+	" \x01(\tR\tlastRunAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\"\x16\n" +
+	"\x14ListSchedulesRequest\"R\n" +
+	"\x15ListSchedulesResponse\x129\n" +
+	"\tschedules\x18\x01 \x03(\v2\x1b.scheduler.ScheduleResponseR\tschedules\"O\n" +
+	"\x14PauseScheduleRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12\x16\n" +
+	"\x06resume\x18\x02 \x01(\bR\x06resume\"8\n" +
+	"\x15DeleteScheduleRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\"\x18\n" +
+	"\x16DeleteScheduleResponse\"\xf8\x01\n" +
+	"\vWorkflowJob\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x04 \x03(\tR\tdependsOn\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x14\n" +
+	"\x05queue\x18\x06 \x01(\tR\x05queue\x129\n" +
+	"\fretry_policy\x18\a \x01(\v2\x16.scheduler.RetryPolicyR\vretryPolicy\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\b \x01(\x03R\ttimeoutMs\"W\n" +
+	"\x15SubmitWorkflowRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12*\n" +
+	"\x04jobs\x18\x02 \x03(\v2\x16.scheduler.WorkflowJobR\x04jobs\"\xac\x01\n" +
+	"\x11WorkflowJobStatus\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x06 \x03(\tR\tdependsOn\"k\n" +
+	"\x16SubmitWorkflowResponse\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x120\n" +
+	"\x04jobs\x18\x02 \x03(\v2\x1c.scheduler.WorkflowJobStatusR\x04jobs\"5\n" +
+	"\x12GetWorkflowRequest\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\"\xcb\x02\n" +
+	"\x13GetWorkflowResponse\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x120\n" +
+	"\x04jobs\x18\x05 \x03(\v2\x1c.scheduler.WorkflowJobStatusR\x04jobs\x12U\n" +
+	"\rstatus_counts\x18\x06 \x03(\v20.scheduler.GetWorkflowResponse.StatusCountsEntryR\fstatusCounts\x1a?\n" +
+	"\x11StatusCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x012\xa3\x0f\n" +
 	"\fJobScheduler\x12[\n" +
-	"\tSubmitJob\x12\x1b.scheduler.SubmitJobRequest\x1a\x1c.scheduler.SubmitJobResponse\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/v1/jobs\x12X\n" +
-	"\x06GetJob\x12\x18.scheduler.GetJobRequest\x1a\x19.scheduler.GetJobResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/jobs/{job_id}\x12S\n" +
+	"\tSubmitJob\x12\x1b.scheduler.SubmitJobRequest\x1a\x1c.scheduler.SubmitJobResponse\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/v1/jobs\x12d\n" +
+	"\n" +
+	"SubmitJobs\x12\x1c.scheduler.SubmitJobsRequest\x1a\x1d.scheduler.SubmitJobsResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/jobs/batch\x12X\n" +
+	"\x06GetJob\x12\x18.scheduler.GetJobRequest\x1a\x19.scheduler.GetJobResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/jobs/{job_id}\x12b\n" +
+	"\bWatchJob\x12\x18.scheduler.GetJobRequest\x1a\x19.scheduler.GetJobResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/jobs/{job_id}/watch0\x01\x12|\n" +
+	"\x0fListJobAttempts\x12!.scheduler.ListJobAttemptsRequest\x1a\".scheduler.ListJobAttemptsResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/jobs/{job_id}/attempts\x12k\n" +
+	"\tCancelJob\x12\x1b.scheduler.CancelJobRequest\x1a\x1c.scheduler.CancelJobResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/jobs/{job_id}/cancel\x12S\n" +
 	"\bListJobs\x12\x19.scheduler.ListJobRequest\x1a\x1a.scheduler.ListJobResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
 	"\x12\b/v1/jobs\x12`\n" +
-	"\vGetJobStats\x12\x1d.scheduler.GetJobStatsRequest\x1a\x1f.scheduler.GetJobStatusResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/stats\x12\\\n" +
-	"\fListDeadJobs\x12\x19.scheduler.ListJobRequest\x1a\x1a.scheduler.ListJobResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/jobs/deadB.Z,github.com/bhanuprakaash/job-scheduler/protob\x06proto3"
+	"\vGetJobStats\x12\x1d.scheduler.GetJobStatsRequest\x1a\x1f.scheduler.GetJobStatusResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/stats\x12q\n" +
+	"\x0fGetJobTypeStats\x12!.scheduler.GetJobTypeStatsRequest\x1a\".scheduler.GetJobTypeStatsResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/stats/types\x12\\\n" +
+	"\fListDeadJobs\x12\x19.scheduler.ListJobRequest\x1a\x1a.scheduler.ListJobResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/jobs/dead\x12x\n" +
+	"\fRetryDeadJob\x12\x1e.scheduler.RetryDeadJobRequest\x1a\x1f.scheduler.RetryDeadJobResponse\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/jobs/dead/{job_id}/retry\x12r\n" +
+	"\rRetryDeadJobs\x12\x1f.scheduler.RetryDeadJobsRequest\x1a .scheduler.RetryDeadJobsResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/v1/jobs/dead/retry\x12i\n" +
+	"\x0eCreateSchedule\x12 .scheduler.CreateScheduleRequest\x1a\x1b.scheduler.ScheduleResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/schedules\x12i\n" +
+	"\rListSchedules\x12\x1f.scheduler.ListSchedulesRequest\x1a .scheduler.ListSchedulesResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/schedules\x12{\n" +
+	"\rPauseSchedule\x12\x1f.scheduler.PauseScheduleRequest\x1a\x1b.scheduler.ScheduleResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/schedules/{schedule_id}/pause\x12z\n" +
+	"\x0eDeleteSchedule\x12 .scheduler.DeleteScheduleRequest\x1a!.scheduler.DeleteScheduleResponse\"#\x82\xd3\xe4\x93\x02\x1d*\x1b/v1/schedules/{schedule_id}\x12o\n" +
+	"\x0eSubmitWorkflow\x12 .scheduler.SubmitWorkflowRequest\x1a!.scheduler.SubmitWorkflowResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/workflows\x12q\n" +
+	"\vGetWorkflow\x12\x1d.scheduler.GetWorkflowRequest\x1a\x1e.scheduler.GetWorkflowResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/workflows/{workflow_id}B.Z,github.com/bhanuprakaash/job-scheduler/protob\x06proto3"
 
 var (
 	file_proto_scheduler_proto_rawDescOnce sync.Once
@@ -619,36 +3109,106 @@ func file_proto_scheduler_proto_rawDescGZIP() []byte {
 	return file_proto_scheduler_proto_rawDescData
 }
 
-var file_proto_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_scheduler_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_proto_scheduler_proto_goTypes = []any{
-	(*SubmitJobRequest)(nil),     // 0: scheduler.SubmitJobRequest
-	(*SubmitJobResponse)(nil),    // 1: scheduler.SubmitJobResponse
-	(*GetJobRequest)(nil),        // 2: scheduler.GetJobRequest
-	(*GetJobResponse)(nil),       // 3: scheduler.GetJobResponse
-	(*ListJobRequest)(nil),       // 4: scheduler.ListJobRequest
-	(*PaginationMetaData)(nil),   // 5: scheduler.PaginationMetaData
-	(*ListJobResponse)(nil),      // 6: scheduler.ListJobResponse
-	(*GetJobStatsRequest)(nil),   // 7: scheduler.GetJobStatsRequest
-	(*GetJobStatusResponse)(nil), // 8: scheduler.GetJobStatusResponse
+	(*SubmitJobRequest)(nil),        // 0: scheduler.SubmitJobRequest
+	(*RetryPolicy)(nil),             // 1: scheduler.RetryPolicy
+	(*SubmitJobResponse)(nil),       // 2: scheduler.SubmitJobResponse
+	(*SubmitJobsRequest)(nil),       // 3: scheduler.SubmitJobsRequest
+	(*SubmitJobsResponse)(nil),      // 4: scheduler.SubmitJobsResponse
+	(*SubmitJobsResult)(nil),        // 5: scheduler.SubmitJobsResult
+	(*GetJobRequest)(nil),           // 6: scheduler.GetJobRequest
+	(*GetJobResponse)(nil),          // 7: scheduler.GetJobResponse
+	(*ListJobAttemptsRequest)(nil),  // 8: scheduler.ListJobAttemptsRequest
+	(*JobAttempt)(nil),              // 9: scheduler.JobAttempt
+	(*ListJobAttemptsResponse)(nil), // 10: scheduler.ListJobAttemptsResponse
+	(*CancelJobRequest)(nil),        // 11: scheduler.CancelJobRequest
+	(*CancelJobResponse)(nil),       // 12: scheduler.CancelJobResponse
+	(*ListJobRequest)(nil),          // 13: scheduler.ListJobRequest
+	(*PaginationMetaData)(nil),      // 14: scheduler.PaginationMetaData
+	(*ListJobResponse)(nil),         // 15: scheduler.ListJobResponse
+	(*RetryDeadJobRequest)(nil),     // 16: scheduler.RetryDeadJobRequest
+	(*RetryDeadJobResponse)(nil),    // 17: scheduler.RetryDeadJobResponse
+	(*RetryDeadJobsRequest)(nil),    // 18: scheduler.RetryDeadJobsRequest
+	(*RetryDeadJobsResponse)(nil),   // 19: scheduler.RetryDeadJobsResponse
+	(*GetJobStatsRequest)(nil),      // 20: scheduler.GetJobStatsRequest
+	(*GetJobStatusResponse)(nil),    // 21: scheduler.GetJobStatusResponse
+	(*GetJobTypeStatsRequest)(nil),  // 22: scheduler.GetJobTypeStatsRequest
+	(*JobWindowStats)(nil),          // 23: scheduler.JobWindowStats
+	(*JobTypeStats)(nil),            // 24: scheduler.JobTypeStats
+	(*GetJobTypeStatsResponse)(nil), // 25: scheduler.GetJobTypeStatsResponse
+	(*CreateScheduleRequest)(nil),   // 26: scheduler.CreateScheduleRequest
+	(*ScheduleResponse)(nil),        // 27: scheduler.ScheduleResponse
+	(*ListSchedulesRequest)(nil),    // 28: scheduler.ListSchedulesRequest
+	(*ListSchedulesResponse)(nil),   // 29: scheduler.ListSchedulesResponse
+	(*PauseScheduleRequest)(nil),    // 30: scheduler.PauseScheduleRequest
+	(*DeleteScheduleRequest)(nil),   // 31: scheduler.DeleteScheduleRequest
+	(*DeleteScheduleResponse)(nil),  // 32: scheduler.DeleteScheduleResponse
+	(*WorkflowJob)(nil),             // 33: scheduler.WorkflowJob
+	(*SubmitWorkflowRequest)(nil),   // 34: scheduler.SubmitWorkflowRequest
+	(*WorkflowJobStatus)(nil),       // 35: scheduler.WorkflowJobStatus
+	(*SubmitWorkflowResponse)(nil),  // 36: scheduler.SubmitWorkflowResponse
+	(*GetWorkflowRequest)(nil),      // 37: scheduler.GetWorkflowRequest
+	(*GetWorkflowResponse)(nil),     // 38: scheduler.GetWorkflowResponse
+	nil,                             // 39: scheduler.GetWorkflowResponse.StatusCountsEntry
 }
 var file_proto_scheduler_proto_depIdxs = []int32{
-	3, // 0: scheduler.ListJobResponse.jobs:type_name -> scheduler.GetJobResponse
-	5, // 1: scheduler.ListJobResponse.meta:type_name -> scheduler.PaginationMetaData
-	0, // 2: scheduler.JobScheduler.SubmitJob:input_type -> scheduler.SubmitJobRequest
-	2, // 3: scheduler.JobScheduler.GetJob:input_type -> scheduler.GetJobRequest
-	4, // 4: scheduler.JobScheduler.ListJobs:input_type -> scheduler.ListJobRequest
-	7, // 5: scheduler.JobScheduler.GetJobStats:input_type -> scheduler.GetJobStatsRequest
-	4, // 6: scheduler.JobScheduler.ListDeadJobs:input_type -> scheduler.ListJobRequest
-	1, // 7: scheduler.JobScheduler.SubmitJob:output_type -> scheduler.SubmitJobResponse
-	3, // 8: scheduler.JobScheduler.GetJob:output_type -> scheduler.GetJobResponse
-	6, // 9: scheduler.JobScheduler.ListJobs:output_type -> scheduler.ListJobResponse
-	8, // 10: scheduler.JobScheduler.GetJobStats:output_type -> scheduler.GetJobStatusResponse
-	6, // 11: scheduler.JobScheduler.ListDeadJobs:output_type -> scheduler.ListJobResponse
-	7, // [7:12] is the sub-list for method output_type
-	2, // [2:7] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1,  // 0: scheduler.SubmitJobRequest.retry_policy:type_name -> scheduler.RetryPolicy
+	0,  // 1: scheduler.SubmitJobsRequest.jobs:type_name -> scheduler.SubmitJobRequest
+	5,  // 2: scheduler.SubmitJobsResponse.results:type_name -> scheduler.SubmitJobsResult
+	9,  // 3: scheduler.ListJobAttemptsResponse.attempts:type_name -> scheduler.JobAttempt
+	7,  // 4: scheduler.ListJobResponse.jobs:type_name -> scheduler.GetJobResponse
+	14, // 5: scheduler.ListJobResponse.meta:type_name -> scheduler.PaginationMetaData
+	23, // 6: scheduler.JobTypeStats.total:type_name -> scheduler.JobWindowStats
+	23, // 7: scheduler.JobTypeStats.buckets:type_name -> scheduler.JobWindowStats
+	24, // 8: scheduler.GetJobTypeStatsResponse.types:type_name -> scheduler.JobTypeStats
+	27, // 9: scheduler.ListSchedulesResponse.schedules:type_name -> scheduler.ScheduleResponse
+	1,  // 10: scheduler.WorkflowJob.retry_policy:type_name -> scheduler.RetryPolicy
+	33, // 11: scheduler.SubmitWorkflowRequest.jobs:type_name -> scheduler.WorkflowJob
+	35, // 12: scheduler.SubmitWorkflowResponse.jobs:type_name -> scheduler.WorkflowJobStatus
+	35, // 13: scheduler.GetWorkflowResponse.jobs:type_name -> scheduler.WorkflowJobStatus
+	39, // 14: scheduler.GetWorkflowResponse.status_counts:type_name -> scheduler.GetWorkflowResponse.StatusCountsEntry
+	0,  // 15: scheduler.JobScheduler.SubmitJob:input_type -> scheduler.SubmitJobRequest
+	3,  // 16: scheduler.JobScheduler.SubmitJobs:input_type -> scheduler.SubmitJobsRequest
+	6,  // 17: scheduler.JobScheduler.GetJob:input_type -> scheduler.GetJobRequest
+	6,  // 18: scheduler.JobScheduler.WatchJob:input_type -> scheduler.GetJobRequest
+	8,  // 19: scheduler.JobScheduler.ListJobAttempts:input_type -> scheduler.ListJobAttemptsRequest
+	11, // 20: scheduler.JobScheduler.CancelJob:input_type -> scheduler.CancelJobRequest
+	13, // 21: scheduler.JobScheduler.ListJobs:input_type -> scheduler.ListJobRequest
+	20, // 22: scheduler.JobScheduler.GetJobStats:input_type -> scheduler.GetJobStatsRequest
+	22, // 23: scheduler.JobScheduler.GetJobTypeStats:input_type -> scheduler.GetJobTypeStatsRequest
+	13, // 24: scheduler.JobScheduler.ListDeadJobs:input_type -> scheduler.ListJobRequest
+	16, // 25: scheduler.JobScheduler.RetryDeadJob:input_type -> scheduler.RetryDeadJobRequest
+	18, // 26: scheduler.JobScheduler.RetryDeadJobs:input_type -> scheduler.RetryDeadJobsRequest
+	26, // 27: scheduler.JobScheduler.CreateSchedule:input_type -> scheduler.CreateScheduleRequest
+	28, // 28: scheduler.JobScheduler.ListSchedules:input_type -> scheduler.ListSchedulesRequest
+	30, // 29: scheduler.JobScheduler.PauseSchedule:input_type -> scheduler.PauseScheduleRequest
+	31, // 30: scheduler.JobScheduler.DeleteSchedule:input_type -> scheduler.DeleteScheduleRequest
+	34, // 31: scheduler.JobScheduler.SubmitWorkflow:input_type -> scheduler.SubmitWorkflowRequest
+	37, // 32: scheduler.JobScheduler.GetWorkflow:input_type -> scheduler.GetWorkflowRequest
+	2,  // 33: scheduler.JobScheduler.SubmitJob:output_type -> scheduler.SubmitJobResponse
+	4,  // 34: scheduler.JobScheduler.SubmitJobs:output_type -> scheduler.SubmitJobsResponse
+	7,  // 35: scheduler.JobScheduler.GetJob:output_type -> scheduler.GetJobResponse
+	7,  // 36: scheduler.JobScheduler.WatchJob:output_type -> scheduler.GetJobResponse
+	10, // 37: scheduler.JobScheduler.ListJobAttempts:output_type -> scheduler.ListJobAttemptsResponse
+	12, // 38: scheduler.JobScheduler.CancelJob:output_type -> scheduler.CancelJobResponse
+	15, // 39: scheduler.JobScheduler.ListJobs:output_type -> scheduler.ListJobResponse
+	21, // 40: scheduler.JobScheduler.GetJobStats:output_type -> scheduler.GetJobStatusResponse
+	25, // 41: scheduler.JobScheduler.GetJobTypeStats:output_type -> scheduler.GetJobTypeStatsResponse
+	15, // 42: scheduler.JobScheduler.ListDeadJobs:output_type -> scheduler.ListJobResponse
+	17, // 43: scheduler.JobScheduler.RetryDeadJob:output_type -> scheduler.RetryDeadJobResponse
+	19, // 44: scheduler.JobScheduler.RetryDeadJobs:output_type -> scheduler.RetryDeadJobsResponse
+	27, // 45: scheduler.JobScheduler.CreateSchedule:output_type -> scheduler.ScheduleResponse
+	29, // 46: scheduler.JobScheduler.ListSchedules:output_type -> scheduler.ListSchedulesResponse
+	27, // 47: scheduler.JobScheduler.PauseSchedule:output_type -> scheduler.ScheduleResponse
+	32, // 48: scheduler.JobScheduler.DeleteSchedule:output_type -> scheduler.DeleteScheduleResponse
+	36, // 49: scheduler.JobScheduler.SubmitWorkflow:output_type -> scheduler.SubmitWorkflowResponse
+	38, // 50: scheduler.JobScheduler.GetWorkflow:output_type -> scheduler.GetWorkflowResponse
+	33, // [33:51] is the sub-list for method output_type
+	15, // [15:33] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_proto_scheduler_proto_init() }
@@ -656,13 +3216,16 @@ func file_proto_scheduler_proto_init() {
 	if File_proto_scheduler_proto != nil {
 		return
 	}
+	file_proto_scheduler_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_scheduler_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_scheduler_proto_msgTypes[22].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_scheduler_proto_rawDesc), len(file_proto_scheduler_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
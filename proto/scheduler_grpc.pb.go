@@ -19,11 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	JobScheduler_SubmitJob_FullMethodName    = "/scheduler.JobScheduler/SubmitJob"
-	JobScheduler_GetJob_FullMethodName       = "/scheduler.JobScheduler/GetJob"
-	JobScheduler_ListJobs_FullMethodName     = "/scheduler.JobScheduler/ListJobs"
-	JobScheduler_GetJobStats_FullMethodName  = "/scheduler.JobScheduler/GetJobStats"
-	JobScheduler_ListDeadJobs_FullMethodName = "/scheduler.JobScheduler/ListDeadJobs"
+	JobScheduler_SubmitJob_FullMethodName       = "/scheduler.JobScheduler/SubmitJob"
+	JobScheduler_SubmitJobs_FullMethodName      = "/scheduler.JobScheduler/SubmitJobs"
+	JobScheduler_GetJob_FullMethodName          = "/scheduler.JobScheduler/GetJob"
+	JobScheduler_WatchJob_FullMethodName        = "/scheduler.JobScheduler/WatchJob"
+	JobScheduler_ListJobAttempts_FullMethodName = "/scheduler.JobScheduler/ListJobAttempts"
+	JobScheduler_CancelJob_FullMethodName       = "/scheduler.JobScheduler/CancelJob"
+	JobScheduler_ListJobs_FullMethodName        = "/scheduler.JobScheduler/ListJobs"
+	JobScheduler_GetJobStats_FullMethodName     = "/scheduler.JobScheduler/GetJobStats"
+	JobScheduler_GetJobTypeStats_FullMethodName = "/scheduler.JobScheduler/GetJobTypeStats"
+	JobScheduler_ListDeadJobs_FullMethodName    = "/scheduler.JobScheduler/ListDeadJobs"
+	JobScheduler_RetryDeadJob_FullMethodName    = "/scheduler.JobScheduler/RetryDeadJob"
+	JobScheduler_RetryDeadJobs_FullMethodName   = "/scheduler.JobScheduler/RetryDeadJobs"
+	JobScheduler_CreateSchedule_FullMethodName  = "/scheduler.JobScheduler/CreateSchedule"
+	JobScheduler_ListSchedules_FullMethodName   = "/scheduler.JobScheduler/ListSchedules"
+	JobScheduler_PauseSchedule_FullMethodName   = "/scheduler.JobScheduler/PauseSchedule"
+	JobScheduler_DeleteSchedule_FullMethodName  = "/scheduler.JobScheduler/DeleteSchedule"
+	JobScheduler_SubmitWorkflow_FullMethodName  = "/scheduler.JobScheduler/SubmitWorkflow"
+	JobScheduler_GetWorkflow_FullMethodName     = "/scheduler.JobScheduler/GetWorkflow"
 )
 
 // JobSchedulerClient is the client API for JobScheduler service.
@@ -38,19 +51,89 @@ const (
 type JobSchedulerClient interface {
 	// SubmitJob enqueues a new job for execution.
 	// It returns the generated Job ID and Status immediately while the job runs in the background.
+	// Set run_at or delay_seconds to defer execution until a later time.
 	SubmitJob(ctx context.Context, in *SubmitJobRequest, opts ...grpc.CallOption) (*SubmitJobResponse, error)
+	// SubmitJobs submits many jobs in one request and one database round trip. Each job
+	// is validated like SubmitJob; an invalid job is reported in its result and does not
+	// prevent the others from being created. Results are in the order of the request.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned if the request is empty or holds more than 1000 jobs.
+	SubmitJobs(ctx context.Context, in *SubmitJobsRequest, opts ...grpc.CallOption) (*SubmitJobsResponse, error)
 	// GetJob retrieves the current status and details of a specific job.
 	// Use this to poll for completion or to retrieve the output of a finished job.
 	// Errors:
 	//   - NOT_FOUND: Returned if the provided job_id does not exist in the store.
 	//   - INVALID_ARGUMENT: Returned if the job_id is malformed.
 	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error)
-	// For the Jobs Table (Pagination)
+	// WatchJob streams the job every time its status or progress changes, starting with
+	// its current state, and ends once the job has finished. A job that is dead-lettered
+	// while being watched is reported once more with status "dead".
+	// Errors:
+	//   - NOT_FOUND: Returned if the job does not exist.
+	//   - INVALID_ARGUMENT: Returned if the job_id is malformed.
+	WatchJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetJobResponse], error)
+	// ListJobAttempts returns every attempt of a job, oldest first: the worker that ran it,
+	// when it started and finished, and how it ended. The history of a dead-lettered job is
+	// kept under the same job_id.
+	// Errors:
+	//   - NOT_FOUND: Returned if the job does not exist.
+	//   - INVALID_ARGUMENT: Returned if the job_id is malformed.
+	ListJobAttempts(ctx context.Context, in *ListJobAttemptsRequest, opts ...grpc.CallOption) (*ListJobAttemptsResponse, error)
+	// CancelJob cancels a pending or running job. A pending job is never dispatched; for a
+	// running job the worker cancels the context passed to the handler. Cancelled jobs are
+	// not retried, and jobs depending on them are skipped.
+	// Errors:
+	//   - NOT_FOUND: Returned if the job does not exist.
+	//   - FAILED_PRECONDITION: Returned if the job already finished.
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*CancelJobResponse, error)
+	// For the Jobs Table (Pagination). Every filter of ListJobRequest can be passed in the
+	// query string, e.g. /v1/jobs?status=failed&status=pending&type=notification:email&sort_by=retry_count
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for an unknown status, sort field or a malformed time bound,
+	//    or a page_token that is invalid or was issued for another sort order.
 	ListJobs(ctx context.Context, in *ListJobRequest, opts ...grpc.CallOption) (*ListJobResponse, error)
 	// Dashboard Stats (Pulse)
 	GetJobStats(ctx context.Context, in *GetJobStatsRequest, opts ...grpc.CallOption) (*GetJobStatusResponse, error)
-	// For the Dead Jobs Table
+	// GetJobTypeStats breaks the jobs of the last window down by type: throughput, success
+	// ratio, and average and p95 queue wait and execution duration, for the whole window
+	// and for each bucket of it, so they can be charted.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for a negative window or bucket, or too many buckets.
+	GetJobTypeStats(ctx context.Context, in *GetJobTypeStatsRequest, opts ...grpc.CallOption) (*GetJobTypeStatsResponse, error)
+	// For the Dead Jobs Table. Accepts the filters of ListJobs except status and the created
+	// range; the completed range and the completed_at sort apply to the time the job was
+	// dead-lettered.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for an unsupported filter, unknown sort field or a malformed time bound.
 	ListDeadJobs(ctx context.Context, in *ListJobRequest, opts ...grpc.CallOption) (*ListJobResponse, error)
+	// RetryDeadJob moves a dead-lettered job back into the queue under its original id with a
+	// reset retry count, optionally replacing its payload. Workflow jobs skipped because of it
+	// are restored.
+	// Errors:
+	//   - NOT_FOUND: Returned if no dead job has the given id.
+	//   - INVALID_ARGUMENT: Returned if the replacement payload is not valid JSON.
+	RetryDeadJob(ctx context.Context, in *RetryDeadJobRequest, opts ...grpc.CallOption) (*RetryDeadJobResponse, error)
+	// RetryDeadJobs requeues every dead-lettered job matching the request. At least one
+	// criterion is required.
+	RetryDeadJobs(ctx context.Context, in *RetryDeadJobsRequest, opts ...grpc.CallOption) (*RetryDeadJobsResponse, error)
+	// CreateSchedule registers a cron schedule that enqueues a job of job_type on every tick.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for an unknown job type, cron expression, time zone or catch-up policy.
+	CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	// PauseSchedule stops a schedule from enqueueing jobs, or resumes it when resume is set.
+	// A resumed schedule continues from its next tick; ticks that fell in the pause are not run.
+	PauseSchedule(ctx context.Context, in *PauseScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error)
+	// SubmitWorkflow submits a graph of jobs atomically. A job is only claimed once every
+	// job it depends on has completed; if one of them fails or is dead-lettered, the jobs
+	// depending on it are skipped.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for an unknown job type, a duplicate key, a dependency on
+	//    an unknown key or a dependency cycle.
+	SubmitWorkflow(ctx context.Context, in *SubmitWorkflowRequest, opts ...grpc.CallOption) (*SubmitWorkflowResponse, error)
+	// GetWorkflow reports the status of every job in a workflow and of the workflow as a whole.
+	GetWorkflow(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*GetWorkflowResponse, error)
 }
 
 type jobSchedulerClient struct {
@@ -71,6 +154,16 @@ func (c *jobSchedulerClient) SubmitJob(ctx context.Context, in *SubmitJobRequest
 	return out, nil
 }
 
+func (c *jobSchedulerClient) SubmitJobs(ctx context.Context, in *SubmitJobsRequest, opts ...grpc.CallOption) (*SubmitJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitJobsResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_SubmitJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *jobSchedulerClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*GetJobResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetJobResponse)
@@ -81,6 +174,45 @@ func (c *jobSchedulerClient) GetJob(ctx context.Context, in *GetJobRequest, opts
 	return out, nil
 }
 
+func (c *jobSchedulerClient) WatchJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetJobResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JobScheduler_ServiceDesc.Streams[0], JobScheduler_WatchJob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetJobRequest, GetJobResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobScheduler_WatchJobClient = grpc.ServerStreamingClient[GetJobResponse]
+
+func (c *jobSchedulerClient) ListJobAttempts(ctx context.Context, in *ListJobAttemptsRequest, opts ...grpc.CallOption) (*ListJobAttemptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJobAttemptsResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_ListJobAttempts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobSchedulerClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*CancelJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelJobResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_CancelJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *jobSchedulerClient) ListJobs(ctx context.Context, in *ListJobRequest, opts ...grpc.CallOption) (*ListJobResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListJobResponse)
@@ -101,6 +233,16 @@ func (c *jobSchedulerClient) GetJobStats(ctx context.Context, in *GetJobStatsReq
 	return out, nil
 }
 
+func (c *jobSchedulerClient) GetJobTypeStats(ctx context.Context, in *GetJobTypeStatsRequest, opts ...grpc.CallOption) (*GetJobTypeStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJobTypeStatsResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_GetJobTypeStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *jobSchedulerClient) ListDeadJobs(ctx context.Context, in *ListJobRequest, opts ...grpc.CallOption) (*ListJobResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListJobResponse)
@@ -111,6 +253,86 @@ func (c *jobSchedulerClient) ListDeadJobs(ctx context.Context, in *ListJobReques
 	return out, nil
 }
 
+func (c *jobSchedulerClient) RetryDeadJob(ctx context.Context, in *RetryDeadJobRequest, opts ...grpc.CallOption) (*RetryDeadJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryDeadJobResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_RetryDeadJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobSchedulerClient) RetryDeadJobs(ctx context.Context, in *RetryDeadJobsRequest, opts ...grpc.CallOption) (*RetryDeadJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryDeadJobsResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_RetryDeadJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobSchedulerClient) CreateSchedule(ctx context.Context, in *CreateScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_CreateSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobSchedulerClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_ListSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobSchedulerClient) PauseSchedule(ctx context.Context, in *PauseScheduleRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_PauseSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobSchedulerClient) DeleteSchedule(ctx context.Context, in *DeleteScheduleRequest, opts ...grpc.CallOption) (*DeleteScheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteScheduleResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_DeleteSchedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobSchedulerClient) SubmitWorkflow(ctx context.Context, in *SubmitWorkflowRequest, opts ...grpc.CallOption) (*SubmitWorkflowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitWorkflowResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_SubmitWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobSchedulerClient) GetWorkflow(ctx context.Context, in *GetWorkflowRequest, opts ...grpc.CallOption) (*GetWorkflowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWorkflowResponse)
+	err := c.cc.Invoke(ctx, JobScheduler_GetWorkflow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // JobSchedulerServer is the server API for JobScheduler service.
 // All implementations must embed UnimplementedJobSchedulerServer
 // for forward compatibility.
@@ -123,19 +345,89 @@ func (c *jobSchedulerClient) ListDeadJobs(ctx context.Context, in *ListJobReques
 type JobSchedulerServer interface {
 	// SubmitJob enqueues a new job for execution.
 	// It returns the generated Job ID and Status immediately while the job runs in the background.
+	// Set run_at or delay_seconds to defer execution until a later time.
 	SubmitJob(context.Context, *SubmitJobRequest) (*SubmitJobResponse, error)
+	// SubmitJobs submits many jobs in one request and one database round trip. Each job
+	// is validated like SubmitJob; an invalid job is reported in its result and does not
+	// prevent the others from being created. Results are in the order of the request.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned if the request is empty or holds more than 1000 jobs.
+	SubmitJobs(context.Context, *SubmitJobsRequest) (*SubmitJobsResponse, error)
 	// GetJob retrieves the current status and details of a specific job.
 	// Use this to poll for completion or to retrieve the output of a finished job.
 	// Errors:
 	//   - NOT_FOUND: Returned if the provided job_id does not exist in the store.
 	//   - INVALID_ARGUMENT: Returned if the job_id is malformed.
 	GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error)
-	// For the Jobs Table (Pagination)
+	// WatchJob streams the job every time its status or progress changes, starting with
+	// its current state, and ends once the job has finished. A job that is dead-lettered
+	// while being watched is reported once more with status "dead".
+	// Errors:
+	//   - NOT_FOUND: Returned if the job does not exist.
+	//   - INVALID_ARGUMENT: Returned if the job_id is malformed.
+	WatchJob(*GetJobRequest, grpc.ServerStreamingServer[GetJobResponse]) error
+	// ListJobAttempts returns every attempt of a job, oldest first: the worker that ran it,
+	// when it started and finished, and how it ended. The history of a dead-lettered job is
+	// kept under the same job_id.
+	// Errors:
+	//   - NOT_FOUND: Returned if the job does not exist.
+	//   - INVALID_ARGUMENT: Returned if the job_id is malformed.
+	ListJobAttempts(context.Context, *ListJobAttemptsRequest) (*ListJobAttemptsResponse, error)
+	// CancelJob cancels a pending or running job. A pending job is never dispatched; for a
+	// running job the worker cancels the context passed to the handler. Cancelled jobs are
+	// not retried, and jobs depending on them are skipped.
+	// Errors:
+	//   - NOT_FOUND: Returned if the job does not exist.
+	//   - FAILED_PRECONDITION: Returned if the job already finished.
+	CancelJob(context.Context, *CancelJobRequest) (*CancelJobResponse, error)
+	// For the Jobs Table (Pagination). Every filter of ListJobRequest can be passed in the
+	// query string, e.g. /v1/jobs?status=failed&status=pending&type=notification:email&sort_by=retry_count
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for an unknown status, sort field or a malformed time bound,
+	//    or a page_token that is invalid or was issued for another sort order.
 	ListJobs(context.Context, *ListJobRequest) (*ListJobResponse, error)
 	// Dashboard Stats (Pulse)
 	GetJobStats(context.Context, *GetJobStatsRequest) (*GetJobStatusResponse, error)
-	// For the Dead Jobs Table
+	// GetJobTypeStats breaks the jobs of the last window down by type: throughput, success
+	// ratio, and average and p95 queue wait and execution duration, for the whole window
+	// and for each bucket of it, so they can be charted.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for a negative window or bucket, or too many buckets.
+	GetJobTypeStats(context.Context, *GetJobTypeStatsRequest) (*GetJobTypeStatsResponse, error)
+	// For the Dead Jobs Table. Accepts the filters of ListJobs except status and the created
+	// range; the completed range and the completed_at sort apply to the time the job was
+	// dead-lettered.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for an unsupported filter, unknown sort field or a malformed time bound.
 	ListDeadJobs(context.Context, *ListJobRequest) (*ListJobResponse, error)
+	// RetryDeadJob moves a dead-lettered job back into the queue under its original id with a
+	// reset retry count, optionally replacing its payload. Workflow jobs skipped because of it
+	// are restored.
+	// Errors:
+	//   - NOT_FOUND: Returned if no dead job has the given id.
+	//   - INVALID_ARGUMENT: Returned if the replacement payload is not valid JSON.
+	RetryDeadJob(context.Context, *RetryDeadJobRequest) (*RetryDeadJobResponse, error)
+	// RetryDeadJobs requeues every dead-lettered job matching the request. At least one
+	// criterion is required.
+	RetryDeadJobs(context.Context, *RetryDeadJobsRequest) (*RetryDeadJobsResponse, error)
+	// CreateSchedule registers a cron schedule that enqueues a job of job_type on every tick.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for an unknown job type, cron expression, time zone or catch-up policy.
+	CreateSchedule(context.Context, *CreateScheduleRequest) (*ScheduleResponse, error)
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	// PauseSchedule stops a schedule from enqueueing jobs, or resumes it when resume is set.
+	// A resumed schedule continues from its next tick; ticks that fell in the pause are not run.
+	PauseSchedule(context.Context, *PauseScheduleRequest) (*ScheduleResponse, error)
+	DeleteSchedule(context.Context, *DeleteScheduleRequest) (*DeleteScheduleResponse, error)
+	// SubmitWorkflow submits a graph of jobs atomically. A job is only claimed once every
+	// job it depends on has completed; if one of them fails or is dead-lettered, the jobs
+	// depending on it are skipped.
+	// Errors:
+	//   - INVALID_ARGUMENT: Returned for an unknown job type, a duplicate key, a dependency on
+	//    an unknown key or a dependency cycle.
+	SubmitWorkflow(context.Context, *SubmitWorkflowRequest) (*SubmitWorkflowResponse, error)
+	// GetWorkflow reports the status of every job in a workflow and of the workflow as a whole.
+	GetWorkflow(context.Context, *GetWorkflowRequest) (*GetWorkflowResponse, error)
 	mustEmbedUnimplementedJobSchedulerServer()
 }
 
@@ -149,18 +441,57 @@ type UnimplementedJobSchedulerServer struct{}
 func (UnimplementedJobSchedulerServer) SubmitJob(context.Context, *SubmitJobRequest) (*SubmitJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SubmitJob not implemented")
 }
+func (UnimplementedJobSchedulerServer) SubmitJobs(context.Context, *SubmitJobsRequest) (*SubmitJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitJobs not implemented")
+}
 func (UnimplementedJobSchedulerServer) GetJob(context.Context, *GetJobRequest) (*GetJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetJob not implemented")
 }
+func (UnimplementedJobSchedulerServer) WatchJob(*GetJobRequest, grpc.ServerStreamingServer[GetJobResponse]) error {
+	return status.Error(codes.Unimplemented, "method WatchJob not implemented")
+}
+func (UnimplementedJobSchedulerServer) ListJobAttempts(context.Context, *ListJobAttemptsRequest) (*ListJobAttemptsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListJobAttempts not implemented")
+}
+func (UnimplementedJobSchedulerServer) CancelJob(context.Context, *CancelJobRequest) (*CancelJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelJob not implemented")
+}
 func (UnimplementedJobSchedulerServer) ListJobs(context.Context, *ListJobRequest) (*ListJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListJobs not implemented")
 }
 func (UnimplementedJobSchedulerServer) GetJobStats(context.Context, *GetJobStatsRequest) (*GetJobStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetJobStats not implemented")
 }
+func (UnimplementedJobSchedulerServer) GetJobTypeStats(context.Context, *GetJobTypeStatsRequest) (*GetJobTypeStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetJobTypeStats not implemented")
+}
 func (UnimplementedJobSchedulerServer) ListDeadJobs(context.Context, *ListJobRequest) (*ListJobResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListDeadJobs not implemented")
 }
+func (UnimplementedJobSchedulerServer) RetryDeadJob(context.Context, *RetryDeadJobRequest) (*RetryDeadJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RetryDeadJob not implemented")
+}
+func (UnimplementedJobSchedulerServer) RetryDeadJobs(context.Context, *RetryDeadJobsRequest) (*RetryDeadJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RetryDeadJobs not implemented")
+}
+func (UnimplementedJobSchedulerServer) CreateSchedule(context.Context, *CreateScheduleRequest) (*ScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateSchedule not implemented")
+}
+func (UnimplementedJobSchedulerServer) ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedJobSchedulerServer) PauseSchedule(context.Context, *PauseScheduleRequest) (*ScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseSchedule not implemented")
+}
+func (UnimplementedJobSchedulerServer) DeleteSchedule(context.Context, *DeleteScheduleRequest) (*DeleteScheduleResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSchedule not implemented")
+}
+func (UnimplementedJobSchedulerServer) SubmitWorkflow(context.Context, *SubmitWorkflowRequest) (*SubmitWorkflowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SubmitWorkflow not implemented")
+}
+func (UnimplementedJobSchedulerServer) GetWorkflow(context.Context, *GetWorkflowRequest) (*GetWorkflowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWorkflow not implemented")
+}
 func (UnimplementedJobSchedulerServer) mustEmbedUnimplementedJobSchedulerServer() {}
 func (UnimplementedJobSchedulerServer) testEmbeddedByValue()                      {}
 
@@ -200,6 +531,24 @@ func _JobScheduler_SubmitJob_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobScheduler_SubmitJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).SubmitJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_SubmitJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).SubmitJobs(ctx, req.(*SubmitJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _JobScheduler_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetJobRequest)
 	if err := dec(in); err != nil {
@@ -218,6 +567,53 @@ func _JobScheduler_GetJob_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobScheduler_WatchJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobSchedulerServer).WatchJob(m, &grpc.GenericServerStream[GetJobRequest, GetJobResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JobScheduler_WatchJobServer = grpc.ServerStreamingServer[GetJobResponse]
+
+func _JobScheduler_ListJobAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobAttemptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).ListJobAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_ListJobAttempts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).ListJobAttempts(ctx, req.(*ListJobAttemptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobScheduler_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _JobScheduler_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListJobRequest)
 	if err := dec(in); err != nil {
@@ -254,6 +650,24 @@ func _JobScheduler_GetJobStats_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobScheduler_GetJobTypeStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobTypeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).GetJobTypeStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_GetJobTypeStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).GetJobTypeStats(ctx, req.(*GetJobTypeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _JobScheduler_ListDeadJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListJobRequest)
 	if err := dec(in); err != nil {
@@ -272,6 +686,150 @@ func _JobScheduler_ListDeadJobs_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _JobScheduler_RetryDeadJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryDeadJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).RetryDeadJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_RetryDeadJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).RetryDeadJob(ctx, req.(*RetryDeadJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobScheduler_RetryDeadJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryDeadJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).RetryDeadJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_RetryDeadJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).RetryDeadJobs(ctx, req.(*RetryDeadJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobScheduler_CreateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).CreateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_CreateSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).CreateSchedule(ctx, req.(*CreateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobScheduler_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_ListSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobScheduler_PauseSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).PauseSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_PauseSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).PauseSchedule(ctx, req.(*PauseScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobScheduler_DeleteSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).DeleteSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_DeleteSchedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).DeleteSchedule(ctx, req.(*DeleteScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobScheduler_SubmitWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).SubmitWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_SubmitWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).SubmitWorkflow(ctx, req.(*SubmitWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobScheduler_GetWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkflowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobSchedulerServer).GetWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobScheduler_GetWorkflow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobSchedulerServer).GetWorkflow(ctx, req.(*GetWorkflowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // JobScheduler_ServiceDesc is the grpc.ServiceDesc for JobScheduler service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -283,10 +841,22 @@ var JobScheduler_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitJob",
 			Handler:    _JobScheduler_SubmitJob_Handler,
 		},
+		{
+			MethodName: "SubmitJobs",
+			Handler:    _JobScheduler_SubmitJobs_Handler,
+		},
 		{
 			MethodName: "GetJob",
 			Handler:    _JobScheduler_GetJob_Handler,
 		},
+		{
+			MethodName: "ListJobAttempts",
+			Handler:    _JobScheduler_ListJobAttempts_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _JobScheduler_CancelJob_Handler,
+		},
 		{
 			MethodName: "ListJobs",
 			Handler:    _JobScheduler_ListJobs_Handler,
@@ -295,11 +865,53 @@ var JobScheduler_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetJobStats",
 			Handler:    _JobScheduler_GetJobStats_Handler,
 		},
+		{
+			MethodName: "GetJobTypeStats",
+			Handler:    _JobScheduler_GetJobTypeStats_Handler,
+		},
 		{
 			MethodName: "ListDeadJobs",
 			Handler:    _JobScheduler_ListDeadJobs_Handler,
 		},
+		{
+			MethodName: "RetryDeadJob",
+			Handler:    _JobScheduler_RetryDeadJob_Handler,
+		},
+		{
+			MethodName: "RetryDeadJobs",
+			Handler:    _JobScheduler_RetryDeadJobs_Handler,
+		},
+		{
+			MethodName: "CreateSchedule",
+			Handler:    _JobScheduler_CreateSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _JobScheduler_ListSchedules_Handler,
+		},
+		{
+			MethodName: "PauseSchedule",
+			Handler:    _JobScheduler_PauseSchedule_Handler,
+		},
+		{
+			MethodName: "DeleteSchedule",
+			Handler:    _JobScheduler_DeleteSchedule_Handler,
+		},
+		{
+			MethodName: "SubmitWorkflow",
+			Handler:    _JobScheduler_SubmitWorkflow_Handler,
+		},
+		{
+			MethodName: "GetWorkflow",
+			Handler:    _JobScheduler_GetWorkflow_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchJob",
+			Handler:       _JobScheduler_WatchJob_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/scheduler.proto",
 }